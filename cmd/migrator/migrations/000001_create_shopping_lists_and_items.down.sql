@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS items;
+DROP TABLE IF EXISTS shopping_lists;
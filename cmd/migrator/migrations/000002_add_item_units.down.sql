@@ -0,0 +1,7 @@
+ALTER TABLE items DROP CONSTRAINT IF EXISTS chk_items_unit;
+
+ALTER TABLE items DROP COLUMN IF EXISTS unit;
+
+ALTER TABLE items
+    ALTER COLUMN quantity TYPE BIGINT USING ROUND(quantity)::BIGINT,
+    ALTER COLUMN quantity SET DEFAULT 1;
@@ -0,0 +1,9 @@
+ALTER TABLE items
+    ALTER COLUMN quantity TYPE NUMERIC(12,3) USING quantity::NUMERIC(12,3),
+    ALTER COLUMN quantity SET DEFAULT 1;
+
+ALTER TABLE items
+    ADD COLUMN IF NOT EXISTS unit VARCHAR(16) NOT NULL DEFAULT 'pieces';
+
+ALTER TABLE items
+    ADD CONSTRAINT chk_items_unit CHECK (unit IN ('pieces', 'g', 'kg', 'ml', 'l', 'oz', 'lb', 'pack'));
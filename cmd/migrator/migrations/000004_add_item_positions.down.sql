@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_items_shopping_list_position;
+
+ALTER TABLE items DROP COLUMN IF EXISTS position;
@@ -0,0 +1,13 @@
+ALTER TABLE items
+    ADD COLUMN IF NOT EXISTS position INTEGER NOT NULL DEFAULT 0;
+
+-- Number existing items in creation order within each list
+UPDATE items
+SET position = ordered.row_number - 1
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY shopping_list_id ORDER BY created_at, id) AS row_number
+    FROM items
+) AS ordered
+WHERE items.id = ordered.id;
+
+CREATE INDEX IF NOT EXISTS idx_items_shopping_list_position ON items(shopping_list_id, position);
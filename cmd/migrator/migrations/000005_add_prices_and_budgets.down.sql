@@ -0,0 +1,7 @@
+ALTER TABLE shopping_lists
+    DROP COLUMN IF EXISTS currency,
+    DROP COLUMN IF EXISTS budget;
+
+ALTER TABLE items
+    DROP COLUMN IF EXISTS currency,
+    DROP COLUMN IF EXISTS unit_price;
@@ -0,0 +1,8 @@
+-- Money amounts are stored in the currency's minor unit (e.g. cents)
+ALTER TABLE items
+    ADD COLUMN IF NOT EXISTS unit_price BIGINT CHECK (unit_price >= 0),
+    ADD COLUMN IF NOT EXISTS currency VARCHAR(3);
+
+ALTER TABLE shopping_lists
+    ADD COLUMN IF NOT EXISTS budget BIGINT CHECK (budget >= 0),
+    ADD COLUMN IF NOT EXISTS currency VARCHAR(3);
@@ -0,0 +1,5 @@
+ALTER TABLE items
+    DROP COLUMN IF EXISTS version;
+
+ALTER TABLE shopping_lists
+    DROP COLUMN IF EXISTS version;
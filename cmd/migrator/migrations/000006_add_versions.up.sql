@@ -0,0 +1,6 @@
+-- Versions back optimistic concurrency control; every update bumps them
+ALTER TABLE shopping_lists
+    ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE items
+    ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
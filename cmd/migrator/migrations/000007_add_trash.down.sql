@@ -0,0 +1,9 @@
+-- Trashed rows would otherwise reappear as live data
+DELETE FROM items WHERE deleted_at IS NOT NULL;
+DELETE FROM shopping_lists WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_items_deleted_at;
+DROP INDEX IF EXISTS idx_shopping_lists_deleted_at;
+
+ALTER TABLE items DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE shopping_lists DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,9 @@
+-- Deleted lists and items stay in the trash until they are restored or purged
+ALTER TABLE shopping_lists
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+ALTER TABLE items
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_shopping_lists_deleted_at ON shopping_lists(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_items_deleted_at ON items(deleted_at);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS template_items;
+
+DROP TABLE IF EXISTS templates;
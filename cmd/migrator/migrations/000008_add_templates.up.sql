@@ -0,0 +1,24 @@
+-- Templates are reusable blueprints for shopping lists and are kept apart from live lists
+CREATE TABLE IF NOT EXISTS templates (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT,
+    budget BIGINT CHECK (budget >= 0),
+    currency VARCHAR(3),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS template_items (
+    id UUID PRIMARY KEY,
+    template_id UUID NOT NULL REFERENCES templates(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    quantity NUMERIC(12,3) DEFAULT 1,
+    unit VARCHAR(16) NOT NULL DEFAULT 'pieces',
+    category_id UUID REFERENCES categories(id) ON DELETE SET NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    unit_price BIGINT CHECK (unit_price >= 0),
+    currency VARCHAR(3)
+);
+
+CREATE INDEX IF NOT EXISTS idx_template_items_template_id ON template_items(template_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_templates_next_run_at;
+
+ALTER TABLE templates
+    DROP COLUMN IF EXISTS next_run_at,
+    DROP COLUMN IF EXISTS recurrence;
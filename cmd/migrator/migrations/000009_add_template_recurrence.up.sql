@@ -0,0 +1,6 @@
+-- Recurring templates create a new shopping list whenever next_run_at comes due
+ALTER TABLE templates
+    ADD COLUMN IF NOT EXISTS recurrence VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS next_run_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_templates_next_run_at ON templates(next_run_at);
@@ -0,0 +1,14 @@
+-- Search vectors are generated from the searchable columns and kept in sync by PostgreSQL
+ALTER TABLE shopping_lists
+    ADD COLUMN IF NOT EXISTS search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('english'::regconfig, COALESCE(name, '')), 'A') ||
+        setweight(to_tsvector('english'::regconfig, COALESCE(description, '')), 'B')
+    ) STORED;
+
+ALTER TABLE items
+    ADD COLUMN IF NOT EXISTS search_vector TSVECTOR GENERATED ALWAYS AS (
+        to_tsvector('english'::regconfig, COALESCE(name, ''))
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_shopping_lists_search_vector ON shopping_lists USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_items_search_vector ON items USING GIN (search_vector);
@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_items_search_vector;
+ALTER TABLE items DROP COLUMN IF EXISTS search_vector;
+
+ALTER TABLE items
+    ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+        to_tsvector('english'::regconfig, COALESCE(name, ''))
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_items_search_vector ON items USING GIN (search_vector);
+
+ALTER TABLE items
+    DROP COLUMN IF EXISTS size,
+    DROP COLUMN IF EXISTS brand,
+    DROP COLUMN IF EXISTS notes;
@@ -0,0 +1,18 @@
+-- Notes, brand and size tell apart variants of the same product and are searchable
+ALTER TABLE items
+    ADD COLUMN IF NOT EXISTS notes TEXT CHECK (char_length(notes) <= 1000),
+    ADD COLUMN IF NOT EXISTS brand VARCHAR(100),
+    ADD COLUMN IF NOT EXISTS size VARCHAR(50);
+
+-- Generated columns cannot change their expression, so the item search vector is rebuilt
+DROP INDEX IF EXISTS idx_items_search_vector;
+ALTER TABLE items DROP COLUMN IF EXISTS search_vector;
+
+ALTER TABLE items
+    ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('english'::regconfig, COALESCE(name, '')), 'A') ||
+        setweight(to_tsvector('english'::regconfig, COALESCE(brand, '')), 'B') ||
+        setweight(to_tsvector('english'::regconfig, COALESCE(size, '') || ' ' || COALESCE(notes, '')), 'C')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_items_search_vector ON items USING GIN (search_vector);
@@ -0,0 +1,12 @@
+-- Item images live in blob storage; this table only keeps their metadata and keys
+CREATE TABLE IF NOT EXISTS item_images (
+    item_id UUID PRIMARY KEY REFERENCES items(id) ON DELETE CASCADE,
+    content_type VARCHAR(32) NOT NULL,
+    size BIGINT NOT NULL CHECK (size >= 0),
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    blob_key TEXT NOT NULL,
+    thumbnail_key TEXT NOT NULL,
+    thumbnail_content_type VARCHAR(32) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
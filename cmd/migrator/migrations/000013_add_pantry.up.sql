@@ -0,0 +1,13 @@
+-- The pantry tracks what is already at home, apart from any shopping list
+CREATE TABLE IF NOT EXISTS pantry_items (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL,
+    quantity NUMERIC(12,3) NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    unit VARCHAR(16) NOT NULL DEFAULT 'pieces',
+    location VARCHAR(100),
+    expires_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pantry_items_expires_at ON pantry_items(expires_at);
@@ -0,0 +1,13 @@
+-- Staples are products kept in stock by putting the shortfall on a shopping list
+CREATE TABLE IF NOT EXISTS staples (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL,
+    unit VARCHAR(16) NOT NULL DEFAULT 'pieces',
+    on_hand NUMERIC(12,3) NOT NULL DEFAULT 0 CHECK (on_hand >= 0),
+    min_quantity NUMERIC(12,3) NOT NULL CHECK (min_quantity > 0),
+    shopping_list_id UUID NOT NULL REFERENCES shopping_lists(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_staples_shopping_list_id ON staples(shopping_list_id);
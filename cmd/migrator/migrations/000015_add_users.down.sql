@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_staples_owner_id;
+DROP INDEX IF EXISTS idx_pantry_items_owner_id;
+DROP INDEX IF EXISTS idx_templates_owner_id;
+DROP INDEX IF EXISTS idx_shopping_lists_owner_id;
+
+ALTER TABLE staples DROP COLUMN IF EXISTS owner_id;
+ALTER TABLE pantry_items DROP COLUMN IF EXISTS owner_id;
+ALTER TABLE templates DROP COLUMN IF EXISTS owner_id;
+ALTER TABLE shopping_lists DROP COLUMN IF EXISTS owner_id;
+
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,22 @@
+-- Users own shopping lists, templates, pantry items and staples.
+-- Rows created before accounts existed keep a NULL owner until the server assigns them
+-- to the account named by LEGACY_OWNER_EMAIL, on start or when that account registers.
+CREATE TABLE IF NOT EXISTS users (
+    id UUID PRIMARY KEY,
+    email VARCHAR(254) NOT NULL,
+    password_hash TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users(email);
+
+ALTER TABLE shopping_lists ADD COLUMN IF NOT EXISTS owner_id UUID REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE templates ADD COLUMN IF NOT EXISTS owner_id UUID REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE pantry_items ADD COLUMN IF NOT EXISTS owner_id UUID REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE staples ADD COLUMN IF NOT EXISTS owner_id UUID REFERENCES users(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_shopping_lists_owner_id ON shopping_lists(owner_id);
+CREATE INDEX IF NOT EXISTS idx_templates_owner_id ON templates(owner_id);
+CREATE INDEX IF NOT EXISTS idx_pantry_items_owner_id ON pantry_items(owner_id);
+CREATE INDEX IF NOT EXISTS idx_staples_owner_id ON staples(owner_id);
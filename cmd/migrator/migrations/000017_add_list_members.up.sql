@@ -0,0 +1,12 @@
+-- List members share a shopping list with other users in the viewer, editor or owner role;
+-- the user a list belongs to is its owner without a membership
+CREATE TABLE IF NOT EXISTS list_members (
+    shopping_list_id UUID NOT NULL REFERENCES shopping_lists(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(16) NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (shopping_list_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_list_members_user_id ON list_members(user_id);
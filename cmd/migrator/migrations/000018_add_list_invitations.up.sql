@@ -0,0 +1,14 @@
+-- Invitations let whoever holds their token join a shopping list; only a SHA-256 hash of each token is stored
+CREATE TABLE IF NOT EXISTS list_invitations (
+    id UUID PRIMARY KEY,
+    shopping_list_id UUID NOT NULL REFERENCES shopping_lists(id) ON DELETE CASCADE,
+    token_hash CHAR(64) NOT NULL,
+    role VARCHAR(16) NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+    max_uses INTEGER CHECK (max_uses > 0),
+    uses INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_list_invitations_token_hash ON list_invitations(token_hash);
+CREATE INDEX IF NOT EXISTS idx_list_invitations_shopping_list_id ON list_invitations(shopping_list_id);
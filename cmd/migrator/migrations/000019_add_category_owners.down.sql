@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_categories_owner_id_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_name ON categories(LOWER(name));
+
+ALTER TABLE categories DROP COLUMN IF EXISTS owner_id;
@@ -0,0 +1,6 @@
+-- Categories belong to a user, and names only have to be unique among a user's categories.
+-- Categories created before owners existed are assigned with the rest of the legacy data.
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS owner_id UUID REFERENCES users(id) ON DELETE CASCADE;
+
+DROP INDEX IF EXISTS idx_categories_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_owner_id_name ON categories(owner_id, LOWER(name));
@@ -0,0 +1 @@
+ALTER TABLE templates DROP COLUMN IF EXISTS time_zone;
@@ -0,0 +1,3 @@
+-- Recurring templates are evaluated in a time zone, so runs keep their local time of day
+-- across daylight saving changes; existing templates keep running in UTC
+ALTER TABLE templates ADD COLUMN IF NOT EXISTS time_zone VARCHAR(64);
@@ -1,8 +1,12 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
+	"time"
+	// Template time zones must load even where the system has no zone database
+	_ "time/tzdata"
 
 	"github.com/gin-gonic/gin"
 	"github.com/uriberma/go-shopping-list-api/internal/adapters/http/handlers"
@@ -10,6 +14,7 @@ import (
 	"github.com/uriberma/go-shopping-list-api/internal/application/services"
 	"github.com/uriberma/go-shopping-list-api/internal/infrastructure/database"
 	"github.com/uriberma/go-shopping-list-api/internal/infrastructure/persistence"
+	"github.com/uriberma/go-shopping-list-api/internal/infrastructure/storage"
 )
 
 func main() {
@@ -35,14 +40,86 @@ func main() {
 	// Initialize repositories
 	shoppingListRepo := persistence.NewPostgresShoppingListRepository(db)
 	itemRepo := persistence.NewPostgresItemRepository(db)
+	categoryRepo := persistence.NewPostgresCategoryRepository(db)
+	templateRepo := persistence.NewPostgresTemplateRepository(db)
+	searchRepo := persistence.NewSearchRepository(db)
+	itemImageRepo := persistence.NewPostgresItemImageRepository(db)
+	pantryRepo := persistence.NewPostgresPantryRepository(db)
+	stapleRepo := persistence.NewPostgresStapleRepository(db)
+	userRepo := persistence.NewPostgresUserRepository(db)
+	apiKeyRepo := persistence.NewPostgresAPIKeyRepository(db)
+	listMemberRepo := persistence.NewPostgresListMemberRepository(db)
+	invitationRepo := persistence.NewPostgresListInvitationRepository(db)
+	transactor := persistence.NewGormTransactor(db)
+
+	// Item images are kept on the local filesystem
+	blobStore, err := storage.NewLocalBlobStore(getEnv("BLOB_STORAGE_DIR", "./data/blobs"))
+	if err != nil {
+		log.Fatalf("Failed to open blob storage: %v", err)
+	}
+
+	// Access tokens are short-lived; refresh tokens renew them
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if jwtSecret == "" {
+		log.Fatal("JWT_SECRET must be set")
+	}
+	authConfig := services.AuthConfig{
+		Secret:     []byte(jwtSecret),
+		AccessTTL:  getDurationEnv("JWT_ACCESS_TTL", 15*time.Minute),
+		RefreshTTL: getDurationEnv("JWT_REFRESH_TTL", 7*24*time.Hour),
+		// Lists and other data created before accounts existed go to this account once it registers
+		LegacyOwnerEmail: os.Getenv("LEGACY_OWNER_EMAIL"),
+	}
 
 	// Initialize services
-	shoppingListService := services.NewShoppingListService(shoppingListRepo, itemRepo)
-	itemService := services.NewItemService(itemRepo, shoppingListRepo)
+	shoppingListService := services.NewShoppingListService(shoppingListRepo, itemRepo, categoryRepo, transactor)
+	itemService := services.NewItemService(itemRepo, shoppingListRepo, categoryRepo, pantryRepo, transactor)
+	categoryService := services.NewCategoryService(categoryRepo)
+	trashService := services.NewTrashService(shoppingListRepo, itemRepo, itemImageRepo, blobStore, transactor)
+	templateService := services.NewTemplateService(templateRepo, shoppingListRepo, itemRepo, categoryRepo, transactor, time.Now)
+	searchService := services.NewSearchService(searchRepo)
+	itemImageService := services.NewItemImageService(itemImageRepo, itemRepo, shoppingListRepo, blobStore)
+	pantryService := services.NewPantryService(pantryRepo)
+	replenishmentService := services.NewReplenishmentService(stapleRepo, shoppingListRepo, itemService, transactor)
+	recipeService := services.NewRecipeService(shoppingListRepo, itemService)
+	authService := services.NewAuthService(userRepo, authConfig, time.Now)
+	apiKeyService := services.NewAPIKeyService(apiKeyRepo, time.Now)
+	listMemberService := services.NewListMemberService(shoppingListRepo, listMemberRepo, userRepo)
+	invitationService := services.NewInvitationService(shoppingListRepo, listMemberRepo, invitationRepo, transactor, time.Now)
+
+	// Hand data created before accounts existed to the legacy owner, which the operator registered beforehand
+	adopted, err := authService.AdoptLegacyData(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to assign legacy data: %v", err)
+	}
+	if adopted > 0 {
+		log.Printf("Assigned %d records created before accounts existed to %s", adopted, authConfig.LegacyOwnerEmail)
+	}
+
+	// Purge trashed lists and items once they are older than the retention period
+	trashRetention := getDurationEnv("TRASH_RETENTION", 30*24*time.Hour)
+	trashPurgeInterval := getDurationEnv("TRASH_PURGE_INTERVAL", time.Hour)
+	go purgeTrashPeriodically(trashService, trashRetention, trashPurgeInterval)
+
+	// Create the lists of recurring templates as they come due
+	recurringListsInterval := getDurationEnv("RECURRING_LISTS_INTERVAL", time.Minute)
+	go materializeRecurringListsPeriodically(templateService, recurringListsInterval)
 
 	// Initialize handlers
 	shoppingListHandler := handlers.NewShoppingListHandler(shoppingListService)
 	itemHandler := handlers.NewItemHandler(itemService)
+	categoryHandler := handlers.NewCategoryHandler(categoryService)
+	trashHandler := handlers.NewTrashHandler(trashService)
+	templateHandler := handlers.NewTemplateHandler(templateService)
+	searchHandler := handlers.NewSearchHandler(searchService)
+	itemImageHandler := handlers.NewItemImageHandler(itemImageService)
+	pantryHandler := handlers.NewPantryHandler(pantryService)
+	stapleHandler := handlers.NewStapleHandler(replenishmentService)
+	recipeHandler := handlers.NewRecipeHandler(recipeService)
+	authHandler := handlers.NewAuthHandler(authService, apiKeyService)
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyService)
+	listMemberHandler := handlers.NewListMemberHandler(listMemberService)
+	invitationHandler := handlers.NewInvitationHandler(invitationService)
 
 	// Setup Gin router
 	router := gin.Default()
@@ -62,7 +139,23 @@ func main() {
 	})
 
 	// Setup routes
-	routes.SetupRoutes(router, shoppingListHandler, itemHandler)
+	routes.SetupRoutes(
+		router,
+		shoppingListHandler,
+		itemHandler,
+		categoryHandler,
+		trashHandler,
+		templateHandler,
+		searchHandler,
+		itemImageHandler,
+		pantryHandler,
+		stapleHandler,
+		recipeHandler,
+		authHandler,
+		apiKeyHandler,
+		listMemberHandler,
+		invitationHandler,
+	)
 
 	// Start server
 	port := getEnv("PORT", "8080")
@@ -79,3 +172,49 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+// getDurationEnv gets a duration environment variable such as "72h" with a fallback value
+func getDurationEnv(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Fatalf("Invalid duration for %s: %q", key, value)
+	}
+	return duration
+}
+
+// purgeTrashPeriodically purges expired trash entries now and then once per interval
+func purgeTrashPeriodically(service *services.TrashService, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		lists, items, err := service.PurgeTrash(context.Background(), retention)
+		if err != nil {
+			log.Printf("Failed to purge trash: %v", err)
+		} else if lists > 0 || items > 0 {
+			log.Printf("Purged %d shopping lists and %d items from the trash", lists, items)
+		}
+		<-ticker.C
+	}
+}
+
+// materializeRecurringListsPeriodically creates the lists of due recurring templates now and then once per interval
+func materializeRecurringListsPeriodically(service *services.TemplateService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		created, err := service.MaterializeDueTemplates(context.Background())
+		if err != nil {
+			log.Printf("Failed to create recurring shopping lists: %v", err)
+		}
+		if created > 0 {
+			log.Printf("Created %d recurring shopping lists", created)
+		}
+		<-ticker.C
+	}
+}
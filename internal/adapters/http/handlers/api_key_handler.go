@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/application/services"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// APIKeyHandler handles HTTP requests for API keys
+type APIKeyHandler struct {
+	service services.APIKeyServiceInterface
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(service services.APIKeyServiceInterface) *APIKeyHandler {
+	return &APIKeyHandler{service: service}
+}
+
+// CreateAPIKeyRequest represents the request body for creating an API key
+type CreateAPIKeyRequest struct {
+	Name      string               `json:"name" binding:"required"`
+	Scope     entities.APIKeyScope `json:"scope"`
+	ExpiresAt *time.Time           `json:"expires_at"`
+}
+
+// CreatedAPIKeyResponse represents a new API key along with the key itself, which is only shown once
+type CreatedAPIKeyResponse struct {
+	*entities.APIKey
+	Key string `json:"key"`
+}
+
+// CreateAPIKey creates an API key for the caller
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	var req CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	apiKey, key, err := h.service.CreateAPIKey(c.Request.Context(), services.CreateAPIKeyInput{
+		Name:      req.Name,
+		Scope:     req.Scope,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		if err == entities.ErrInvalidInput {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create API key"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, CreatedAPIKeyResponse{APIKey: apiKey, Key: key})
+}
+
+// GetAPIKeys retrieves the caller's API keys
+func (h *APIKeyHandler) GetAPIKeys(c *gin.Context) {
+	apiKeys, err := h.service.GetAPIKeys(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve API keys"})
+		return
+	}
+
+	c.JSON(http.StatusOK, apiKeys)
+}
+
+// RevokeAPIKey deletes one of the caller's API keys
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	err = h.service.RevokeAPIKey(c.Request.Context(), id)
+	if err != nil {
+		if err == entities.ErrAPIKeyNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke API key"})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
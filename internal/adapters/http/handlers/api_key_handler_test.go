@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/application/services"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// MockAPIKeyService is a mock implementation of the API key service interface
+type MockAPIKeyService struct {
+	mock.Mock
+}
+
+// Ensure MockAPIKeyService implements the interface
+var _ services.APIKeyServiceInterface = (*MockAPIKeyService)(nil)
+
+func (m *MockAPIKeyService) CreateAPIKey(ctx context.Context, input services.CreateAPIKeyInput) (*entities.APIKey, string, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*entities.APIKey), args.String(1), args.Error(2)
+}
+
+func (m *MockAPIKeyService) GetAPIKeys(ctx context.Context) ([]*entities.APIKey, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyService) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyService) Authenticate(ctx context.Context, key string) (*entities.APIKey, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.APIKey), args.Error(1)
+}
+
+func TestAPIKeyHandler_CreateAPIKey(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(*MockAPIKeyService)
+		expectedStatus int
+	}{
+		{
+			name: "creates an API key",
+			body: `{"name": "Home Assistant", "scope": "read_write"}`,
+			mockSetup: func(m *MockAPIKeyService) {
+				input := services.CreateAPIKeyInput{Name: "Home Assistant", Scope: entities.APIKeyScopeReadWrite}
+				apiKey := &entities.APIKey{ID: uuid.New(), Name: "Home Assistant", Prefix: "slk_abcdefgh", Scope: entities.APIKeyScopeReadWrite}
+				m.On("CreateAPIKey", mock.Anything, input).Return(apiKey, "slk_secret", nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "fails without a name",
+			body:           `{"scope": "read_only"}`,
+			mockSetup:      func(m *MockAPIKeyService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "fails with invalid input",
+			body: `{"name": "Home Assistant", "scope": "admin"}`,
+			mockSetup: func(m *MockAPIKeyService) {
+				m.On("CreateAPIKey", mock.Anything, mock.Anything).Return(nil, "", entities.ErrInvalidInput)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "fails with internal error",
+			body: `{"name": "Home Assistant"}`,
+			mockSetup: func(m *MockAPIKeyService) {
+				m.On("CreateAPIKey", mock.Anything, mock.Anything).Return(nil, "", fmt.Errorf("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockAPIKeyService{}
+			tt.mockSetup(mockService)
+
+			handler := NewAPIKeyHandler(mockService)
+			router := setupTestRouter()
+			router.POST("/api-keys", handler.CreateAPIKey)
+
+			req := httptest.NewRequest(http.MethodPost, "/api-keys", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				var body map[string]interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				assert.Equal(t, "slk_secret", body["key"])
+				assert.Equal(t, "slk_abcdefgh", body["prefix"])
+				assert.NotContains(t, body, "key_hash")
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAPIKeyHandler_GetAndRevokeAPIKeys(t *testing.T) {
+	mockService := &MockAPIKeyService{}
+	apiKey := &entities.APIKey{ID: uuid.New(), Name: "Home Assistant", Scope: entities.APIKeyScopeReadOnly}
+	mockService.On("GetAPIKeys", mock.Anything).Return([]*entities.APIKey{apiKey}, nil)
+	mockService.On("RevokeAPIKey", mock.Anything, apiKey.ID).Return(nil)
+	mockService.On("RevokeAPIKey", mock.Anything, mock.Anything).Return(entities.ErrAPIKeyNotFound)
+
+	handler := NewAPIKeyHandler(mockService)
+	router := setupTestRouter()
+	router.GET("/api-keys", handler.GetAPIKeys)
+	router.DELETE("/api-keys/:id", handler.RevokeAPIKey)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api-keys", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Home Assistant")
+
+	tests := []struct {
+		path           string
+		expectedStatus int
+	}{
+		{path: "/api-keys/" + apiKey.ID.String(), expectedStatus: http.StatusNoContent},
+		{path: "/api-keys/" + uuid.NewString(), expectedStatus: http.StatusNotFound},
+		{path: "/api-keys/not-a-uuid", expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, tt.path, nil))
+		assert.Equal(t, tt.expectedStatus, w.Code, tt.path)
+	}
+}
+
+func TestAPIKeyHandler_RequiresSession(t *testing.T) {
+	userID := uuid.New()
+	readWrite := &entities.APIKey{ID: uuid.New(), UserID: userID, Scope: entities.APIKeyScopeReadWrite}
+	readOnly := &entities.APIKey{ID: uuid.New(), UserID: userID, Scope: entities.APIKeyScopeReadOnly}
+
+	authService := &MockAuthService{}
+	authService.On("Authenticate", mock.Anything, "valid").Return(userID, nil)
+	apiKeyService := &MockAPIKeyService{}
+	apiKeyService.On("Authenticate", mock.Anything, "slk_write").Return(readWrite, nil)
+	apiKeyService.On("Authenticate", mock.Anything, "slk_read").Return(readOnly, nil)
+	created := &entities.APIKey{ID: uuid.New(), Name: "Script", Scope: entities.APIKeyScopeReadWrite}
+	apiKeyService.On("CreateAPIKey", mock.Anything, mock.Anything).Return(created, "slk_secret", nil)
+	apiKeyService.On("GetAPIKeys", mock.Anything).Return([]*entities.APIKey{readWrite}, nil)
+	apiKeyService.On("RevokeAPIKey", mock.Anything, readWrite.ID).Return(nil)
+
+	authHandler := NewAuthHandler(authService, apiKeyService)
+	handler := NewAPIKeyHandler(apiKeyService)
+	router := setupTestRouter()
+	keys := router.Group("/api-keys", authHandler.RequireAuth, authHandler.RequireSession)
+	keys.POST("", handler.CreateAPIKey)
+	keys.GET("", handler.GetAPIKeys)
+	keys.DELETE("/:id", handler.RevokeAPIKey)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		authorization  string
+		expectedStatus int
+	}{
+		{name: "session creates a key", method: http.MethodPost, path: "/api-keys", authorization: "Bearer valid", expectedStatus: http.StatusCreated},
+		{name: "session lists keys", method: http.MethodGet, path: "/api-keys", authorization: "Bearer valid", expectedStatus: http.StatusOK},
+		{name: "session revokes a key", method: http.MethodDelete, path: "/api-keys/" + readWrite.ID.String(), authorization: "Bearer valid", expectedStatus: http.StatusNoContent},
+		{name: "read-write key cannot create a key", method: http.MethodPost, path: "/api-keys", authorization: "ApiKey slk_write", expectedStatus: http.StatusForbidden},
+		{name: "read-write key cannot list keys", method: http.MethodGet, path: "/api-keys", authorization: "ApiKey slk_write", expectedStatus: http.StatusForbidden},
+		{name: "read-write key cannot revoke a key", method: http.MethodDelete, path: "/api-keys/" + readWrite.ID.String(), authorization: "ApiKey slk_write", expectedStatus: http.StatusForbidden},
+		{name: "read-only key cannot list keys", method: http.MethodGet, path: "/api-keys", authorization: "ApiKey slk_read", expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &bytes.Buffer{}
+			if tt.method == http.MethodPost {
+				body = bytes.NewBufferString(`{"name": "Script"}`)
+			}
+			req := httptest.NewRequest(tt.method, tt.path, body)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", tt.authorization)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+
+	// Only the session's requests reached the service
+	apiKeyService.AssertNumberOfCalls(t, "CreateAPIKey", 1)
+	apiKeyService.AssertNumberOfCalls(t, "GetAPIKeys", 1)
+	apiKeyService.AssertNumberOfCalls(t, "RevokeAPIKey", 1)
+}
@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/application/services"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// apiKeyCallerKey marks the requests that RequireAuth authenticated with an API key
+const apiKeyCallerKey = "apiKeyCaller"
+
+// AuthHandler handles HTTP requests for accounts and tokens
+type AuthHandler struct {
+	service services.AuthServiceInterface
+	apiKeys services.APIKeyServiceInterface
+}
+
+// NewAuthHandler creates a new auth handler
+func NewAuthHandler(service services.AuthServiceInterface, apiKeys services.APIKeyServiceInterface) *AuthHandler {
+	return &AuthHandler{service: service, apiKeys: apiKeys}
+}
+
+// CredentialsRequest represents the request body for registering or logging in
+type CredentialsRequest struct {
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// RefreshRequest represents the request body for renewing a token pair
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// TokenResponse represents an issued token pair; ExpiresIn is the access token lifetime in seconds
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// Register creates a user account
+func (h *AuthHandler) Register(c *gin.Context) {
+	var req CredentialsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.service.Register(c.Request.Context(), req.Email, req.Password)
+	if err != nil {
+		if err == entities.ErrDuplicateEmail {
+			c.JSON(http.StatusConflict, gin.H{"error": "Email already registered"})
+			return
+		}
+		if err == entities.ErrInvalidInput {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid email or password"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register user"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, user)
+}
+
+// Login issues a token pair for valid credentials
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req CredentialsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tokens, err := h.service.Login(c.Request.Context(), req.Email, req.Password)
+	if err != nil {
+		if err == entities.ErrInvalidCredentials {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid email or password"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log in"})
+		return
+	}
+
+	c.JSON(http.StatusOK, newTokenResponse(tokens))
+}
+
+// Refresh exchanges a refresh token for a new token pair
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tokens, err := h.service.Refresh(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		if err == entities.ErrInvalidToken {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid refresh token"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh tokens"})
+		return
+	}
+
+	c.JSON(http.StatusOK, newTokenResponse(tokens))
+}
+
+// RequireAuth is middleware that rejects requests without valid credentials and runs the rest
+// of the chain on behalf of their user. It accepts an "Authorization: Bearer" access token or an
+// "Authorization: ApiKey" key; read-only keys are limited to safe methods.
+func (h *AuthHandler) RequireAuth(c *gin.Context) {
+	scheme, credentials, ok := authorization(c.GetHeader("Authorization"))
+	if !ok {
+		abortUnauthorized(c, "Authentication required")
+		return
+	}
+
+	var userID uuid.UUID
+	var err error
+	switch strings.ToLower(scheme) {
+	case "bearer":
+		userID, err = h.service.Authenticate(c.Request.Context(), credentials)
+	case "apikey":
+		var apiKey *entities.APIKey
+		apiKey, err = h.apiKeys.Authenticate(c.Request.Context(), credentials)
+		if err == nil {
+			if !apiKey.AllowsWrites() && !isSafeMethod(c.Request.Method) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "API key is read-only"})
+				return
+			}
+			userID = apiKey.UserID
+			c.Set(apiKeyCallerKey, true)
+		}
+	default:
+		abortUnauthorized(c, "Unsupported authorization scheme")
+		return
+	}
+	if err != nil {
+		if err == entities.ErrInvalidToken {
+			abortUnauthorized(c, "Invalid credentials")
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to authenticate"})
+		return
+	}
+
+	c.Request = c.Request.WithContext(services.WithCaller(c.Request.Context(), userID))
+	c.Next()
+}
+
+// RequireSession is middleware, run after RequireAuth, that rejects callers authenticated with an API key.
+// Routes that manage credentials use it, so that a leaked key cannot list keys or mint ones that outlive it.
+func (h *AuthHandler) RequireSession(c *gin.Context) {
+	if c.GetBool(apiKeyCallerKey) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "API keys cannot be used here; log in instead"})
+		return
+	}
+	c.Next()
+}
+
+// authorization splits an Authorization header into its scheme and credentials
+func authorization(header string) (scheme, credentials string, ok bool) {
+	scheme, credentials, found := strings.Cut(header, " ")
+	credentials = strings.TrimSpace(credentials)
+	return scheme, credentials, found && credentials != ""
+}
+
+// isSafeMethod reports whether an HTTP method only reads
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// abortUnauthorized stops a request that lacks valid credentials
+func abortUnauthorized(c *gin.Context, message string) {
+	c.Writer.Header().Add("WWW-Authenticate", `Bearer realm="api"`)
+	c.Writer.Header().Add("WWW-Authenticate", `ApiKey realm="api"`)
+	c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": message})
+}
+
+// newTokenResponse converts a token pair into its response
+func newTokenResponse(tokens *services.TokenPair) TokenResponse {
+	return TokenResponse{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(tokens.ExpiresIn.Seconds()),
+	}
+}
@@ -0,0 +1,328 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/application/services"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// MockAuthService is a mock implementation of the auth service interface
+type MockAuthService struct {
+	mock.Mock
+}
+
+// Ensure MockAuthService implements the interface
+var _ services.AuthServiceInterface = (*MockAuthService)(nil)
+
+func (m *MockAuthService) Register(ctx context.Context, email, password string) (*entities.User, error) {
+	args := m.Called(ctx, email, password)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.User), args.Error(1)
+}
+
+func (m *MockAuthService) Login(ctx context.Context, email, password string) (*services.TokenPair, error) {
+	args := m.Called(ctx, email, password)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.TokenPair), args.Error(1)
+}
+
+func (m *MockAuthService) Refresh(ctx context.Context, refreshToken string) (*services.TokenPair, error) {
+	args := m.Called(ctx, refreshToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.TokenPair), args.Error(1)
+}
+
+func (m *MockAuthService) Authenticate(ctx context.Context, accessToken string) (uuid.UUID, error) {
+	args := m.Called(ctx, accessToken)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func TestAuthHandler_Register(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(*MockAuthService)
+		expectedStatus int
+	}{
+		{
+			name: "registers a user",
+			body: `{"email": "ana@example.com", "password": "correct horse"}`,
+			mockSetup: func(m *MockAuthService) {
+				m.On("Register", mock.Anything, "ana@example.com", "correct horse").Return(entities.NewUser("ana@example.com", "hash"), nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "fails without a password",
+			body:           `{"email": "ana@example.com"}`,
+			mockSetup:      func(m *MockAuthService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "fails with invalid input",
+			body: `{"email": "ana@example.com", "password": "short"}`,
+			mockSetup: func(m *MockAuthService) {
+				m.On("Register", mock.Anything, mock.Anything, mock.Anything).Return(nil, entities.ErrInvalidInput)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "fails when the email is registered",
+			body: `{"email": "ana@example.com", "password": "correct horse"}`,
+			mockSetup: func(m *MockAuthService) {
+				m.On("Register", mock.Anything, mock.Anything, mock.Anything).Return(nil, entities.ErrDuplicateEmail)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "fails with internal error",
+			body: `{"email": "ana@example.com", "password": "correct horse"}`,
+			mockSetup: func(m *MockAuthService) {
+				m.On("Register", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockAuthService{}
+			tt.mockSetup(mockService)
+
+			handler := NewAuthHandler(mockService, nil)
+			router := setupTestRouter()
+			router.POST("/auth/register", handler.Register)
+
+			req := httptest.NewRequest(http.MethodPost, "/auth/register", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.NotContains(t, w.Body.String(), "hash")
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthHandler_LoginAndRefresh(t *testing.T) {
+	tokens := &services.TokenPair{AccessToken: "access", RefreshToken: "refresh", ExpiresIn: 15 * time.Minute}
+
+	tests := []struct {
+		name           string
+		path           string
+		body           string
+		mockSetup      func(*MockAuthService)
+		expectedStatus int
+	}{
+		{
+			name: "logs in",
+			path: "/auth/login",
+			body: `{"email": "ana@example.com", "password": "correct horse"}`,
+			mockSetup: func(m *MockAuthService) {
+				m.On("Login", mock.Anything, "ana@example.com", "correct horse").Return(tokens, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "fails with wrong credentials",
+			path: "/auth/login",
+			body: `{"email": "ana@example.com", "password": "wrong password"}`,
+			mockSetup: func(m *MockAuthService) {
+				m.On("Login", mock.Anything, mock.Anything, mock.Anything).Return(nil, entities.ErrInvalidCredentials)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "refreshes tokens",
+			path: "/auth/refresh",
+			body: `{"refresh_token": "refresh"}`,
+			mockSetup: func(m *MockAuthService) {
+				m.On("Refresh", mock.Anything, "refresh").Return(tokens, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "fails with an invalid refresh token",
+			path: "/auth/refresh",
+			body: `{"refresh_token": "expired"}`,
+			mockSetup: func(m *MockAuthService) {
+				m.On("Refresh", mock.Anything, "expired").Return(nil, entities.ErrInvalidToken)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "fails without a refresh token",
+			path:           "/auth/refresh",
+			body:           `{}`,
+			mockSetup:      func(m *MockAuthService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockAuthService{}
+			tt.mockSetup(mockService)
+
+			handler := NewAuthHandler(mockService, nil)
+			router := setupTestRouter()
+			router.POST("/auth/login", handler.Login)
+			router.POST("/auth/refresh", handler.Refresh)
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var body TokenResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				assert.Equal(t, TokenResponse{AccessToken: "access", RefreshToken: "refresh", TokenType: "Bearer", ExpiresIn: 900}, body)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthHandler_RequireAuth(t *testing.T) {
+	userID := uuid.New()
+	readOnly := &entities.APIKey{ID: uuid.New(), UserID: userID, Scope: entities.APIKeyScopeReadOnly}
+	readWrite := &entities.APIKey{ID: uuid.New(), UserID: userID, Scope: entities.APIKeyScopeReadWrite}
+
+	tests := []struct {
+		name           string
+		method         string
+		authorization  string
+		mockSetup      func(*MockAuthService)
+		apiKeySetup    func(*MockAPIKeyService)
+		expectedStatus int
+	}{
+		{
+			name:          "passes the caller on",
+			authorization: "Bearer valid",
+			mockSetup: func(m *MockAuthService) {
+				m.On("Authenticate", mock.Anything, "valid").Return(userID, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "fails without a token",
+			mockSetup:      func(m *MockAuthService) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "fails with another scheme",
+			authorization:  "Basic YW5hOnNlY3JldA==",
+			mockSetup:      func(m *MockAuthService) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:          "fails with an invalid token",
+			authorization: "Bearer expired",
+			mockSetup: func(m *MockAuthService) {
+				m.On("Authenticate", mock.Anything, "expired").Return(uuid.Nil, entities.ErrInvalidToken)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:          "reads with a read-only API key",
+			authorization: "ApiKey slk_read",
+			apiKeySetup: func(m *MockAPIKeyService) {
+				m.On("Authenticate", mock.Anything, "slk_read").Return(readOnly, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:          "cannot write with a read-only API key",
+			method:        http.MethodPost,
+			authorization: "ApiKey slk_read",
+			apiKeySetup: func(m *MockAPIKeyService) {
+				m.On("Authenticate", mock.Anything, "slk_read").Return(readOnly, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:          "writes with a read-write API key",
+			method:        http.MethodPost,
+			authorization: "apikey slk_write",
+			apiKeySetup: func(m *MockAPIKeyService) {
+				m.On("Authenticate", mock.Anything, "slk_write").Return(readWrite, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:          "fails with an unknown API key",
+			authorization: "ApiKey slk_unknown",
+			apiKeySetup: func(m *MockAPIKeyService) {
+				m.On("Authenticate", mock.Anything, "slk_unknown").Return(nil, entities.ErrInvalidToken)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockAuthService{}
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockService)
+			}
+			apiKeyService := &MockAPIKeyService{}
+			if tt.apiKeySetup != nil {
+				tt.apiKeySetup(apiKeyService)
+			}
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			handler := NewAuthHandler(mockService, apiKeyService)
+			router := setupTestRouter()
+			router.Handle(method, "/me", handler.RequireAuth, func(c *gin.Context) {
+				caller, ok := services.CallerFromContext(c.Request.Context())
+				assert.True(t, ok)
+				c.JSON(http.StatusOK, gin.H{"id": caller})
+			})
+
+			req := httptest.NewRequest(method, "/me", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			switch tt.expectedStatus {
+			case http.StatusOK:
+				assert.Contains(t, w.Body.String(), userID.String())
+			case http.StatusUnauthorized:
+				assert.Contains(t, w.Header().Values("WWW-Authenticate"), `Bearer realm="api"`)
+			}
+			mockService.AssertExpectations(t)
+			apiKeyService.AssertExpectations(t)
+		})
+	}
+}
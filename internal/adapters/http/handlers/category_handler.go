@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/application/services"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// CategoryHandler handles HTTP requests for item categories
+type CategoryHandler struct {
+	service services.CategoryServiceInterface
+}
+
+// NewCategoryHandler creates a new category handler
+func NewCategoryHandler(service services.CategoryServiceInterface) *CategoryHandler {
+	return &CategoryHandler{service: service}
+}
+
+// CategoryRequest represents the request body for creating or updating a category
+type CategoryRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Position int    `json:"position"`
+}
+
+// CreateCategory creates a new category
+func (h *CategoryHandler) CreateCategory(c *gin.Context) {
+	var req CategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category, err := h.service.CreateCategory(c.Request.Context(), req.Name, req.Position)
+	if err != nil {
+		if err == entities.ErrInvalidInput {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err == entities.ErrDuplicateCategory {
+			c.JSON(http.StatusConflict, gin.H{"error": "Category already exists"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create category"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, category)
+}
+
+// GetCategory retrieves a category by ID
+func (h *CategoryHandler) GetCategory(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	category, err := h.service.GetCategory(c.Request.Context(), id)
+	if err != nil {
+		if err == entities.ErrCategoryNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve category"})
+		return
+	}
+
+	c.JSON(http.StatusOK, category)
+}
+
+// GetAllCategories retrieves all categories in display order
+func (h *CategoryHandler) GetAllCategories(c *gin.Context) {
+	categories, err := h.service.GetAllCategories(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve categories"})
+		return
+	}
+
+	c.JSON(http.StatusOK, categories)
+}
+
+// UpdateCategory updates an existing category
+func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req CategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category, err := h.service.UpdateCategory(c.Request.Context(), id, req.Name, req.Position)
+	if err != nil {
+		if err == entities.ErrCategoryNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+			return
+		}
+		if err == entities.ErrInvalidInput {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err == entities.ErrDuplicateCategory {
+			c.JSON(http.StatusConflict, gin.H{"error": "Category already exists"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update category"})
+		return
+	}
+
+	c.JSON(http.StatusOK, category)
+}
+
+// DeleteCategory deletes a category
+func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	err = h.service.DeleteCategory(c.Request.Context(), id)
+	if err != nil {
+		if err == entities.ErrCategoryNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete category"})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
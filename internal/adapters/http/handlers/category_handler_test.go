@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/application/services"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// MockCategoryService is a mock implementation of the category service interface
+type MockCategoryService struct {
+	mock.Mock
+}
+
+// Ensure MockCategoryService implements the interface
+var _ services.CategoryServiceInterface = (*MockCategoryService)(nil)
+
+func (m *MockCategoryService) CreateCategory(ctx context.Context, name string, position int) (*entities.Category, error) {
+	args := m.Called(ctx, name, position)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Category), args.Error(1)
+}
+
+func (m *MockCategoryService) GetCategory(ctx context.Context, id uuid.UUID) (*entities.Category, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Category), args.Error(1)
+}
+
+func (m *MockCategoryService) GetAllCategories(ctx context.Context) ([]*entities.Category, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Category), args.Error(1)
+}
+
+func (m *MockCategoryService) UpdateCategory(
+	ctx context.Context,
+	id uuid.UUID,
+	name string,
+	position int,
+) (*entities.Category, error) {
+	args := m.Called(ctx, id, name, position)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Category), args.Error(1)
+}
+
+func (m *MockCategoryService) DeleteCategory(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestNewCategoryHandler(t *testing.T) {
+	mockService := &MockCategoryService{}
+	handler := NewCategoryHandler(mockService)
+
+	assert.NotNil(t, handler)
+	assert.Equal(t, mockService, handler.service)
+}
+
+func TestCategoryHandler_CreateCategory(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		mockSetup      func(*MockCategoryService)
+		expectedStatus int
+		expectedBody   func(*testing.T, map[string]interface{})
+	}{
+		{
+			name:        "successfully creates category",
+			requestBody: CategoryRequest{Name: "Produce", Position: 1},
+			mockSetup: func(m *MockCategoryService) {
+				m.On("CreateCategory", mock.Anything, "Produce", 1).Return(entities.NewCategory("Produce", 1), nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Produce", body["name"])
+				assert.Equal(t, float64(1), body["position"])
+			},
+		},
+		{
+			name:           "fails with missing name",
+			requestBody:    map[string]interface{}{"position": 1},
+			mockSetup:      func(m *MockCategoryService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Contains(t, body["error"], "required")
+			},
+		},
+		{
+			name:        "fails with duplicate category",
+			requestBody: CategoryRequest{Name: "Produce"},
+			mockSetup: func(m *MockCategoryService) {
+				m.On("CreateCategory", mock.Anything, "Produce", 0).Return(nil, entities.ErrDuplicateCategory)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Category already exists", body["error"])
+			},
+		},
+		{
+			name:        "fails with internal server error",
+			requestBody: CategoryRequest{Name: "Produce"},
+			mockSetup: func(m *MockCategoryService) {
+				m.On("CreateCategory", mock.Anything, "Produce", 0).Return(nil, fmt.Errorf("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Failed to create category", body["error"])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockCategoryService{}
+			tt.mockSetup(mockService)
+
+			handler := NewCategoryHandler(mockService)
+			router := setupTestRouter()
+			router.POST("/categories", handler.CreateCategory)
+
+			body, err := json.Marshal(tt.requestBody)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/categories", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var responseBody map[string]interface{}
+			err = json.Unmarshal(w.Body.Bytes(), &responseBody)
+			require.NoError(t, err)
+
+			tt.expectedBody(t, responseBody)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCategoryHandler_GetAllCategories(t *testing.T) {
+	mockService := &MockCategoryService{}
+	mockService.On("GetAllCategories", mock.Anything).Return([]*entities.Category{
+		entities.NewCategory("Produce", 1),
+		entities.NewCategory("Dairy", 2),
+	}, nil)
+
+	handler := NewCategoryHandler(mockService)
+	router := setupTestRouter()
+	router.GET("/categories", handler.GetAllCategories)
+
+	req := httptest.NewRequest(http.MethodGet, "/categories", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var responseBody []map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &responseBody))
+	require.Len(t, responseBody, 2)
+	assert.Equal(t, "Produce", responseBody[0]["name"])
+	mockService.AssertExpectations(t)
+}
+
+func TestCategoryHandler_UpdateCategory(t *testing.T) {
+	tests := []struct {
+		name           string
+		categoryID     string
+		mockSetup      func(*MockCategoryService)
+		expectedStatus int
+	}{
+		{
+			name:       "successfully updates category",
+			categoryID: uuid.New().String(),
+			mockSetup: func(m *MockCategoryService) {
+				m.On("UpdateCategory", mock.Anything, mock.AnythingOfType("uuid.UUID"), "Dairy", 3).
+					Return(entities.NewCategory("Dairy", 3), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "fails with invalid UUID",
+			categoryID:     "invalid-uuid",
+			mockSetup:      func(m *MockCategoryService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "fails with not found error",
+			categoryID: uuid.New().String(),
+			mockSetup: func(m *MockCategoryService) {
+				m.On("UpdateCategory", mock.Anything, mock.AnythingOfType("uuid.UUID"), "Dairy", 3).
+					Return(nil, entities.ErrCategoryNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockCategoryService{}
+			tt.mockSetup(mockService)
+
+			handler := NewCategoryHandler(mockService)
+			router := setupTestRouter()
+			router.PUT("/categories/:id", handler.UpdateCategory)
+
+			body, err := json.Marshal(CategoryRequest{Name: "Dairy", Position: 3})
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPut, "/categories/"+tt.categoryID, bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCategoryHandler_DeleteCategory(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "successfully deletes category", expectedStatus: http.StatusNoContent},
+		{name: "fails with not found error", serviceErr: entities.ErrCategoryNotFound, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockCategoryService{}
+			mockService.On("DeleteCategory", mock.Anything, mock.AnythingOfType("uuid.UUID")).Return(tt.serviceErr)
+
+			handler := NewCategoryHandler(mockService)
+			router := setupTestRouter()
+			router.DELETE("/categories/:id", handler.DeleteCategory)
+
+			req := httptest.NewRequest(http.MethodDelete, "/categories/"+uuid.New().String(), nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setETag exposes the version of a resource as its entity tag
+func setETag(c *gin.Context, version int) {
+	c.Header("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// ifMatchVersion reads the version required by the If-Match header.
+// A missing header or "*" requires no particular version. When the header
+// holds no version it cannot match, so 412 is written and ok is false.
+// If-Match uses the strong comparison (RFC 9110), so weak tags never match.
+func ifMatchVersion(c *gin.Context) (version *int, ok bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil, true
+	}
+
+	tag, err := strconv.Unquote(header)
+	if err == nil {
+		if v, err := strconv.Atoi(tag); err == nil {
+			return &v, true
+		}
+	}
+
+	c.JSON(http.StatusPreconditionFailed, gin.H{"error": "If-Match does not match the current version"})
+	return nil, false
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/application/services"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// InvitationHandler handles HTTP requests for invitations to shared shopping lists
+type InvitationHandler struct {
+	service services.InvitationServiceInterface
+}
+
+// NewInvitationHandler creates a new invitation handler
+func NewInvitationHandler(service services.InvitationServiceInterface) *InvitationHandler {
+	return &InvitationHandler{service: service}
+}
+
+// CreateInvitationRequest represents the optional request body for creating an invitation
+type CreateInvitationRequest struct {
+	Role      entities.ListRole `json:"role"`
+	ExpiresAt *time.Time        `json:"expires_at"`
+	MaxUses   *int              `json:"max_uses"`
+}
+
+// CreatedInvitationResponse represents a new invitation along with its token, which is only shown once
+type CreatedInvitationResponse struct {
+	*entities.ListInvitation
+	Token string `json:"token"`
+}
+
+// CreateInvitation creates an invitation to a shopping list
+func (h *InvitationHandler) CreateInvitation(c *gin.Context) {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req CreateInvitationRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	invitation, token, err := h.service.CreateInvitation(c.Request.Context(), listID, services.CreateInvitationInput{
+		Role:      req.Role,
+		ExpiresAt: req.ExpiresAt,
+		MaxUses:   req.MaxUses,
+	})
+	if err != nil {
+		switch err {
+		case entities.ErrShoppingListNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Shopping list not found"})
+		case entities.ErrForbidden:
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only owners can manage invitations"})
+		case entities.ErrInvalidInput:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invitations need a valid role, a future expiry and at least one use"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create invitation"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, CreatedInvitationResponse{ListInvitation: invitation, Token: token})
+}
+
+// GetInvitations retrieves the outstanding invitations to a shopping list
+func (h *InvitationHandler) GetInvitations(c *gin.Context) {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	invitations, err := h.service.GetInvitations(c.Request.Context(), listID)
+	if err != nil {
+		switch err {
+		case entities.ErrShoppingListNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Shopping list not found"})
+		case entities.ErrForbidden:
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only owners can manage invitations"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve invitations"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+// RevokeInvitation deletes an invitation to a shopping list
+func (h *InvitationHandler) RevokeInvitation(c *gin.Context) {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+	id, err := uuid.Parse(c.Param("invitationId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invitation ID format"})
+		return
+	}
+
+	err = h.service.RevokeInvitation(c.Request.Context(), listID, id)
+	if err != nil {
+		switch err {
+		case entities.ErrShoppingListNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Shopping list not found"})
+		case entities.ErrInvitationNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Invitation not found"})
+		case entities.ErrForbidden:
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only owners can manage invitations"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke invitation"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// AcceptInvitation makes the caller a member of the shopping list an invitation is for
+func (h *InvitationHandler) AcceptInvitation(c *gin.Context) {
+	member, err := h.service.AcceptInvitation(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		switch err {
+		case entities.ErrInvitationNotFound, entities.ErrShoppingListNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Invitation not found"})
+		case entities.ErrInvitationExpired:
+			c.JSON(http.StatusGone, gin.H{"error": "Invitation has expired or been used up"})
+		case entities.ErrDuplicateMember:
+			c.JSON(http.StatusConflict, gin.H{"error": "You are already a member of this shopping list"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to accept invitation"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, member)
+}
@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/application/services"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// MockInvitationService is a mock implementation of the invitation service interface
+type MockInvitationService struct {
+	mock.Mock
+}
+
+// Ensure MockInvitationService implements the interface
+var _ services.InvitationServiceInterface = (*MockInvitationService)(nil)
+
+func (m *MockInvitationService) CreateInvitation(
+	ctx context.Context,
+	shoppingListID uuid.UUID,
+	input services.CreateInvitationInput,
+) (*entities.ListInvitation, string, error) {
+	args := m.Called(ctx, shoppingListID, input)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*entities.ListInvitation), args.String(1), args.Error(2)
+}
+
+func (m *MockInvitationService) GetInvitations(ctx context.Context, shoppingListID uuid.UUID) ([]*entities.ListInvitation, error) {
+	args := m.Called(ctx, shoppingListID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.ListInvitation), args.Error(1)
+}
+
+func (m *MockInvitationService) RevokeInvitation(ctx context.Context, shoppingListID, id uuid.UUID) error {
+	args := m.Called(ctx, shoppingListID, id)
+	return args.Error(0)
+}
+
+func (m *MockInvitationService) AcceptInvitation(ctx context.Context, token string) (*entities.ListMember, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ListMember), args.Error(1)
+}
+
+func TestInvitationHandler_CreateInvitation(t *testing.T) {
+	listID := uuid.New()
+	once := 1
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(*MockInvitationService)
+		expectedStatus int
+	}{
+		{
+			name: "creates a single-use invitation",
+			body: `{"role": "editor", "max_uses": 1}`,
+			mockSetup: func(m *MockInvitationService) {
+				input := services.CreateInvitationInput{Role: entities.ListRoleEditor, MaxUses: &once}
+				invitation := &entities.ListInvitation{ID: uuid.New(), ShoppingListID: listID, Role: entities.ListRoleEditor, MaxUses: &once}
+				m.On("CreateInvitation", mock.Anything, listID, input).Return(invitation, "secret", nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "creates an invitation without a body",
+			mockSetup: func(m *MockInvitationService) {
+				invitation := &entities.ListInvitation{ID: uuid.New(), ShoppingListID: listID, Role: entities.ListRoleViewer}
+				m.On("CreateInvitation", mock.Anything, listID, services.CreateInvitationInput{}).Return(invitation, "secret", nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "fails with invalid input",
+			body: `{"max_uses": 0}`,
+			mockSetup: func(m *MockInvitationService) {
+				m.On("CreateInvitation", mock.Anything, listID, mock.Anything).Return(nil, "", entities.ErrInvalidInput)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "fails for non-owners",
+			body: `{"role": "viewer"}`,
+			mockSetup: func(m *MockInvitationService) {
+				m.On("CreateInvitation", mock.Anything, listID, mock.Anything).Return(nil, "", entities.ErrForbidden)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "fails with internal error",
+			body: `{"role": "viewer"}`,
+			mockSetup: func(m *MockInvitationService) {
+				m.On("CreateInvitation", mock.Anything, listID, mock.Anything).Return(nil, "", fmt.Errorf("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockInvitationService{}
+			tt.mockSetup(mockService)
+
+			handler := NewInvitationHandler(mockService)
+			router := setupTestRouter()
+			router.POST("/lists/:id/invitations", handler.CreateInvitation)
+
+			req := httptest.NewRequest(http.MethodPost, "/lists/"+listID.String()+"/invitations", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				var body map[string]interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				assert.Equal(t, "secret", body["token"])
+				assert.NotContains(t, body, "token_hash")
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestInvitationHandler_AcceptInvitation(t *testing.T) {
+	member := entities.NewListMember(uuid.New(), uuid.New(), entities.ListRoleEditor)
+
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "joins the list", expectedStatus: http.StatusCreated},
+		{name: "fails for unknown tokens", err: entities.ErrInvitationNotFound, expectedStatus: http.StatusNotFound},
+		{name: "fails for used up invitations", err: entities.ErrInvitationExpired, expectedStatus: http.StatusGone},
+		{name: "fails for existing members", err: entities.ErrDuplicateMember, expectedStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockInvitationService{}
+			if tt.err != nil {
+				mockService.On("AcceptInvitation", mock.Anything, "secret").Return(nil, tt.err)
+			} else {
+				mockService.On("AcceptInvitation", mock.Anything, "secret").Return(member, nil)
+			}
+
+			handler := NewInvitationHandler(mockService)
+			router := setupTestRouter()
+			router.POST("/invitations/:token/accept", handler.AcceptInvitation)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/invitations/secret/accept", nil))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestInvitationHandler_GetAndRevokeInvitations(t *testing.T) {
+	listID := uuid.New()
+	invitation := &entities.ListInvitation{ID: uuid.New(), ShoppingListID: listID, Role: entities.ListRoleViewer}
+	mockService := &MockInvitationService{}
+	mockService.On("GetInvitations", mock.Anything, listID).Return([]*entities.ListInvitation{invitation}, nil)
+	mockService.On("GetInvitations", mock.Anything, mock.Anything).Return(nil, entities.ErrForbidden)
+	mockService.On("RevokeInvitation", mock.Anything, listID, invitation.ID).Return(nil)
+	mockService.On("RevokeInvitation", mock.Anything, listID, mock.Anything).Return(entities.ErrInvitationNotFound)
+
+	handler := NewInvitationHandler(mockService)
+	router := setupTestRouter()
+	router.GET("/lists/:id/invitations", handler.GetInvitations)
+	router.DELETE("/lists/:id/invitations/:invitationId", handler.RevokeInvitation)
+
+	invitationsPath := "/lists/" + listID.String() + "/invitations"
+	tests := []struct {
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{method: http.MethodGet, path: invitationsPath, expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/lists/" + uuid.NewString() + "/invitations", expectedStatus: http.StatusForbidden},
+		{method: http.MethodDelete, path: invitationsPath + "/" + invitation.ID.String(), expectedStatus: http.StatusNoContent},
+		{method: http.MethodDelete, path: invitationsPath + "/" + uuid.NewString(), expectedStatus: http.StatusNotFound},
+		{method: http.MethodDelete, path: invitationsPath + "/not-a-uuid", expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+		assert.Equal(t, tt.expectedStatus, w.Code, tt.method+" "+tt.path)
+	}
+}
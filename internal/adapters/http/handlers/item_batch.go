@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/application/services"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// BatchItemsRequest represents the request body for applying many item operations at once.
+// Mode is "atomic" (the default) or "best_effort".
+type BatchItemsRequest struct {
+	Mode       services.BatchMode     `json:"mode"`
+	Operations []ItemOperationRequest `json:"operations" binding:"required,dive"`
+}
+
+// ItemOperationRequest represents one operation of an item batch.
+// Op is one of "create", "update", "complete" or "delete"; ID and Version apply to
+// the last three, and the remaining fields to create and update.
+type ItemOperationRequest struct {
+	Op          services.ItemOperationType `json:"op" binding:"required"`
+	ID          uuid.UUID                  `json:"id"`
+	Version     *int                       `json:"version"`
+	Name        string                     `json:"name"`
+	Quantity    float64                    `json:"quantity"`
+	Unit        entities.Unit              `json:"unit"`
+	CategoryID  *uuid.UUID                 `json:"category_id"`
+	UnitPrice   *int64                     `json:"unit_price"`
+	Currency    string                     `json:"currency"`
+	Notes       string                     `json:"notes"`
+	Brand       string                     `json:"brand"`
+	Size        string                     `json:"size"`
+	Completed   bool                       `json:"completed"`
+	OnDuplicate services.DuplicatePolicy   `json:"on_duplicate"`
+}
+
+// ItemOperationResponse represents the outcome of one operation of an item batch.
+// Status is the HTTP status the operation would have had on its own.
+type ItemOperationResponse struct {
+	Index  int                        `json:"index"`
+	Op     services.ItemOperationType `json:"op"`
+	Status int                        `json:"status"`
+	Item   *entities.Item             `json:"item,omitempty"`
+	Error  string                     `json:"error,omitempty"`
+}
+
+// BatchItemsResponse represents the outcome of an item batch
+type BatchItemsResponse struct {
+	Committed bool                    `json:"committed"`
+	Results   []ItemOperationResponse `json:"results"`
+}
+
+// ItemAction dispatches the custom actions on the items of a shopping list, such as items:batch
+func (h *ItemHandler) ItemAction(c *gin.Context) {
+	switch c.Param("action") {
+	case ":batch":
+		h.BatchItems(c)
+	default:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unknown item action"})
+	}
+}
+
+// BatchItems creates, updates, completes and deletes many items of a shopping list in one transaction.
+// An atomic batch with a failed operation stores nothing and answers 422 Unprocessable Entity.
+func (h *ItemHandler) BatchItems(c *gin.Context) {
+	listIDParam := c.Param("listId")
+	listID, err := uuid.Parse(listIDParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid list ID format"})
+		return
+	}
+
+	var req BatchItemsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	operations := make([]services.ItemOperation, len(req.Operations))
+	for i, op := range req.Operations {
+		quantity := op.Quantity
+		if quantity <= 0 {
+			quantity = 1
+		}
+		operations[i] = services.ItemOperation{
+			Type:    op.Op,
+			ItemID:  op.ID,
+			Version: op.Version,
+			Create: services.CreateItemInput{
+				Name:        op.Name,
+				Quantity:    quantity,
+				Unit:        op.Unit,
+				CategoryID:  op.CategoryID,
+				UnitPrice:   op.UnitPrice,
+				Currency:    op.Currency,
+				Notes:       op.Notes,
+				Brand:       op.Brand,
+				Size:        op.Size,
+				OnDuplicate: op.OnDuplicate,
+			},
+			Update: services.UpdateItemInput{
+				Name:       op.Name,
+				Quantity:   quantity,
+				Unit:       op.Unit,
+				CategoryID: op.CategoryID,
+				UnitPrice:  op.UnitPrice,
+				Currency:   op.Currency,
+				Notes:      op.Notes,
+				Brand:      op.Brand,
+				Size:       op.Size,
+				Completed:  op.Completed,
+			},
+		}
+	}
+
+	result, err := h.service.BatchItems(c.Request.Context(), listID, req.Mode, operations)
+	if err != nil {
+		if err == entities.ErrForbidden {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Not allowed to change this shopping list"})
+			return
+		}
+		if err == entities.ErrShoppingListNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Shopping list not found"})
+			return
+		}
+		if err == entities.ErrInvalidInput {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("A batch needs between 1 and %d operations and a valid mode", services.MaxBatchOperations),
+			})
+			return
+		}
+		if err == entities.ErrVersionConflict || err == entities.ErrItemNotFound {
+			c.JSON(http.StatusConflict, gin.H{"error": "Items changed while the batch was applied"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to apply item operations"})
+		return
+	}
+
+	response := BatchItemsResponse{
+		Committed: result.Committed,
+		Results:   make([]ItemOperationResponse, len(result.Results)),
+	}
+	for i, outcome := range result.Results {
+		status, message := itemOperationStatus(req.Operations[i].Op, outcome)
+		response.Results[i] = ItemOperationResponse{
+			Index:  i,
+			Op:     req.Operations[i].Op,
+			Status: status,
+			Item:   outcome.Item,
+			Error:  message,
+		}
+	}
+
+	if !result.Committed {
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// itemOperationStatus maps the outcome of a batch operation to an HTTP status and error message
+func itemOperationStatus(op services.ItemOperationType, outcome services.ItemOperationResult) (int, string) {
+	switch outcome.Err {
+	case nil:
+		if op == services.ItemOpDelete {
+			return http.StatusNoContent, ""
+		}
+		if outcome.Created {
+			return http.StatusCreated, ""
+		}
+		return http.StatusOK, ""
+	case entities.ErrOperationAborted:
+		return http.StatusFailedDependency, "Not applied because another operation failed"
+	case entities.ErrItemNotFound:
+		return http.StatusNotFound, "Item not found"
+	case entities.ErrVersionConflict:
+		return http.StatusPreconditionFailed, "Item has been modified since it was read"
+	case entities.ErrDuplicateItem:
+		return http.StatusConflict, "An open item with this name already exists in the shopping list"
+	case entities.ErrCategoryNotFound:
+		return http.StatusBadRequest, "Category not found"
+	case entities.ErrInvalidInput:
+		return http.StatusBadRequest, outcome.Err.Error()
+	}
+	return http.StatusInternalServerError, "Failed to apply operation"
+}
@@ -0,0 +1,277 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/application/services"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+func TestItemHandler_BatchItems(t *testing.T) {
+	listID := uuid.New()
+	itemID := uuid.New()
+	version := 4
+	created := &entities.Item{ID: uuid.New(), Name: "Butter", Quantity: 1}
+
+	tests := []struct {
+		name           string
+		path           string
+		requestBody    interface{}
+		mockSetup      func(*MockItemService)
+		expectedStatus int
+		expectedBody   func(*testing.T, map[string]interface{})
+	}{
+		{
+			name: "applies every operation",
+			path: "/shopping-lists/" + listID.String() + "/items:batch",
+			requestBody: map[string]interface{}{
+				"operations": []map[string]interface{}{
+					{"op": "create", "name": "Butter"},
+					{"op": "complete", "id": itemID, "version": version},
+					{"op": "delete", "id": itemID},
+				},
+			},
+			mockSetup: func(m *MockItemService) {
+				m.On("BatchItems", mock.Anything, listID, services.BatchMode(""), []services.ItemOperation{
+					{
+						Type:   services.ItemOpCreate,
+						Create: services.CreateItemInput{Name: "Butter", Quantity: 1},
+						Update: services.UpdateItemInput{Name: "Butter", Quantity: 1},
+					},
+					{
+						Type:    services.ItemOpComplete,
+						ItemID:  itemID,
+						Version: &version,
+						Create:  services.CreateItemInput{Quantity: 1},
+						Update:  services.UpdateItemInput{Quantity: 1},
+					},
+					{
+						Type:   services.ItemOpDelete,
+						ItemID: itemID,
+						Create: services.CreateItemInput{Quantity: 1},
+						Update: services.UpdateItemInput{Quantity: 1},
+					},
+				}).Return(&services.ItemBatchResult{
+					Committed: true,
+					Results: []services.ItemOperationResult{
+						{Item: created, Created: true},
+						{Item: &entities.Item{ID: itemID, Name: "Milk"}},
+						{},
+					},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, true, body["committed"])
+				results := body["results"].([]interface{})
+				require.Len(t, results, 3)
+				first := results[0].(map[string]interface{})
+				assert.Equal(t, float64(http.StatusCreated), first["status"])
+				assert.Equal(t, "Butter", first["item"].(map[string]interface{})["name"])
+				assert.Equal(t, float64(http.StatusOK), results[1].(map[string]interface{})["status"])
+				third := results[2].(map[string]interface{})
+				assert.Equal(t, float64(2), third["index"])
+				assert.Equal(t, "delete", third["op"])
+				assert.Equal(t, float64(http.StatusNoContent), third["status"])
+				assert.NotContains(t, third, "item")
+			},
+		},
+		{
+			name: "reports an aborted atomic batch",
+			path: "/shopping-lists/" + listID.String() + "/items:batch",
+			requestBody: map[string]interface{}{
+				"mode": "atomic",
+				"operations": []map[string]interface{}{
+					{"op": "create", "name": "Butter"},
+					{"op": "complete", "id": itemID},
+				},
+			},
+			mockSetup: func(m *MockItemService) {
+				m.On("BatchItems", mock.Anything, listID, services.BatchAtomic, mock.Anything).Return(&services.ItemBatchResult{
+					Results: []services.ItemOperationResult{
+						{Err: entities.ErrOperationAborted},
+						{Err: entities.ErrItemNotFound},
+					},
+				}, nil)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, false, body["committed"])
+				results := body["results"].([]interface{})
+				require.Len(t, results, 2)
+				assert.Equal(t, map[string]interface{}{
+					"index":  float64(0),
+					"op":     "create",
+					"status": float64(http.StatusFailedDependency),
+					"error":  "Not applied because another operation failed",
+				}, results[0])
+				assert.Equal(t, map[string]interface{}{
+					"index":  float64(1),
+					"op":     "complete",
+					"status": float64(http.StatusNotFound),
+					"error":  "Item not found",
+				}, results[1])
+			},
+		},
+		{
+			name:           "unknown action",
+			path:           "/shopping-lists/" + listID.String() + "/items:purge",
+			requestBody:    map[string]interface{}{},
+			mockSetup:      func(m *MockItemService) {},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Unknown item action", body["error"])
+			},
+		},
+		{
+			name:           "invalid list ID",
+			path:           "/shopping-lists/invalid-uuid/items:batch",
+			requestBody:    map[string]interface{}{},
+			mockSetup:      func(m *MockItemService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Invalid list ID format", body["error"])
+			},
+		},
+		{
+			name: "operation without op",
+			path: "/shopping-lists/" + listID.String() + "/items:batch",
+			requestBody: map[string]interface{}{
+				"operations": []map[string]interface{}{{"name": "Butter"}},
+			},
+			mockSetup:      func(m *MockItemService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Contains(t, body["error"], "Op")
+			},
+		},
+		{
+			name: "invalid batch",
+			path: "/shopping-lists/" + listID.String() + "/items:batch",
+			requestBody: map[string]interface{}{
+				"mode":       "eventually",
+				"operations": []map[string]interface{}{{"op": "create", "name": "Butter"}},
+			},
+			mockSetup: func(m *MockItemService) {
+				m.On("BatchItems", mock.Anything, listID, services.BatchMode("eventually"), mock.Anything).
+					Return(nil, entities.ErrInvalidInput)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "A batch needs between 1 and 100 operations and a valid mode", body["error"])
+			},
+		},
+		{
+			name: "shopping list not found",
+			path: "/shopping-lists/" + listID.String() + "/items:batch",
+			requestBody: map[string]interface{}{
+				"operations": []map[string]interface{}{{"op": "create", "name": "Butter"}},
+			},
+			mockSetup: func(m *MockItemService) {
+				m.On("BatchItems", mock.Anything, listID, mock.Anything, mock.Anything).
+					Return(nil, entities.ErrShoppingListNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Shopping list not found", body["error"])
+			},
+		},
+		{
+			name: "concurrent change",
+			path: "/shopping-lists/" + listID.String() + "/items:batch",
+			requestBody: map[string]interface{}{
+				"operations": []map[string]interface{}{{"op": "complete", "id": itemID}},
+			},
+			mockSetup: func(m *MockItemService) {
+				m.On("BatchItems", mock.Anything, listID, mock.Anything, mock.Anything).
+					Return(nil, entities.ErrVersionConflict)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Items changed while the batch was applied", body["error"])
+			},
+		},
+		{
+			name: "internal server error",
+			path: "/shopping-lists/" + listID.String() + "/items:batch",
+			requestBody: map[string]interface{}{
+				"operations": []map[string]interface{}{{"op": "complete", "id": itemID}},
+			},
+			mockSetup: func(m *MockItemService) {
+				m.On("BatchItems", mock.Anything, listID, mock.Anything, mock.Anything).
+					Return(nil, fmt.Errorf("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Failed to apply item operations", body["error"])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockItemService{}
+			tt.mockSetup(mockService)
+
+			handler := NewItemHandler(mockService)
+			router := setupTestRouter()
+			router.POST("/shopping-lists/:listId/items:action", handler.ItemAction)
+
+			body, err := json.Marshal(tt.requestBody)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var responseBody map[string]interface{}
+			err = json.Unmarshal(w.Body.Bytes(), &responseBody)
+			require.NoError(t, err)
+
+			tt.expectedBody(t, responseBody)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemOperationStatus(t *testing.T) {
+	tests := []struct {
+		op              services.ItemOperationType
+		outcome         services.ItemOperationResult
+		expectedStatus  int
+		expectedMessage string
+	}{
+		{services.ItemOpCreate, services.ItemOperationResult{Created: true}, http.StatusCreated, ""},
+		{services.ItemOpCreate, services.ItemOperationResult{}, http.StatusOK, ""},
+		{services.ItemOpDelete, services.ItemOperationResult{}, http.StatusNoContent, ""},
+		{services.ItemOpUpdate, services.ItemOperationResult{Err: entities.ErrVersionConflict},
+			http.StatusPreconditionFailed, "Item has been modified since it was read"},
+		{services.ItemOpCreate, services.ItemOperationResult{Err: entities.ErrDuplicateItem},
+			http.StatusConflict, "An open item with this name already exists in the shopping list"},
+		{services.ItemOpUpdate, services.ItemOperationResult{Err: entities.ErrCategoryNotFound},
+			http.StatusBadRequest, "Category not found"},
+		{services.ItemOpUpdate, services.ItemOperationResult{Err: entities.ErrInvalidInput},
+			http.StatusBadRequest, "invalid input"},
+		{services.ItemOpUpdate, services.ItemOperationResult{Err: fmt.Errorf("database error")},
+			http.StatusInternalServerError, "Failed to apply operation"},
+	}
+
+	for _, tt := range tests {
+		status, message := itemOperationStatus(tt.op, tt.outcome)
+		assert.Equal(t, tt.expectedStatus, status)
+		assert.Equal(t, tt.expectedMessage, message)
+	}
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -19,17 +20,38 @@ func NewItemHandler(service services.ItemServiceInterface) *ItemHandler {
 	return &ItemHandler{service: service}
 }
 
-// CreateItemRequest represents the request body for creating an item
+// CreateItemRequest represents the request body for creating an item.
+// OnDuplicate is one of "allow" (the default), "reject" or "merge".
 type CreateItemRequest struct {
-	Name     string `json:"name" binding:"required"`
-	Quantity int    `json:"quantity"`
+	Name        string                   `json:"name" binding:"required"`
+	Quantity    float64                  `json:"quantity"`
+	Unit        entities.Unit            `json:"unit"`
+	CategoryID  *uuid.UUID               `json:"category_id"`
+	UnitPrice   *int64                   `json:"unit_price"`
+	Currency    string                   `json:"currency"`
+	Notes       string                   `json:"notes"`
+	Brand       string                   `json:"brand"`
+	Size        string                   `json:"size"`
+	OnDuplicate services.DuplicatePolicy `json:"on_duplicate"`
 }
 
 // UpdateItemRequest represents the request body for updating an item
 type UpdateItemRequest struct {
-	Name      string `json:"name" binding:"required"`
-	Quantity  int    `json:"quantity"`
-	Completed bool   `json:"completed"`
+	Name       string        `json:"name" binding:"required"`
+	Quantity   float64       `json:"quantity"`
+	Unit       entities.Unit `json:"unit"`
+	CategoryID *uuid.UUID    `json:"category_id"`
+	UnitPrice  *int64        `json:"unit_price"`
+	Currency   string        `json:"currency"`
+	Notes      string        `json:"notes"`
+	Brand      string        `json:"brand"`
+	Size       string        `json:"size"`
+	Completed  bool          `json:"completed"`
+}
+
+// ReorderItemsRequest represents the request body for reordering the items of a shopping list
+type ReorderItemsRequest struct {
+	ItemIDs []uuid.UUID `json:"item_ids" binding:"required"`
 }
 
 // CreateItem creates a new item in a shopping list
@@ -51,12 +73,35 @@ func (h *ItemHandler) CreateItem(c *gin.Context) {
 		req.Quantity = 1
 	}
 
-	item, err := h.service.CreateItem(c.Request.Context(), listID, req.Name, req.Quantity)
+	item, created, err := h.service.CreateItem(c.Request.Context(), listID, services.CreateItemInput{
+		Name:        req.Name,
+		Quantity:    req.Quantity,
+		Unit:        req.Unit,
+		CategoryID:  req.CategoryID,
+		UnitPrice:   req.UnitPrice,
+		Currency:    req.Currency,
+		Notes:       req.Notes,
+		Brand:       req.Brand,
+		Size:        req.Size,
+		OnDuplicate: req.OnDuplicate,
+	})
 	if err != nil {
+		if err == entities.ErrForbidden {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Not allowed to change this shopping list"})
+			return
+		}
 		if err == entities.ErrInvalidInput {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		if err == entities.ErrDuplicateItem {
+			c.JSON(http.StatusConflict, gin.H{"error": "An open item with this name already exists in the shopping list"})
+			return
+		}
+		if err == entities.ErrCategoryNotFound {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Category not found"})
+			return
+		}
 		if err == entities.ErrShoppingListNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Shopping list not found"})
 			return
@@ -65,6 +110,12 @@ func (h *ItemHandler) CreateItem(c *gin.Context) {
 		return
 	}
 
+	setETag(c, item.Version)
+	if !created {
+		c.JSON(http.StatusOK, item)
+		return
+	}
+
 	c.JSON(http.StatusCreated, item)
 }
 
@@ -87,6 +138,7 @@ func (h *ItemHandler) GetItem(c *gin.Context) {
 		return
 	}
 
+	setETag(c, item.Version)
 	c.JSON(http.StatusOK, item)
 }
 
@@ -101,6 +153,10 @@ func (h *ItemHandler) GetItemsByShoppingListID(c *gin.Context) {
 
 	items, err := h.service.GetItemsByShoppingListID(c.Request.Context(), listID)
 	if err != nil {
+		if err == entities.ErrShoppingListNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Shopping list not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve items"})
 		return
 	}
@@ -108,7 +164,7 @@ func (h *ItemHandler) GetItemsByShoppingListID(c *gin.Context) {
 	c.JSON(http.StatusOK, items)
 }
 
-// UpdateItem updates an existing item
+// UpdateItem updates an existing item, honoring If-Match
 func (h *ItemHandler) UpdateItem(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := uuid.Parse(idParam)
@@ -117,6 +173,11 @@ func (h *ItemHandler) UpdateItem(c *gin.Context) {
 		return
 	}
 
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
 	var req UpdateItemRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -127,20 +188,101 @@ func (h *ItemHandler) UpdateItem(c *gin.Context) {
 		req.Quantity = 1
 	}
 
-	item, err := h.service.UpdateItem(c.Request.Context(), id, req.Name, req.Quantity, req.Completed)
+	item, err := h.service.UpdateItem(c.Request.Context(), id, services.UpdateItemInput{
+		Name:       req.Name,
+		Quantity:   req.Quantity,
+		Unit:       req.Unit,
+		CategoryID: req.CategoryID,
+		UnitPrice:  req.UnitPrice,
+		Currency:   req.Currency,
+		Notes:      req.Notes,
+		Brand:      req.Brand,
+		Size:       req.Size,
+		Completed:  req.Completed,
+		Version:    version,
+	})
+	if err != nil {
+		if err == entities.ErrForbidden {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Not allowed to change this shopping list"})
+			return
+		}
+		if err == entities.ErrItemNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+			return
+		}
+		if err == entities.ErrVersionConflict {
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": "Item has been modified since it was read"})
+			return
+		}
+		if err == entities.ErrInvalidInput {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err == entities.ErrCategoryNotFound {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Category not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update item"})
+		return
+	}
+
+	setETag(c, item.Version)
+	c.JSON(http.StatusOK, item)
+}
+
+// PatchItem applies a JSON merge-patch document to an item, honoring If-Match
+func (h *ItemHandler) PatchItem(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	doc, ok := readMergePatch(c)
+	if !ok {
+		return
+	}
+
+	patch, err := doc.itemPatch()
 	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	patch.Version = version
+
+	item, err := h.service.PatchItem(c.Request.Context(), id, patch)
+	if err != nil {
+		if err == entities.ErrForbidden {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Not allowed to change this shopping list"})
+			return
+		}
 		if err == entities.ErrItemNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
 			return
 		}
+		if err == entities.ErrVersionConflict {
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": "Item has been modified since it was read"})
+			return
+		}
 		if err == entities.ErrInvalidInput {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		if err == entities.ErrCategoryNotFound {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Category not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update item"})
 		return
 	}
 
+	setETag(c, item.Version)
 	c.JSON(http.StatusOK, item)
 }
 
@@ -155,6 +297,10 @@ func (h *ItemHandler) DeleteItem(c *gin.Context) {
 
 	err = h.service.DeleteItem(c.Request.Context(), id)
 	if err != nil {
+		if err == entities.ErrForbidden {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Not allowed to change this shopping list"})
+			return
+		}
 		if err == entities.ErrItemNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
 			return
@@ -166,7 +312,8 @@ func (h *ItemHandler) DeleteItem(c *gin.Context) {
 	c.JSON(http.StatusNoContent, nil)
 }
 
-// ToggleItemCompletion toggles the completion status of an item
+// ToggleItemCompletion toggles the completion status of an item, honoring If-Match.
+// With ?add_to_pantry=true, completing the item adds its quantity to the pantry.
 func (h *ItemHandler) ToggleItemCompletion(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := uuid.Parse(idParam)
@@ -175,15 +322,71 @@ func (h *ItemHandler) ToggleItemCompletion(c *gin.Context) {
 		return
 	}
 
-	item, err := h.service.ToggleItemCompletion(c.Request.Context(), id)
+	addToPantry, err := strconv.ParseBool(c.DefaultQuery("add_to_pantry", "false"))
 	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "add_to_pantry must be true or false"})
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	item, err := h.service.ToggleItemCompletion(c.Request.Context(), id, version, addToPantry)
+	if err != nil {
+		if err == entities.ErrForbidden {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Not allowed to change this shopping list"})
+			return
+		}
 		if err == entities.ErrItemNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
 			return
 		}
+		if err == entities.ErrVersionConflict {
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": "Item has been modified since it was read"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to toggle item completion"})
 		return
 	}
 
+	setETag(c, item.Version)
 	c.JSON(http.StatusOK, item)
 }
+
+// ReorderItems sets the order of the items in a shopping list
+func (h *ItemHandler) ReorderItems(c *gin.Context) {
+	listIDParam := c.Param("listId")
+	listID, err := uuid.Parse(listIDParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid list ID format"})
+		return
+	}
+
+	var req ReorderItemsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	items, err := h.service.ReorderItems(c.Request.Context(), listID, req.ItemIDs)
+	if err != nil {
+		if err == entities.ErrForbidden {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Not allowed to change this shopping list"})
+			return
+		}
+		if err == entities.ErrShoppingListNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Shopping list not found"})
+			return
+		}
+		if err == entities.ErrInvalidInput || err == entities.ErrItemNotFound {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "item_ids must list every item of the shopping list exactly once"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reorder items"})
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
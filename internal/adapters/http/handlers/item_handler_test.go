@@ -25,12 +25,16 @@ type MockItemService struct {
 // Ensure MockItemService implements the interface
 var _ services.ItemServiceInterface = (*MockItemService)(nil)
 
-func (m *MockItemService) CreateItem(ctx context.Context, shoppingListID uuid.UUID, name string, quantity int) (*entities.Item, error) {
-	args := m.Called(ctx, shoppingListID, name, quantity)
+func (m *MockItemService) CreateItem(
+	ctx context.Context,
+	shoppingListID uuid.UUID,
+	input services.CreateItemInput,
+) (*entities.Item, bool, error) {
+	args := m.Called(ctx, shoppingListID, input)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, false, args.Error(2)
 	}
-	return args.Get(0).(*entities.Item), args.Error(1)
+	return args.Get(0).(*entities.Item), args.Bool(1), args.Error(2)
 }
 
 func (m *MockItemService) GetItem(ctx context.Context, id uuid.UUID) (*entities.Item, error) {
@@ -55,11 +59,21 @@ func (m *MockItemService) GetItemsByShoppingListID(
 func (m *MockItemService) UpdateItem(
 	ctx context.Context,
 	id uuid.UUID,
-	name string,
-	quantity int,
-	completed bool,
+	input services.UpdateItemInput,
+) (*entities.Item, error) {
+	args := m.Called(ctx, id, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Item), args.Error(1)
+}
+
+func (m *MockItemService) PatchItem(
+	ctx context.Context,
+	id uuid.UUID,
+	patch services.ItemPatch,
 ) (*entities.Item, error) {
-	args := m.Called(ctx, id, name, quantity, completed)
+	args := m.Called(ctx, id, patch)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -71,14 +85,39 @@ func (m *MockItemService) DeleteItem(ctx context.Context, id uuid.UUID) error {
 	return args.Error(0)
 }
 
-func (m *MockItemService) ToggleItemCompletion(ctx context.Context, id uuid.UUID) (*entities.Item, error) {
-	args := m.Called(ctx, id)
+func (m *MockItemService) ToggleItemCompletion(ctx context.Context, id uuid.UUID, version *int, addToPantry bool) (*entities.Item, error) {
+	args := m.Called(ctx, id, version, addToPantry)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*entities.Item), args.Error(1)
 }
 
+func (m *MockItemService) BatchItems(
+	ctx context.Context,
+	shoppingListID uuid.UUID,
+	mode services.BatchMode,
+	operations []services.ItemOperation,
+) (*services.ItemBatchResult, error) {
+	args := m.Called(ctx, shoppingListID, mode, operations)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.ItemBatchResult), args.Error(1)
+}
+
+func (m *MockItemService) ReorderItems(
+	ctx context.Context,
+	shoppingListID uuid.UUID,
+	itemIDs []uuid.UUID,
+) ([]*entities.Item, error) {
+	args := m.Called(ctx, shoppingListID, itemIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Item), args.Error(1)
+}
+
 func TestNewItemHandler(t *testing.T) {
 	mockService := &MockItemService{}
 	handler := NewItemHandler(mockService)
@@ -109,7 +148,7 @@ func TestItemHandler_CreateItem(t *testing.T) {
 					Name:     "Milk",
 					Quantity: 2,
 				}
-				m.On("CreateItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), "Milk", 2).Return(expectedItem, nil)
+				m.On("CreateItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), services.CreateItemInput{Name: "Milk", Quantity: 2}).Return(expectedItem, true, nil)
 			},
 			expectedStatus: http.StatusCreated,
 			expectedBody: func(t *testing.T, body map[string]interface{}) {
@@ -117,6 +156,28 @@ func TestItemHandler_CreateItem(t *testing.T) {
 				assert.Equal(t, float64(2), body["quantity"])
 			},
 		},
+		{
+			name:   "creates item with details",
+			listID: uuid.New().String(),
+			requestBody: CreateItemRequest{
+				Name:     "Milk",
+				Quantity: 1,
+				Notes:    "the lactose-free one, green cap",
+				Brand:    "Arla",
+				Size:     "1 l",
+			},
+			mockSetup: func(m *MockItemService) {
+				input := services.CreateItemInput{Name: "Milk", Quantity: 1, Notes: "the lactose-free one, green cap", Brand: "Arla", Size: "1 l"}
+				expectedItem := &entities.Item{ID: uuid.New(), Name: "Milk", Quantity: 1, Notes: input.Notes, Brand: input.Brand, Size: input.Size}
+				m.On("CreateItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), input).Return(expectedItem, true, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "the lactose-free one, green cap", body["notes"])
+				assert.Equal(t, "Arla", body["brand"])
+				assert.Equal(t, "1 l", body["size"])
+			},
+		},
 		{
 			name:   "creates item with default quantity when zero",
 			listID: uuid.New().String(),
@@ -130,7 +191,7 @@ func TestItemHandler_CreateItem(t *testing.T) {
 					Name:     "Bread",
 					Quantity: 1,
 				}
-				m.On("CreateItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), "Bread", 1).Return(expectedItem, nil)
+				m.On("CreateItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), services.CreateItemInput{Name: "Bread", Quantity: 1}).Return(expectedItem, true, nil)
 			},
 			expectedStatus: http.StatusCreated,
 			expectedBody: func(t *testing.T, body map[string]interface{}) {
@@ -151,7 +212,7 @@ func TestItemHandler_CreateItem(t *testing.T) {
 					Name:     "Eggs",
 					Quantity: 1,
 				}
-				m.On("CreateItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), "Eggs", 1).Return(expectedItem, nil)
+				m.On("CreateItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), services.CreateItemInput{Name: "Eggs", Quantity: 1}).Return(expectedItem, true, nil)
 			},
 			expectedStatus: http.StatusCreated,
 			expectedBody: func(t *testing.T, body map[string]interface{}) {
@@ -159,6 +220,30 @@ func TestItemHandler_CreateItem(t *testing.T) {
 				assert.Equal(t, float64(1), body["quantity"])
 			},
 		},
+		{
+			name:   "creates item with fractional quantity and unit",
+			listID: uuid.New().String(),
+			requestBody: CreateItemRequest{
+				Name:     "Flour",
+				Quantity: 1.5,
+				Unit:     entities.UnitKilogram,
+			},
+			mockSetup: func(m *MockItemService) {
+				expectedItem := &entities.Item{
+					ID:       uuid.New(),
+					Name:     "Flour",
+					Quantity: 1.5,
+					Unit:     entities.UnitKilogram,
+				}
+				m.On("CreateItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), services.CreateItemInput{Name: "Flour", Quantity: 1.5, Unit: entities.UnitKilogram}).Return(expectedItem, true, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Flour", body["name"])
+				assert.Equal(t, 1.5, body["quantity"])
+				assert.Equal(t, "kg", body["unit"])
+			},
+		},
 		{
 			name:           "fails with invalid list ID",
 			listID:         "invalid-uuid",
@@ -187,13 +272,49 @@ func TestItemHandler_CreateItem(t *testing.T) {
 				Quantity: 1,
 			},
 			mockSetup: func(m *MockItemService) {
-				m.On("CreateItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), "ValidName", 1).Return(nil, entities.ErrInvalidInput)
+				m.On("CreateItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), services.CreateItemInput{Name: "ValidName", Quantity: 1}).Return(nil, false, entities.ErrInvalidInput)
 			},
 			expectedStatus: http.StatusBadRequest,
 			expectedBody: func(t *testing.T, body map[string]interface{}) {
 				assert.Equal(t, entities.ErrInvalidInput.Error(), body["error"])
 			},
 		},
+		{
+			name:   "fails with duplicate item",
+			listID: uuid.New().String(),
+			requestBody: CreateItemRequest{
+				Name:     "Milk",
+				Quantity: 1,
+			},
+			mockSetup: func(m *MockItemService) {
+				m.On("CreateItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), services.CreateItemInput{Name: "Milk", Quantity: 1}).Return(nil, false, entities.ErrDuplicateItem)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "An open item with this name already exists in the shopping list", body["error"])
+			},
+		},
+		{
+			name:   "merges duplicate item",
+			listID: uuid.New().String(),
+			requestBody: CreateItemRequest{
+				Name:        "Milk",
+				Quantity:    1,
+				OnDuplicate: services.DuplicateMerge,
+			},
+			mockSetup: func(m *MockItemService) {
+				mergedItem := &entities.Item{
+					ID:       uuid.New(),
+					Name:     "Milk",
+					Quantity: 3,
+				}
+				m.On("CreateItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), services.CreateItemInput{Name: "Milk", Quantity: 1, OnDuplicate: services.DuplicateMerge}).Return(mergedItem, false, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, float64(3), body["quantity"])
+			},
+		},
 		{
 			name:   "fails with shopping list not found",
 			listID: uuid.New().String(),
@@ -202,7 +323,7 @@ func TestItemHandler_CreateItem(t *testing.T) {
 				Quantity: 1,
 			},
 			mockSetup: func(m *MockItemService) {
-				m.On("CreateItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), "Test Item", 1).Return(nil, entities.ErrShoppingListNotFound)
+				m.On("CreateItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), services.CreateItemInput{Name: "Test Item", Quantity: 1}).Return(nil, false, entities.ErrShoppingListNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
 			expectedBody: func(t *testing.T, body map[string]interface{}) {
@@ -217,7 +338,7 @@ func TestItemHandler_CreateItem(t *testing.T) {
 				Quantity: 1,
 			},
 			mockSetup: func(m *MockItemService) {
-				m.On("CreateItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), "Test Item", 1).Return(nil, fmt.Errorf("database error"))
+				m.On("CreateItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), services.CreateItemInput{Name: "Test Item", Quantity: 1}).Return(nil, false, fmt.Errorf("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: func(t *testing.T, body map[string]interface{}) {
@@ -466,7 +587,7 @@ func TestItemHandler_UpdateItem(t *testing.T) {
 					Quantity:  3,
 					Completed: true,
 				}
-				m.On("UpdateItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), "Updated Milk", 3, true).Return(expectedItem, nil)
+				m.On("UpdateItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), services.UpdateItemInput{Name: "Updated Milk", Quantity: 3, Completed: true}).Return(expectedItem, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: func(t *testing.T, body map[string]interface{}) {
@@ -490,7 +611,7 @@ func TestItemHandler_UpdateItem(t *testing.T) {
 					Quantity:  1,
 					Completed: false,
 				}
-				m.On("UpdateItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), "Test Item", 1, false).Return(expectedItem, nil)
+				m.On("UpdateItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), services.UpdateItemInput{Name: "Test Item", Quantity: 1}).Return(expectedItem, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: func(t *testing.T, body map[string]interface{}) {
@@ -526,7 +647,7 @@ func TestItemHandler_UpdateItem(t *testing.T) {
 				Quantity: 1,
 			},
 			mockSetup: func(m *MockItemService) {
-				m.On("UpdateItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), "Test Item", 1, false).Return(nil, entities.ErrItemNotFound)
+				m.On("UpdateItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), services.UpdateItemInput{Name: "Test Item", Quantity: 1}).Return(nil, entities.ErrItemNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
 			expectedBody: func(t *testing.T, body map[string]interface{}) {
@@ -541,7 +662,7 @@ func TestItemHandler_UpdateItem(t *testing.T) {
 				Quantity: 1,
 			},
 			mockSetup: func(m *MockItemService) {
-				m.On("UpdateItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), "ValidName", 1, false).Return(nil, entities.ErrInvalidInput)
+				m.On("UpdateItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), services.UpdateItemInput{Name: "ValidName", Quantity: 1}).Return(nil, entities.ErrInvalidInput)
 			},
 			expectedStatus: http.StatusBadRequest,
 			expectedBody: func(t *testing.T, body map[string]interface{}) {
@@ -556,7 +677,7 @@ func TestItemHandler_UpdateItem(t *testing.T) {
 				Quantity: 1,
 			},
 			mockSetup: func(m *MockItemService) {
-				m.On("UpdateItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), "Test Item", 1, false).Return(nil, fmt.Errorf("database error"))
+				m.On("UpdateItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), services.UpdateItemInput{Name: "Test Item", Quantity: 1}).Return(nil, fmt.Errorf("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: func(t *testing.T, body map[string]interface{}) {
@@ -595,6 +716,131 @@ func TestItemHandler_UpdateItem(t *testing.T) {
 	}
 }
 
+func TestItemHandler_PatchItem(t *testing.T) {
+	quantity := 3.0
+	completed := true
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(*MockItemService)
+		expectedStatus int
+		expectedBody   func(*testing.T, map[string]interface{})
+	}{
+		{
+			name: "patches only the quantity",
+			body: `{"quantity": 3}`,
+			mockSetup: func(m *MockItemService) {
+				m.On("PatchItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), services.ItemPatch{Quantity: &quantity}).Return(&entities.Item{Name: "Milk", Quantity: 3, Version: 2}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Milk", body["name"])
+				assert.Equal(t, float64(3), body["quantity"])
+			},
+		},
+		{
+			name: "null removes category and price",
+			body: `{"category_id": null, "unit_price": null, "completed": true}`,
+			mockSetup: func(m *MockItemService) {
+				m.On("PatchItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), services.ItemPatch{ClearCategory: true, ClearUnitPrice: true, Completed: &completed}).Return(&entities.Item{Name: "Milk", Completed: true}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Nil(t, body["category_id"])
+				assert.Equal(t, true, body["completed"])
+			},
+		},
+		{
+			name: "null removes notes",
+			body: `{"notes": null, "brand": "Arla"}`,
+			mockSetup: func(m *MockItemService) {
+				brand := "Arla"
+				patch := services.ItemPatch{Notes: new(string), Brand: &brand}
+				m.On("PatchItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), patch).Return(&entities.Item{Name: "Milk", Brand: "Arla"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "", body["notes"])
+				assert.Equal(t, "Arla", body["brand"])
+			},
+		},
+		{
+			name:           "fails when removing the quantity",
+			body:           `{"quantity": null}`,
+			mockSetup:      func(m *MockItemService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "quantity cannot be null", body["error"])
+			},
+		},
+		{
+			name:           "fails with unknown field",
+			body:           `{"id": "123"}`,
+			mockSetup:      func(m *MockItemService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "id cannot be patched", body["error"])
+			},
+		},
+		{
+			name:           "fails with wrongly typed field",
+			body:           `{"completed": "yes"}`,
+			mockSetup:      func(m *MockItemService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Contains(t, body["error"], "invalid completed")
+			},
+		},
+		{
+			name:           "fails when the document is not an object",
+			body:           `null`,
+			mockSetup:      func(m *MockItemService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "merge patch must be a JSON object", body["error"])
+			},
+		},
+		{
+			name: "fails with not found error",
+			body: `{"quantity": 3}`,
+			mockSetup: func(m *MockItemService) {
+				m.On("PatchItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), services.ItemPatch{Quantity: &quantity}).Return(nil, entities.ErrItemNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Item not found", body["error"])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockItemService{}
+			tt.mockSetup(mockService)
+
+			handler := NewItemHandler(mockService)
+			router := setupTestRouter()
+			router.PATCH("/items/:id", handler.PatchItem)
+
+			req := httptest.NewRequest(http.MethodPatch, "/items/"+uuid.New().String(), bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/merge-patch+json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var responseBody map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &responseBody)
+			require.NoError(t, err)
+
+			tt.expectedBody(t, responseBody)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
 func TestItemHandler_DeleteItem(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -627,6 +873,20 @@ func TestItemHandler_DeleteItem(t *testing.T) {
 				assert.Equal(t, "Invalid ID format", responseBody["error"])
 			},
 		},
+		{
+			name:   "fails for viewers of a shared list",
+			itemID: uuid.New().String(),
+			mockSetup: func(m *MockItemService) {
+				m.On("DeleteItem", mock.Anything, mock.AnythingOfType("uuid.UUID")).Return(entities.ErrForbidden)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody: func(t *testing.T, body []byte) {
+				var responseBody map[string]interface{}
+				err := json.Unmarshal(body, &responseBody)
+				require.NoError(t, err)
+				assert.Equal(t, "Not allowed to change this shopping list", responseBody["error"])
+			},
+		},
 		{
 			name:   "fails with not found error",
 			itemID: uuid.New().String(),
@@ -679,9 +939,13 @@ func TestItemHandler_DeleteItem(t *testing.T) {
 }
 
 func TestItemHandler_ToggleItemCompletion(t *testing.T) {
+	version := 3
+
 	tests := []struct {
 		name           string
 		itemID         string
+		query          string
+		ifMatch        string
 		mockSetup      func(*MockItemService)
 		expectedStatus int
 		expectedBody   func(*testing.T, map[string]interface{})
@@ -696,7 +960,7 @@ func TestItemHandler_ToggleItemCompletion(t *testing.T) {
 					Quantity:  1,
 					Completed: true,
 				}
-				m.On("ToggleItemCompletion", mock.Anything, mock.AnythingOfType("uuid.UUID")).Return(expectedItem, nil)
+				m.On("ToggleItemCompletion", mock.Anything, mock.AnythingOfType("uuid.UUID"), (*int)(nil), false).Return(expectedItem, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: func(t *testing.T, body map[string]interface{}) {
@@ -704,6 +968,62 @@ func TestItemHandler_ToggleItemCompletion(t *testing.T) {
 				assert.Equal(t, true, body["completed"])
 			},
 		},
+		{
+			name:    "passes If-Match version to the service",
+			itemID:  uuid.New().String(),
+			ifMatch: `"3"`,
+			mockSetup: func(m *MockItemService) {
+				m.On("ToggleItemCompletion", mock.Anything, mock.AnythingOfType("uuid.UUID"), &version, false).Return(&entities.Item{Name: "Test Item", Version: 4}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, float64(4), body["version"])
+			},
+		},
+		{
+			name:    "fails with stale version",
+			itemID:  uuid.New().String(),
+			ifMatch: `"3"`,
+			mockSetup: func(m *MockItemService) {
+				m.On("ToggleItemCompletion", mock.Anything, mock.AnythingOfType("uuid.UUID"), &version, false).Return(nil, entities.ErrVersionConflict)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Item has been modified since it was read", body["error"])
+			},
+		},
+		{
+			name:   "adds the item to the pantry",
+			itemID: uuid.New().String(),
+			query:  "?add_to_pantry=true",
+			mockSetup: func(m *MockItemService) {
+				m.On("ToggleItemCompletion", mock.Anything, mock.AnythingOfType("uuid.UUID"), (*int)(nil), true).Return(&entities.Item{Name: "Milk", Completed: true}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, true, body["completed"])
+			},
+		},
+		{
+			name:           "fails with invalid add_to_pantry",
+			itemID:         uuid.New().String(),
+			query:          "?add_to_pantry=maybe",
+			mockSetup:      func(m *MockItemService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "add_to_pantry must be true or false", body["error"])
+			},
+		},
+		{
+			name:           "fails with malformed If-Match",
+			itemID:         uuid.New().String(),
+			ifMatch:        "latest",
+			mockSetup:      func(m *MockItemService) {},
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "If-Match does not match the current version", body["error"])
+			},
+		},
 		{
 			name:           "fails with invalid UUID",
 			itemID:         "invalid-uuid",
@@ -717,7 +1037,7 @@ func TestItemHandler_ToggleItemCompletion(t *testing.T) {
 			name:   "fails with not found error",
 			itemID: uuid.New().String(),
 			mockSetup: func(m *MockItemService) {
-				m.On("ToggleItemCompletion", mock.Anything, mock.AnythingOfType("uuid.UUID")).Return(nil, entities.ErrItemNotFound)
+				m.On("ToggleItemCompletion", mock.Anything, mock.AnythingOfType("uuid.UUID"), (*int)(nil), false).Return(nil, entities.ErrItemNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
 			expectedBody: func(t *testing.T, body map[string]interface{}) {
@@ -728,7 +1048,7 @@ func TestItemHandler_ToggleItemCompletion(t *testing.T) {
 			name:   "fails with internal server error",
 			itemID: uuid.New().String(),
 			mockSetup: func(m *MockItemService) {
-				m.On("ToggleItemCompletion", mock.Anything, mock.AnythingOfType("uuid.UUID")).Return(nil, fmt.Errorf("database error"))
+				m.On("ToggleItemCompletion", mock.Anything, mock.AnythingOfType("uuid.UUID"), (*int)(nil), false).Return(nil, fmt.Errorf("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: func(t *testing.T, body map[string]interface{}) {
@@ -746,7 +1066,10 @@ func TestItemHandler_ToggleItemCompletion(t *testing.T) {
 			router := setupTestRouter()
 			router.PATCH("/items/:id/toggle", handler.ToggleItemCompletion)
 
-			req := httptest.NewRequest(http.MethodPatch, "/items/"+tt.itemID+"/toggle", nil)
+			req := httptest.NewRequest(http.MethodPatch, "/items/"+tt.itemID+"/toggle"+tt.query, nil)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
 			w := httptest.NewRecorder()
 
 			router.ServeHTTP(w, req)
@@ -762,3 +1085,82 @@ func TestItemHandler_ToggleItemCompletion(t *testing.T) {
 		})
 	}
 }
+
+func TestItemHandler_ReorderItems(t *testing.T) {
+	firstID := uuid.New()
+	secondID := uuid.New()
+
+	tests := []struct {
+		name           string
+		listID         string
+		requestBody    interface{}
+		mockSetup      func(*MockItemService)
+		expectedStatus int
+	}{
+		{
+			name:        "successfully reorders items",
+			listID:      uuid.New().String(),
+			requestBody: ReorderItemsRequest{ItemIDs: []uuid.UUID{secondID, firstID}},
+			mockSetup: func(m *MockItemService) {
+				items := []*entities.Item{{ID: secondID, Position: 0}, {ID: firstID, Position: 1}}
+				m.On("ReorderItems", mock.Anything, mock.AnythingOfType("uuid.UUID"), []uuid.UUID{secondID, firstID}).Return(items, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "fails with invalid list ID",
+			listID:         "invalid-uuid",
+			requestBody:    ReorderItemsRequest{ItemIDs: []uuid.UUID{firstID}},
+			mockSetup:      func(m *MockItemService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "fails with missing item_ids",
+			listID:         uuid.New().String(),
+			requestBody:    map[string]interface{}{},
+			mockSetup:      func(m *MockItemService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "fails with incomplete item set",
+			listID:      uuid.New().String(),
+			requestBody: ReorderItemsRequest{ItemIDs: []uuid.UUID{firstID}},
+			mockSetup: func(m *MockItemService) {
+				m.On("ReorderItems", mock.Anything, mock.AnythingOfType("uuid.UUID"), []uuid.UUID{firstID}).Return(nil, entities.ErrInvalidInput)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "fails with shopping list not found",
+			listID:      uuid.New().String(),
+			requestBody: ReorderItemsRequest{ItemIDs: []uuid.UUID{firstID}},
+			mockSetup: func(m *MockItemService) {
+				m.On("ReorderItems", mock.Anything, mock.AnythingOfType("uuid.UUID"), []uuid.UUID{firstID}).Return(nil, entities.ErrShoppingListNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockItemService{}
+			tt.mockSetup(mockService)
+
+			handler := NewItemHandler(mockService)
+			router := setupTestRouter()
+			router.PUT("/shopping-lists/:listId/items/order", handler.ReorderItems)
+
+			body, err := json.Marshal(tt.requestBody)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPut, "/shopping-lists/"+tt.listID+"/items/order", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/application/services"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// maxImageRequestSize bounds an image upload, leaving room for the multipart framing around the file
+const maxImageRequestSize = entities.MaxImageSize + 64<<10
+
+// ItemImageHandler handles HTTP requests for the images attached to items
+type ItemImageHandler struct {
+	service services.ItemImageServiceInterface
+}
+
+// NewItemImageHandler creates a new item image handler
+func NewItemImageHandler(service services.ItemImageServiceInterface) *ItemImageHandler {
+	return &ItemImageHandler{service: service}
+}
+
+// UploadImage attaches the image in the "image" field of a multipart form to an item
+func (h *ItemImageHandler) UploadImage(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImageRequestSize)
+	file, err := c.FormFile("image")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Image must be at most 5 MiB"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A multipart form with an image file is required"})
+		return
+	}
+	if file.Size > entities.MaxImageSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Image must be at most 5 MiB"})
+		return
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read image"})
+		return
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read image"})
+		return
+	}
+
+	image, err := h.service.UploadImage(c.Request.Context(), id, data)
+	if err != nil {
+		switch err {
+		case entities.ErrItemNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+		case entities.ErrForbidden:
+			c.JSON(http.StatusForbidden, gin.H{"error": "Not allowed to change this shopping list"})
+		case entities.ErrImageTooLarge:
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Image must be at most 5 MiB and 40 megapixels"})
+		case entities.ErrUnsupportedImageType:
+			c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Image must be a JPEG, PNG or GIF"})
+		case entities.ErrInvalidInput:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Image could not be decoded"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store image"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, image)
+}
+
+// GetImage serves the image of an item, or its thumbnail with ?variant=thumbnail
+func (h *ItemImageHandler) GetImage(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var thumbnail bool
+	switch c.DefaultQuery("variant", "original") {
+	case "original":
+	case "thumbnail":
+		thumbnail = true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "variant must be original or thumbnail"})
+		return
+	}
+
+	image, data, err := h.service.GetImage(c.Request.Context(), id, thumbnail)
+	if err != nil {
+		switch err {
+		case entities.ErrItemNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+		case entities.ErrImageNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Item has no image"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve image"})
+		}
+		return
+	}
+
+	contentType := image.ContentType
+	if thumbnail {
+		contentType = image.ThumbnailContentType
+	}
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// DeleteImage removes the image of an item
+func (h *ItemImageHandler) DeleteImage(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	err = h.service.DeleteImage(c.Request.Context(), id)
+	if err != nil {
+		switch err {
+		case entities.ErrItemNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+		case entities.ErrImageNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Item has no image"})
+		case entities.ErrForbidden:
+			c.JSON(http.StatusForbidden, gin.H{"error": "Not allowed to change this shopping list"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete image"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
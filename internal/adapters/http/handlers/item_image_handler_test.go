@@ -0,0 +1,277 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/application/services"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// MockItemImageService is a mock implementation of the item image service interface
+type MockItemImageService struct {
+	mock.Mock
+}
+
+// Ensure MockItemImageService implements the interface
+var _ services.ItemImageServiceInterface = (*MockItemImageService)(nil)
+
+func (m *MockItemImageService) UploadImage(ctx context.Context, itemID uuid.UUID, data []byte) (*entities.ItemImage, error) {
+	args := m.Called(ctx, itemID, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ItemImage), args.Error(1)
+}
+
+func (m *MockItemImageService) GetImage(ctx context.Context, itemID uuid.UUID, thumbnail bool) (*entities.ItemImage, []byte, error) {
+	args := m.Called(ctx, itemID, thumbnail)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*entities.ItemImage), args.Get(1).([]byte), args.Error(2)
+}
+
+func (m *MockItemImageService) DeleteImage(ctx context.Context, itemID uuid.UUID) error {
+	args := m.Called(ctx, itemID)
+	return args.Error(0)
+}
+
+// multipartImage builds a multipart form body holding a file in the given field
+func multipartImage(t *testing.T, field string, data []byte) (*bytes.Buffer, string) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile(field, "photo.png")
+	require.NoError(t, err)
+	_, err = part.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	return &body, writer.FormDataContentType()
+}
+
+func TestItemImageHandler_UploadImage(t *testing.T) {
+	photo := []byte("\x89PNG\r\n\x1a\nphoto")
+
+	tests := []struct {
+		name           string
+		field          string
+		data           []byte
+		mockSetup      func(*MockItemImageService)
+		expectedStatus int
+		expectedBody   func(*testing.T, map[string]interface{})
+	}{
+		{
+			name:  "stores the image",
+			field: "image",
+			data:  photo,
+			mockSetup: func(m *MockItemImageService) {
+				m.On("UploadImage", mock.Anything, mock.AnythingOfType("uuid.UUID"), photo).
+					Return(&entities.ItemImage{ContentType: entities.ContentTypePNG, Size: int64(len(photo)), Width: 4, Height: 3}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "image/png", body["content_type"])
+				assert.Equal(t, float64(4), body["width"])
+				assert.NotContains(t, body, "blob_key")
+			},
+		},
+		{
+			name:           "fails without an image field",
+			field:          "file",
+			data:           photo,
+			mockSetup:      func(m *MockItemImageService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "A multipart form with an image file is required", body["error"])
+			},
+		},
+		{
+			name:           "fails when the request is too large",
+			field:          "image",
+			data:           make([]byte, maxImageRequestSize),
+			mockSetup:      func(m *MockItemImageService) {},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Image must be at most 5 MiB", body["error"])
+			},
+		},
+		{
+			name:  "fails with an unsupported type",
+			field: "image",
+			data:  []byte("plain text"),
+			mockSetup: func(m *MockItemImageService) {
+				m.On("UploadImage", mock.Anything, mock.Anything, mock.Anything).Return(nil, entities.ErrUnsupportedImageType)
+			},
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Image must be a JPEG, PNG or GIF", body["error"])
+			},
+		},
+		{
+			name:  "fails when the item does not exist",
+			field: "image",
+			data:  photo,
+			mockSetup: func(m *MockItemImageService) {
+				m.On("UploadImage", mock.Anything, mock.Anything, mock.Anything).Return(nil, entities.ErrItemNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Item not found", body["error"])
+			},
+		},
+		{
+			name:  "fails with storage error",
+			field: "image",
+			data:  photo,
+			mockSetup: func(m *MockItemImageService) {
+				m.On("UploadImage", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("disk full"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Failed to store image", body["error"])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockItemImageService{}
+			tt.mockSetup(mockService)
+
+			handler := NewItemImageHandler(mockService)
+			router := setupTestRouter()
+			router.POST("/items/:id/image", handler.UploadImage)
+
+			body, contentType := multipartImage(t, tt.field, tt.data)
+			req := httptest.NewRequest(http.MethodPost, "/items/"+uuid.New().String()+"/image", body)
+			req.Header.Set("Content-Type", contentType)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var responseBody map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &responseBody))
+
+			tt.expectedBody(t, responseBody)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemImageHandler_GetImage(t *testing.T) {
+	image := &entities.ItemImage{ContentType: entities.ContentTypeJPEG, ThumbnailContentType: entities.ContentTypeJPEG}
+
+	tests := []struct {
+		name                string
+		query               string
+		mockSetup           func(*MockItemImageService)
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:  "serves the original",
+			query: "",
+			mockSetup: func(m *MockItemImageService) {
+				m.On("GetImage", mock.Anything, mock.AnythingOfType("uuid.UUID"), false).Return(image, []byte("original"), nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "image/jpeg",
+			expectedBody:        "original",
+		},
+		{
+			name:  "serves the thumbnail",
+			query: "?variant=thumbnail",
+			mockSetup: func(m *MockItemImageService) {
+				m.On("GetImage", mock.Anything, mock.AnythingOfType("uuid.UUID"), true).Return(image, []byte("thumbnail"), nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "image/jpeg",
+			expectedBody:        "thumbnail",
+		},
+		{
+			name:                "fails with unknown variant",
+			query:               "?variant=huge",
+			mockSetup:           func(m *MockItemImageService) {},
+			expectedStatus:      http.StatusBadRequest,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        `{"error":"variant must be original or thumbnail"}`,
+		},
+		{
+			name:  "fails when the item has no image",
+			query: "",
+			mockSetup: func(m *MockItemImageService) {
+				m.On("GetImage", mock.Anything, mock.Anything, false).Return(nil, nil, entities.ErrImageNotFound)
+			},
+			expectedStatus:      http.StatusNotFound,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        `{"error":"Item has no image"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockItemImageService{}
+			tt.mockSetup(mockService)
+
+			handler := NewItemImageHandler(mockService)
+			router := setupTestRouter()
+			router.GET("/items/:id/image", handler.GetImage)
+
+			req := httptest.NewRequest(http.MethodGet, "/items/"+uuid.New().String()+"/image"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedContentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, tt.expectedBody, w.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemImageHandler_DeleteImage(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "deletes the image", expectedStatus: http.StatusNoContent},
+		{name: "fails when the item does not exist", err: entities.ErrItemNotFound, expectedStatus: http.StatusNotFound},
+		{name: "fails when the item has no image", err: entities.ErrImageNotFound, expectedStatus: http.StatusNotFound},
+		{name: "fails when the caller may not edit the list", err: entities.ErrForbidden, expectedStatus: http.StatusForbidden},
+		{name: "fails with storage error", err: fmt.Errorf("database error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockItemImageService{}
+			mockService.On("DeleteImage", mock.Anything, mock.AnythingOfType("uuid.UUID")).Return(tt.err)
+
+			handler := NewItemImageHandler(mockService)
+			router := setupTestRouter()
+			router.DELETE("/items/:id/image", handler.DeleteImage)
+
+			req := httptest.NewRequest(http.MethodDelete, "/items/"+uuid.New().String()+"/image", nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
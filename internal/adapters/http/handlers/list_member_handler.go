@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/application/services"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// ListMemberHandler handles HTTP requests for the members of shared shopping lists
+type ListMemberHandler struct {
+	service services.ListMemberServiceInterface
+}
+
+// NewListMemberHandler creates a new list member handler
+func NewListMemberHandler(service services.ListMemberServiceInterface) *ListMemberHandler {
+	return &ListMemberHandler{service: service}
+}
+
+// AddMemberRequest represents the request body for sharing a shopping list with a user
+type AddMemberRequest struct {
+	UserID uuid.UUID         `json:"user_id" binding:"required"`
+	Role   entities.ListRole `json:"role" binding:"required"`
+}
+
+// UpdateMemberRequest represents the request body for changing the role of a member
+type UpdateMemberRequest struct {
+	Role entities.ListRole `json:"role" binding:"required"`
+}
+
+// GetMembers retrieves the members of a shopping list
+func (h *ListMemberHandler) GetMembers(c *gin.Context) {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	members, err := h.service.GetMembers(c.Request.Context(), listID)
+	if err != nil {
+		if err == entities.ErrShoppingListNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Shopping list not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve members"})
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+// AddMember shares a shopping list with a user
+func (h *ListMemberHandler) AddMember(c *gin.Context) {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req AddMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	member, err := h.service.AddMember(c.Request.Context(), listID, req.UserID, req.Role)
+	if err != nil {
+		writeMemberError(c, err, "Failed to add member")
+		return
+	}
+
+	c.JSON(http.StatusCreated, member)
+}
+
+// UpdateMember changes the role of a member of a shopping list
+func (h *ListMemberHandler) UpdateMember(c *gin.Context) {
+	listID, userID, ok := memberParams(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	member, err := h.service.UpdateMember(c.Request.Context(), listID, userID, req.Role)
+	if err != nil {
+		writeMemberError(c, err, "Failed to update member")
+		return
+	}
+
+	c.JSON(http.StatusOK, member)
+}
+
+// RemoveMember stops sharing a shopping list with a user
+func (h *ListMemberHandler) RemoveMember(c *gin.Context) {
+	listID, userID, ok := memberParams(c)
+	if !ok {
+		return
+	}
+
+	err := h.service.RemoveMember(c.Request.Context(), listID, userID)
+	if err != nil {
+		writeMemberError(c, err, "Failed to remove member")
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// memberParams parses the shopping list and user IDs of a member route
+func memberParams(c *gin.Context) (listID, userID uuid.UUID, ok bool) {
+	listID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	userID, err = uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return listID, userID, true
+}
+
+// writeMemberError maps an error of a member change to its response
+func writeMemberError(c *gin.Context, err error, failure string) {
+	switch err {
+	case entities.ErrShoppingListNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Shopping list not found"})
+	case entities.ErrMemberNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Member not found"})
+	case entities.ErrUserNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+	case entities.ErrForbidden:
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only owners can manage members"})
+	case entities.ErrDuplicateMember:
+		c.JSON(http.StatusConflict, gin.H{"error": "User is already a member"})
+	case entities.ErrInvalidInput:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Role must be owner, editor or viewer"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": failure})
+	}
+}
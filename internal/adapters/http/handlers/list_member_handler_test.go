@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/uriberma/go-shopping-list-api/internal/application/services"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// MockListMemberService is a mock implementation of the list member service interface
+type MockListMemberService struct {
+	mock.Mock
+}
+
+// Ensure MockListMemberService implements the interface
+var _ services.ListMemberServiceInterface = (*MockListMemberService)(nil)
+
+func (m *MockListMemberService) GetMembers(ctx context.Context, shoppingListID uuid.UUID) ([]*entities.ListMember, error) {
+	args := m.Called(ctx, shoppingListID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.ListMember), args.Error(1)
+}
+
+func (m *MockListMemberService) AddMember(
+	ctx context.Context,
+	shoppingListID, userID uuid.UUID,
+	role entities.ListRole,
+) (*entities.ListMember, error) {
+	args := m.Called(ctx, shoppingListID, userID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ListMember), args.Error(1)
+}
+
+func (m *MockListMemberService) UpdateMember(
+	ctx context.Context,
+	shoppingListID, userID uuid.UUID,
+	role entities.ListRole,
+) (*entities.ListMember, error) {
+	args := m.Called(ctx, shoppingListID, userID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ListMember), args.Error(1)
+}
+
+func (m *MockListMemberService) RemoveMember(ctx context.Context, shoppingListID, userID uuid.UUID) error {
+	args := m.Called(ctx, shoppingListID, userID)
+	return args.Error(0)
+}
+
+func TestListMemberHandler_AddMember(t *testing.T) {
+	listID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name           string
+		path           string
+		body           string
+		mockSetup      func(*MockListMemberService)
+		expectedStatus int
+	}{
+		{
+			name: "shares the list",
+			path: "/lists/" + listID.String() + "/members",
+			body: fmt.Sprintf(`{"user_id": %q, "role": "editor"}`, userID),
+			mockSetup: func(m *MockListMemberService) {
+				member := entities.NewListMember(listID, userID, entities.ListRoleEditor)
+				m.On("AddMember", mock.Anything, listID, userID, entities.ListRoleEditor).Return(member, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "fails without a role",
+			path:           "/lists/" + listID.String() + "/members",
+			body:           fmt.Sprintf(`{"user_id": %q}`, userID),
+			mockSetup:      func(m *MockListMemberService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "fails with an invalid list ID",
+			path:           "/lists/not-a-uuid/members",
+			body:           fmt.Sprintf(`{"user_id": %q, "role": "editor"}`, userID),
+			mockSetup:      func(m *MockListMemberService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "fails for non-owners",
+			path: "/lists/" + listID.String() + "/members",
+			body: fmt.Sprintf(`{"user_id": %q, "role": "viewer"}`, userID),
+			mockSetup: func(m *MockListMemberService) {
+				m.On("AddMember", mock.Anything, listID, userID, entities.ListRoleViewer).Return(nil, entities.ErrForbidden)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "fails for existing members",
+			path: "/lists/" + listID.String() + "/members",
+			body: fmt.Sprintf(`{"user_id": %q, "role": "viewer"}`, userID),
+			mockSetup: func(m *MockListMemberService) {
+				m.On("AddMember", mock.Anything, listID, userID, entities.ListRoleViewer).Return(nil, entities.ErrDuplicateMember)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "fails for unknown users",
+			path: "/lists/" + listID.String() + "/members",
+			body: fmt.Sprintf(`{"user_id": %q, "role": "viewer"}`, userID),
+			mockSetup: func(m *MockListMemberService) {
+				m.On("AddMember", mock.Anything, listID, userID, entities.ListRoleViewer).Return(nil, entities.ErrUserNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "fails with an unknown role",
+			path: "/lists/" + listID.String() + "/members",
+			body: fmt.Sprintf(`{"user_id": %q, "role": "admin"}`, userID),
+			mockSetup: func(m *MockListMemberService) {
+				m.On("AddMember", mock.Anything, listID, userID, entities.ListRole("admin")).Return(nil, entities.ErrInvalidInput)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockListMemberService{}
+			tt.mockSetup(mockService)
+
+			handler := NewListMemberHandler(mockService)
+			router := setupTestRouter()
+			router.POST("/lists/:id/members", handler.AddMember)
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListMemberHandler_GetUpdateAndRemoveMembers(t *testing.T) {
+	listID := uuid.New()
+	member := entities.NewListMember(listID, uuid.New(), entities.ListRoleViewer)
+	mockService := &MockListMemberService{}
+	mockService.On("GetMembers", mock.Anything, listID).Return([]*entities.ListMember{member}, nil)
+	mockService.On("GetMembers", mock.Anything, mock.Anything).Return(nil, entities.ErrShoppingListNotFound)
+	mockService.On("UpdateMember", mock.Anything, listID, member.UserID, entities.ListRoleEditor).Return(member, nil)
+	mockService.On("UpdateMember", mock.Anything, listID, mock.Anything, mock.Anything).Return(nil, entities.ErrMemberNotFound)
+	mockService.On("RemoveMember", mock.Anything, listID, member.UserID).Return(nil)
+	mockService.On("RemoveMember", mock.Anything, listID, mock.Anything).Return(entities.ErrForbidden)
+
+	handler := NewListMemberHandler(mockService)
+	router := setupTestRouter()
+	router.GET("/lists/:id/members", handler.GetMembers)
+	router.PUT("/lists/:id/members/:userId", handler.UpdateMember)
+	router.DELETE("/lists/:id/members/:userId", handler.RemoveMember)
+
+	membersPath := "/lists/" + listID.String() + "/members"
+	tests := []struct {
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+	}{
+		{method: http.MethodGet, path: membersPath, expectedStatus: http.StatusOK},
+		{method: http.MethodGet, path: "/lists/" + uuid.NewString() + "/members", expectedStatus: http.StatusNotFound},
+		{method: http.MethodPut, path: membersPath + "/" + member.UserID.String(), body: `{"role": "editor"}`, expectedStatus: http.StatusOK},
+		{method: http.MethodPut, path: membersPath + "/" + uuid.NewString(), body: `{"role": "editor"}`, expectedStatus: http.StatusNotFound},
+		{method: http.MethodPut, path: membersPath + "/not-a-uuid", body: `{"role": "editor"}`, expectedStatus: http.StatusBadRequest},
+		{method: http.MethodPut, path: membersPath + "/" + member.UserID.String(), body: `{}`, expectedStatus: http.StatusBadRequest},
+		{method: http.MethodDelete, path: membersPath + "/" + member.UserID.String(), expectedStatus: http.StatusNoContent},
+		{method: http.MethodDelete, path: membersPath + "/" + uuid.NewString(), expectedStatus: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, tt.expectedStatus, w.Code, tt.method+" "+tt.path)
+	}
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/application/services"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// mergePatchContentType is the media type of RFC 7396 JSON merge-patch documents
+const mergePatchContentType = "application/merge-patch+json"
+
+// mergePatch holds the top-level members of a JSON merge-patch document
+type mergePatch map[string]json.RawMessage
+
+// readMergePatch decodes a merge-patch request body, writing an error response when it is unusable.
+// Plain application/json bodies are accepted as well.
+func readMergePatch(c *gin.Context) (mergePatch, bool) {
+	mediaType, _, err := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	if err != nil || (mediaType != mergePatchContentType && mediaType != "application/json") {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Content-Type must be " + mergePatchContentType})
+		return nil, false
+	}
+
+	var patch mergePatch
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	if patch == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "merge patch must be a JSON object"})
+		return nil, false
+	}
+	return patch, true
+}
+
+// isNull reports whether a member removes the field it targets
+func isNull(value json.RawMessage) bool {
+	return bytes.Equal(bytes.TrimSpace(value), []byte("null"))
+}
+
+// decodeRequired decodes a member whose field cannot be removed
+func decodeRequired[T any](field string, value json.RawMessage) (*T, error) {
+	if isNull(value) {
+		return nil, fmt.Errorf("%s cannot be null", field)
+	}
+	var v T
+	if err := json.Unmarshal(value, &v); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", field, err)
+	}
+	return &v, nil
+}
+
+// decodeNullable decodes a member whose field may be removed, reporting whether it was null
+func decodeNullable[T any](field string, value json.RawMessage) (*T, bool, error) {
+	if isNull(value) {
+		return nil, true, nil
+	}
+	v, err := decodeRequired[T](field, value)
+	return v, false, err
+}
+
+// decodeClearable decodes a text member whose field null empties
+func decodeClearable(field string, value json.RawMessage) (*string, error) {
+	if isNull(value) {
+		return new(string), nil
+	}
+	return decodeRequired[string](field, value)
+}
+
+// itemPatch converts a merge-patch document into the fields of an item to change
+func (p mergePatch) itemPatch() (services.ItemPatch, error) {
+	var patch services.ItemPatch
+	var err error
+	for field, value := range p {
+		switch field {
+		case "name":
+			patch.Name, err = decodeRequired[string](field, value)
+		case "quantity":
+			patch.Quantity, err = decodeRequired[float64](field, value)
+		case "unit":
+			patch.Unit, err = decodeRequired[entities.Unit](field, value)
+		case "category_id":
+			patch.CategoryID, patch.ClearCategory, err = decodeNullable[uuid.UUID](field, value)
+		case "unit_price":
+			patch.UnitPrice, patch.ClearUnitPrice, err = decodeNullable[int64](field, value)
+		case "currency":
+			patch.Currency, err = decodeRequired[string](field, value)
+		case "notes":
+			patch.Notes, err = decodeClearable(field, value)
+		case "brand":
+			patch.Brand, err = decodeClearable(field, value)
+		case "size":
+			patch.Size, err = decodeClearable(field, value)
+		case "completed":
+			patch.Completed, err = decodeRequired[bool](field, value)
+		default:
+			err = fmt.Errorf("%s cannot be patched", field)
+		}
+		if err != nil {
+			return services.ItemPatch{}, err
+		}
+	}
+	return patch, nil
+}
+
+// shoppingListPatch converts a merge-patch document into the fields of a shopping list to change
+func (p mergePatch) shoppingListPatch() (services.ShoppingListPatch, error) {
+	var patch services.ShoppingListPatch
+	var err error
+	for field, value := range p {
+		switch field {
+		case "name":
+			patch.Name, err = decodeRequired[string](field, value)
+		case "description":
+			var clear bool
+			patch.Description, clear, err = decodeNullable[string](field, value)
+			if clear {
+				patch.Description = new(string)
+			}
+		case "budget":
+			patch.Budget, patch.ClearBudget, err = decodeNullable[int64](field, value)
+		case "currency":
+			var clear bool
+			patch.Currency, clear, err = decodeNullable[string](field, value)
+			if clear {
+				patch.Currency = new(string)
+			}
+		default:
+			err = fmt.Errorf("%s cannot be patched", field)
+		}
+		if err != nil {
+			return services.ShoppingListPatch{}, err
+		}
+	}
+	return patch, nil
+}
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/application/services"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// PantryHandler handles HTTP requests for the pantry
+type PantryHandler struct {
+	service services.PantryServiceInterface
+}
+
+// NewPantryHandler creates a new pantry handler
+func NewPantryHandler(service services.PantryServiceInterface) *PantryHandler {
+	return &PantryHandler{service: service}
+}
+
+// PantryItemRequest represents the request body for creating or updating a pantry item.
+// A missing quantity means one; zero records that the item has run out.
+type PantryItemRequest struct {
+	Name      string        `json:"name" binding:"required"`
+	Quantity  *float64      `json:"quantity"`
+	Unit      entities.Unit `json:"unit"`
+	Location  string        `json:"location"`
+	ExpiresAt *time.Time    `json:"expires_at"`
+}
+
+// CreatePantryItem adds an item to the pantry
+func (h *PantryHandler) CreatePantryItem(c *gin.Context) {
+	var req PantryItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := h.service.CreatePantryItem(c.Request.Context(), req.toInput())
+	if err != nil {
+		if err == entities.ErrInvalidInput {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create pantry item"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, item)
+}
+
+// GetPantryItem retrieves a pantry item by ID
+func (h *PantryHandler) GetPantryItem(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	item, err := h.service.GetPantryItem(c.Request.Context(), id)
+	if err != nil {
+		if err == entities.ErrPantryItemNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Pantry item not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve pantry item"})
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// GetPantryItems retrieves the pantry, optionally filtered by ?location= and ?expires_before=
+func (h *PantryHandler) GetPantryItems(c *gin.Context) {
+	input := services.ListPantryItemsInput{Location: c.Query("location")}
+	if raw := c.Query("expires_before"); raw != "" {
+		expiresBefore, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "expires_before must be an RFC 3339 timestamp"})
+			return
+		}
+		input.ExpiresBefore = &expiresBefore
+	}
+
+	items, err := h.service.GetPantryItems(c.Request.Context(), input)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve pantry items"})
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// UpdatePantryItem replaces the attributes of a pantry item
+func (h *PantryHandler) UpdatePantryItem(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req PantryItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := h.service.UpdatePantryItem(c.Request.Context(), id, req.toInput())
+	if err != nil {
+		if err == entities.ErrPantryItemNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Pantry item not found"})
+			return
+		}
+		if err == entities.ErrInvalidInput {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update pantry item"})
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// DeletePantryItem removes an item from the pantry
+func (h *PantryHandler) DeletePantryItem(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	err = h.service.DeletePantryItem(c.Request.Context(), id)
+	if err != nil {
+		if err == entities.ErrPantryItemNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Pantry item not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete pantry item"})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// toInput converts the request to a service input, defaulting a missing quantity to 1
+func (req PantryItemRequest) toInput() services.PantryItemInput {
+	quantity := 1.0
+	if req.Quantity != nil {
+		quantity = *req.Quantity
+	}
+	return services.PantryItemInput{
+		Name:      req.Name,
+		Quantity:  quantity,
+		Unit:      req.Unit,
+		Location:  req.Location,
+		ExpiresAt: req.ExpiresAt,
+	}
+}
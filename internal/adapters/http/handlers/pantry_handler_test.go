@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/application/services"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// MockPantryService is a mock implementation of the pantry service interface
+type MockPantryService struct {
+	mock.Mock
+}
+
+// Ensure MockPantryService implements the interface
+var _ services.PantryServiceInterface = (*MockPantryService)(nil)
+
+func (m *MockPantryService) CreatePantryItem(ctx context.Context, input services.PantryItemInput) (*entities.PantryItem, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PantryItem), args.Error(1)
+}
+
+func (m *MockPantryService) GetPantryItem(ctx context.Context, id uuid.UUID) (*entities.PantryItem, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PantryItem), args.Error(1)
+}
+
+func (m *MockPantryService) GetPantryItems(ctx context.Context, input services.ListPantryItemsInput) ([]*entities.PantryItem, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.PantryItem), args.Error(1)
+}
+
+func (m *MockPantryService) UpdatePantryItem(
+	ctx context.Context,
+	id uuid.UUID,
+	input services.PantryItemInput,
+) (*entities.PantryItem, error) {
+	args := m.Called(ctx, id, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PantryItem), args.Error(1)
+}
+
+func (m *MockPantryService) DeletePantryItem(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestPantryHandler_CreatePantryItem(t *testing.T) {
+	expiresAt := time.Date(2026, 10, 30, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(*MockPantryService)
+		expectedStatus int
+		expectedBody   func(*testing.T, map[string]interface{})
+	}{
+		{
+			name: "creates a pantry item",
+			body: `{"name": "Eggs", "quantity": 6, "location": "fridge", "expires_at": "2026-10-30T00:00:00Z"}`,
+			mockSetup: func(m *MockPantryService) {
+				input := services.PantryItemInput{Name: "Eggs", Quantity: 6, Location: "fridge", ExpiresAt: &expiresAt}
+				item := entities.NewPantryItem("Eggs", 6, entities.UnitPieces)
+				item.Location = "fridge"
+				m.On("CreatePantryItem", mock.Anything, input).Return(item, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Eggs", body["name"])
+				assert.Equal(t, "fridge", body["location"])
+			},
+		},
+		{
+			name: "defaults a missing quantity to one but keeps zero",
+			body: `{"name": "Rice", "quantity": 0, "unit": "kg"}`,
+			mockSetup: func(m *MockPantryService) {
+				input := services.PantryItemInput{Name: "Rice", Quantity: 0, Unit: entities.UnitKilogram}
+				m.On("CreatePantryItem", mock.Anything, input).Return(entities.NewPantryItem("Rice", 0, entities.UnitKilogram), nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, float64(0), body["quantity"])
+			},
+		},
+		{
+			name:           "fails without a name",
+			body:           `{"quantity": 1}`,
+			mockSetup:      func(m *MockPantryService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Contains(t, body["error"], "Name")
+			},
+		},
+		{
+			name: "fails with invalid input",
+			body: `{"name": "Eggs", "quantity": -1}`,
+			mockSetup: func(m *MockPantryService) {
+				m.On("CreatePantryItem", mock.Anything, mock.Anything).Return(nil, entities.ErrInvalidInput)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "invalid input", body["error"])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockPantryService{}
+			tt.mockSetup(mockService)
+
+			handler := NewPantryHandler(mockService)
+			router := setupTestRouter()
+			router.POST("/pantry", handler.CreatePantryItem)
+
+			req := httptest.NewRequest(http.MethodPost, "/pantry", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var responseBody map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &responseBody))
+
+			tt.expectedBody(t, responseBody)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPantryHandler_GetPantryItems(t *testing.T) {
+	expiresBefore := time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		query          string
+		mockSetup      func(*MockPantryService)
+		expectedStatus int
+	}{
+		{
+			name:  "passes the filters",
+			query: "?location=fridge&expires_before=2026-10-23T00:00:00Z",
+			mockSetup: func(m *MockPantryService) {
+				input := services.ListPantryItemsInput{Location: "fridge", ExpiresBefore: &expiresBefore}
+				m.On("GetPantryItems", mock.Anything, input).Return([]*entities.PantryItem{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "fails with invalid expires_before",
+			query:          "?expires_before=next-week",
+			mockSetup:      func(m *MockPantryService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "fails with internal error",
+			query: "",
+			mockSetup: func(m *MockPantryService) {
+				m.On("GetPantryItems", mock.Anything, services.ListPantryItemsInput{}).Return(nil, fmt.Errorf("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockPantryService{}
+			tt.mockSetup(mockService)
+
+			handler := NewPantryHandler(mockService)
+			router := setupTestRouter()
+			router.GET("/pantry", handler.GetPantryItems)
+
+			req := httptest.NewRequest(http.MethodGet, "/pantry"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPantryHandler_UpdatePantryItem(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             string
+		mockSetup      func(*MockPantryService)
+		expectedStatus int
+	}{
+		{
+			name: "updates the pantry item",
+			id:   uuid.New().String(),
+			mockSetup: func(m *MockPantryService) {
+				input := services.PantryItemInput{Name: "Milk", Quantity: 1}
+				m.On("UpdatePantryItem", mock.Anything, mock.AnythingOfType("uuid.UUID"), input).Return(entities.NewPantryItem("Milk", 1, entities.UnitPieces), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "fails when the pantry item does not exist",
+			id:   uuid.New().String(),
+			mockSetup: func(m *MockPantryService) {
+				m.On("UpdatePantryItem", mock.Anything, mock.Anything, mock.Anything).Return(nil, entities.ErrPantryItemNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "fails with invalid UUID",
+			id:             "invalid-uuid",
+			mockSetup:      func(m *MockPantryService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockPantryService{}
+			tt.mockSetup(mockService)
+
+			handler := NewPantryHandler(mockService)
+			router := setupTestRouter()
+			router.PUT("/pantry/:id", handler.UpdatePantryItem)
+
+			req := httptest.NewRequest(http.MethodPut, "/pantry/"+tt.id, bytes.NewBufferString(`{"name": "Milk"}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPantryHandler_GetAndDeletePantryItem(t *testing.T) {
+	mockService := &MockPantryService{}
+	item := entities.NewPantryItem("Milk", 1, entities.UnitLiter)
+	missing := uuid.New()
+	mockService.On("GetPantryItem", mock.Anything, item.ID).Return(item, nil)
+	mockService.On("GetPantryItem", mock.Anything, missing).Return(nil, entities.ErrPantryItemNotFound)
+	mockService.On("DeletePantryItem", mock.Anything, item.ID).Return(nil)
+	mockService.On("DeletePantryItem", mock.Anything, missing).Return(entities.ErrPantryItemNotFound)
+
+	handler := NewPantryHandler(mockService)
+	router := setupTestRouter()
+	router.GET("/pantry/:id", handler.GetPantryItem)
+	router.DELETE("/pantry/:id", handler.DeletePantryItem)
+
+	requests := []struct {
+		method         string
+		id             uuid.UUID
+		expectedStatus int
+	}{
+		{method: http.MethodGet, id: item.ID, expectedStatus: http.StatusOK},
+		{method: http.MethodGet, id: missing, expectedStatus: http.StatusNotFound},
+		{method: http.MethodDelete, id: item.ID, expectedStatus: http.StatusNoContent},
+		{method: http.MethodDelete, id: missing, expectedStatus: http.StatusNotFound},
+	}
+	for _, r := range requests {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(r.method, "/pantry/"+r.id.String(), nil))
+		assert.Equal(t, r.expectedStatus, w.Code, "%s %s", r.method, r.id)
+	}
+	mockService.AssertExpectations(t)
+}
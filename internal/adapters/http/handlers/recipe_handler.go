@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/application/services"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// RecipeHandler handles HTTP requests for recipes
+type RecipeHandler struct {
+	service services.RecipeServiceInterface
+}
+
+// NewRecipeHandler creates a new recipe handler
+func NewRecipeHandler(service services.RecipeServiceInterface) *RecipeHandler {
+	return &RecipeHandler{service: service}
+}
+
+// ImportRecipeRequest represents the request body for importing a recipe.
+// Text holds one ingredient per line; ServingsFactor scales the quantities and defaults to 1.
+type ImportRecipeRequest struct {
+	Text           string  `json:"text" binding:"required"`
+	ServingsFactor float64 `json:"servings_factor"`
+}
+
+// ImportedIngredientResponse represents an ingredient line that was understood.
+// Status is the HTTP status adding the item would have had on its own.
+type ImportedIngredientResponse struct {
+	Line     string         `json:"line"`
+	Name     string         `json:"name"`
+	Quantity float64        `json:"quantity"`
+	Unit     entities.Unit  `json:"unit"`
+	Status   int            `json:"status"`
+	Item     *entities.Item `json:"item,omitempty"`
+	Error    string         `json:"error,omitempty"`
+}
+
+// ImportRecipeResponse represents the outcome of a recipe import
+type ImportRecipeResponse struct {
+	Ingredients []ImportedIngredientResponse `json:"ingredients"`
+	Unparsed    []string                     `json:"unparsed"`
+}
+
+// ImportRecipe adds the ingredients of a recipe to a shopping list.
+// When no line can be understood it answers 422 Unprocessable Entity.
+func (h *RecipeHandler) ImportRecipe(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req ImportRecipeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	input := services.ImportRecipeInput{Text: req.Text, ServingsFactor: req.ServingsFactor}
+	result, err := h.service.ImportRecipe(c.Request.Context(), id, input)
+	if err != nil {
+		if err == entities.ErrForbidden {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Not allowed to change this shopping list"})
+			return
+		}
+		if err == entities.ErrShoppingListNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Shopping list not found"})
+			return
+		}
+		if err == entities.ErrInvalidInput {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf(
+					"A recipe needs at most %d ingredients and a servings factor between 0 and %d",
+					services.MaxBatchOperations, services.MaxServingsFactor,
+				),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import recipe"})
+		return
+	}
+
+	response := ImportRecipeResponse{
+		Ingredients: make([]ImportedIngredientResponse, len(result.Ingredients)),
+		Unparsed:    append([]string{}, result.Unparsed...),
+	}
+	for i, imported := range result.Ingredients {
+		status, message := itemOperationStatus(services.ItemOpCreate, services.ItemOperationResult{
+			Item:    imported.Item,
+			Created: imported.Created,
+			Err:     imported.Err,
+		})
+		response.Ingredients[i] = ImportedIngredientResponse{
+			Line:     imported.Line,
+			Name:     imported.Ingredient.Name,
+			Quantity: imported.Ingredient.Quantity,
+			Unit:     imported.Ingredient.Unit,
+			Status:   status,
+			Item:     imported.Item,
+			Error:    message,
+		}
+	}
+
+	if len(result.Ingredients) == 0 {
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/application/services"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// MockRecipeService is a mock implementation of the recipe service interface
+type MockRecipeService struct {
+	mock.Mock
+}
+
+// Ensure MockRecipeService implements the interface
+var _ services.RecipeServiceInterface = (*MockRecipeService)(nil)
+
+func (m *MockRecipeService) ImportRecipe(
+	ctx context.Context,
+	shoppingListID uuid.UUID,
+	input services.ImportRecipeInput,
+) (*services.RecipeImport, error) {
+	args := m.Called(ctx, shoppingListID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.RecipeImport), args.Error(1)
+}
+
+func TestRecipeHandler_ImportRecipe(t *testing.T) {
+	listID := uuid.New()
+	eggs := entities.NewItem("eggs", 6)
+
+	tests := []struct {
+		name           string
+		id             string
+		body           string
+		mockSetup      func(*MockRecipeService)
+		expectedStatus int
+		expectedBody   func(*testing.T, map[string]interface{})
+	}{
+		{
+			name: "reports parsed and unparsed lines",
+			id:   listID.String(),
+			body: `{"text": "3 eggs\n100 g sugar\nsalt to taste", "servings_factor": 2}`,
+			mockSetup: func(m *MockRecipeService) {
+				input := services.ImportRecipeInput{Text: "3 eggs\n100 g sugar\nsalt to taste", ServingsFactor: 2}
+				m.On("ImportRecipe", mock.Anything, listID, input).Return(&services.RecipeImport{
+					Ingredients: []services.ImportedIngredient{
+						{Line: "3 eggs", Ingredient: entities.Ingredient{Name: "eggs", Quantity: 6, Unit: entities.UnitPieces}, Item: eggs, Created: true},
+						{Line: "100 g sugar", Ingredient: entities.Ingredient{Name: "sugar", Quantity: 200, Unit: entities.UnitGram}, Err: entities.ErrDuplicateItem},
+					},
+					Unparsed: []string{"salt to taste"},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				ingredients := body["ingredients"].([]interface{})
+				require.Len(t, ingredients, 2)
+				created := ingredients[0].(map[string]interface{})
+				assert.Equal(t, "eggs", created["name"])
+				assert.Equal(t, float64(http.StatusCreated), created["status"])
+				assert.NotNil(t, created["item"])
+				conflict := ingredients[1].(map[string]interface{})
+				assert.Equal(t, float64(http.StatusConflict), conflict["status"])
+				assert.NotEmpty(t, conflict["error"])
+				assert.Equal(t, []interface{}{"salt to taste"}, body["unparsed"])
+			},
+		},
+		{
+			name: "fails when no line is understood",
+			id:   listID.String(),
+			body: `{"text": "salt to taste"}`,
+			mockSetup: func(m *MockRecipeService) {
+				m.On("ImportRecipe", mock.Anything, listID, mock.Anything).Return(&services.RecipeImport{Unparsed: []string{"salt to taste"}}, nil)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Empty(t, body["ingredients"])
+				assert.Equal(t, []interface{}{"salt to taste"}, body["unparsed"])
+			},
+		},
+		{
+			name:           "fails without text",
+			id:             listID.String(),
+			body:           `{"servings_factor": 2}`,
+			mockSetup:      func(m *MockRecipeService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   func(t *testing.T, body map[string]interface{}) { assert.NotEmpty(t, body["error"]) },
+		},
+		{
+			name:           "fails with invalid UUID",
+			id:             "invalid-uuid",
+			body:           `{"text": "3 eggs"}`,
+			mockSetup:      func(m *MockRecipeService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   func(t *testing.T, body map[string]interface{}) { assert.Equal(t, "Invalid ID format", body["error"]) },
+		},
+		{
+			name: "fails with an invalid servings factor",
+			id:   listID.String(),
+			body: `{"text": "3 eggs", "servings_factor": -1}`,
+			mockSetup: func(m *MockRecipeService) {
+				m.On("ImportRecipe", mock.Anything, listID, mock.Anything).Return(nil, entities.ErrInvalidInput)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   func(t *testing.T, body map[string]interface{}) { assert.Contains(t, body["error"], "servings factor") },
+		},
+		{
+			name: "fails when the shopping list does not exist",
+			id:   listID.String(),
+			body: `{"text": "3 eggs"}`,
+			mockSetup: func(m *MockRecipeService) {
+				m.On("ImportRecipe", mock.Anything, listID, mock.Anything).Return(nil, entities.ErrShoppingListNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Shopping list not found", body["error"])
+			},
+		},
+		{
+			name: "fails with internal error",
+			id:   listID.String(),
+			body: `{"text": "3 eggs"}`,
+			mockSetup: func(m *MockRecipeService) {
+				m.On("ImportRecipe", mock.Anything, listID, mock.Anything).Return(nil, fmt.Errorf("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Failed to import recipe", body["error"])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockRecipeService{}
+			tt.mockSetup(mockService)
+
+			handler := NewRecipeHandler(mockService)
+			router := setupTestRouter()
+			router.POST("/lists/:id/import/recipe", handler.ImportRecipe)
+
+			req := httptest.NewRequest(http.MethodPost, "/lists/"+tt.id+"/import/recipe", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var responseBody map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &responseBody))
+
+			tt.expectedBody(t, responseBody)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/application/services"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// SearchHandler handles HTTP requests for searching shopping lists and items
+type SearchHandler struct {
+	service services.SearchServiceInterface
+}
+
+// NewSearchHandler creates a new search handler
+func NewSearchHandler(service services.SearchServiceInterface) *SearchHandler {
+	return &SearchHandler{service: service}
+}
+
+// SearchListContext identifies the shopping list a search hit belongs to
+type SearchListContext struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+}
+
+// SearchHitResponse represents a shopping list or item matching a search.
+// Item is only set for item hits.
+type SearchHitResponse struct {
+	Kind entities.SearchHitKind `json:"kind"`
+	Rank float64                `json:"rank"`
+	List SearchListContext      `json:"list"`
+	Item *entities.Item         `json:"item,omitempty"`
+}
+
+// SearchResponse represents the hits of a search, best first
+type SearchResponse struct {
+	Query string              `json:"query"`
+	Hits  []SearchHitResponse `json:"hits"`
+}
+
+// Search finds the shopping lists and items matching the q query parameter
+func (h *SearchHandler) Search(c *gin.Context) {
+	query := c.Query("q")
+
+	limit := 0
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return
+		}
+		limit = parsed
+	}
+
+	hits, err := h.service.Search(c.Request.Context(), query, limit)
+	if err != nil {
+		if err == entities.ErrInvalidInput {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("q must hold 1 to %d characters and limit be at most %d", services.MaxSearchLength, services.MaxPageSize),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search"})
+		return
+	}
+
+	response := SearchResponse{Query: query, Hits: make([]SearchHitResponse, 0, len(hits))}
+	for _, hit := range hits {
+		if hit.List == nil {
+			continue
+		}
+		response.Hits = append(response.Hits, SearchHitResponse{
+			Kind: hit.Kind,
+			Rank: hit.Rank,
+			List: SearchListContext{ID: hit.List.ID, Name: hit.List.Name, Description: hit.List.Description},
+			Item: hit.Item,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
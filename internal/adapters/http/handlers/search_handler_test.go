@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/application/services"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// MockSearchService is a mock implementation of the search service interface
+type MockSearchService struct {
+	mock.Mock
+}
+
+// Ensure MockSearchService implements the interface
+var _ services.SearchServiceInterface = (*MockSearchService)(nil)
+
+func (m *MockSearchService) Search(ctx context.Context, text string, limit int) ([]entities.SearchHit, error) {
+	args := m.Called(ctx, text, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.SearchHit), args.Error(1)
+}
+
+func TestSearchHandler_Search(t *testing.T) {
+	list := entities.NewShoppingList("Hardware store", "Saturday errands")
+	item := entities.NewItem("AA batteries", 8)
+	item.ShoppingListID = list.ID
+
+	tests := []struct {
+		name           string
+		query          string
+		mockSetup      func(*MockSearchService)
+		expectedStatus int
+		expectedBody   func(*testing.T, map[string]interface{})
+	}{
+		{
+			name:  "returns hits with their list",
+			query: "?q=batteries",
+			mockSetup: func(m *MockSearchService) {
+				m.On("Search", mock.Anything, "batteries", 0).Return([]entities.SearchHit{
+					{Kind: entities.SearchHitItem, Rank: 0.6, List: list, Item: item},
+					{Kind: entities.SearchHitList, Rank: 0.2, List: list},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "batteries", body["query"])
+				hits := body["hits"].([]interface{})
+				require.Len(t, hits, 2)
+				first := hits[0].(map[string]interface{})
+				assert.Equal(t, "item", first["kind"])
+				assert.Equal(t, 0.6, first["rank"])
+				assert.Equal(t, "AA batteries", first["item"].(map[string]interface{})["name"])
+				assert.Equal(t, map[string]interface{}{
+					"id":          list.ID.String(),
+					"name":        "Hardware store",
+					"description": "Saturday errands",
+				}, first["list"])
+				assert.NotContains(t, hits[1], "item")
+			},
+		},
+		{
+			name:  "returns no hits",
+			query: "?q=caviar&limit=5",
+			mockSetup: func(m *MockSearchService) {
+				m.On("Search", mock.Anything, "caviar", 5).Return([]entities.SearchHit{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, []interface{}{}, body["hits"])
+			},
+		},
+		{
+			name:           "invalid limit",
+			query:          "?q=batteries&limit=many",
+			mockSetup:      func(m *MockSearchService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Invalid limit", body["error"])
+			},
+		},
+		{
+			name:  "missing query",
+			query: "",
+			mockSetup: func(m *MockSearchService) {
+				m.On("Search", mock.Anything, "", 0).Return(nil, entities.ErrInvalidInput)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "q must hold 1 to 200 characters and limit be at most 100", body["error"])
+			},
+		},
+		{
+			name:  "internal server error",
+			query: "?q=batteries",
+			mockSetup: func(m *MockSearchService) {
+				m.On("Search", mock.Anything, "batteries", 0).Return(nil, fmt.Errorf("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Failed to search", body["error"])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockSearchService{}
+			tt.mockSetup(mockService)
+
+			handler := NewSearchHandler(mockService)
+			router := setupTestRouter()
+			router.GET("/search", handler.Search)
+
+			req := httptest.NewRequest(http.MethodGet, "/search"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var responseBody map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &responseBody))
+
+			tt.expectedBody(t, responseBody)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
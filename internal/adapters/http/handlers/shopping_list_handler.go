@@ -2,7 +2,10 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -24,12 +27,22 @@ func NewShoppingListHandler(service services.ShoppingListServiceInterface) *Shop
 type CreateShoppingListRequest struct {
 	Name        string `json:"name" binding:"required"`
 	Description string `json:"description"`
+	Budget      *int64 `json:"budget"`
+	Currency    string `json:"currency"`
 }
 
 // UpdateShoppingListRequest represents the request body for updating a shopping list
 type UpdateShoppingListRequest struct {
 	Name        string `json:"name" binding:"required"`
 	Description string `json:"description"`
+	Budget      *int64 `json:"budget"`
+	Currency    string `json:"currency"`
+}
+
+// ShoppingListResponse represents a shopping list with its computed money totals
+type ShoppingListResponse struct {
+	*entities.ShoppingList
+	Totals []entities.ListTotals `json:"totals"`
 }
 
 // CreateShoppingList creates a new shopping list
@@ -40,7 +53,12 @@ func (h *ShoppingListHandler) CreateShoppingList(c *gin.Context) {
 		return
 	}
 
-	list, err := h.service.CreateShoppingList(c.Request.Context(), req.Name, req.Description)
+	list, err := h.service.CreateShoppingList(c.Request.Context(), services.ShoppingListInput{
+		Name:        req.Name,
+		Description: req.Description,
+		Budget:      req.Budget,
+		Currency:    req.Currency,
+	})
 	if err != nil {
 		if err == entities.ErrInvalidInput {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -50,10 +68,20 @@ func (h *ShoppingListHandler) CreateShoppingList(c *gin.Context) {
 		return
 	}
 
+	setETag(c, list.Version)
 	c.JSON(http.StatusCreated, list)
 }
 
-// GetShoppingList retrieves a shopping list by ID
+// GroupedShoppingListResponse represents a shopping list whose items are grouped by category
+type GroupedShoppingListResponse struct {
+	*entities.ShoppingList
+	Items  []entities.Item       `json:"items,omitempty"`
+	Groups []entities.ItemGroup  `json:"groups"`
+	Totals []entities.ListTotals `json:"totals"`
+}
+
+// GetShoppingList retrieves a shopping list by ID along with its money totals.
+// With ?group_by=category the items are returned grouped by category.
 func (h *ShoppingListHandler) GetShoppingList(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := uuid.Parse(idParam)
@@ -62,6 +90,16 @@ func (h *ShoppingListHandler) GetShoppingList(c *gin.Context) {
 		return
 	}
 
+	switch c.Query("group_by") {
+	case "":
+	case "category":
+		h.getShoppingListGroupedByCategory(c, id)
+		return
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid group_by value, expected 'category'"})
+		return
+	}
+
 	list, err := h.service.GetShoppingList(c.Request.Context(), id)
 	if err != nil {
 		if err == entities.ErrShoppingListNotFound {
@@ -72,21 +110,105 @@ func (h *ShoppingListHandler) GetShoppingList(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, list)
+	setETag(c, list.Version)
+	c.JSON(http.StatusOK, ShoppingListResponse{ShoppingList: list, Totals: list.Totals()})
+}
+
+// getShoppingListGroupedByCategory writes a shopping list with its items grouped by category
+func (h *ShoppingListHandler) getShoppingListGroupedByCategory(c *gin.Context, id uuid.UUID) {
+	list, groups, err := h.service.GetShoppingListGroupedByCategory(c.Request.Context(), id)
+	if err != nil {
+		if err == entities.ErrShoppingListNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Shopping list not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve shopping list"})
+		return
+	}
+
+	setETag(c, list.Version)
+	c.JSON(http.StatusOK, GroupedShoppingListResponse{ShoppingList: list, Groups: groups, Totals: list.Totals()})
 }
 
-// GetAllShoppingLists retrieves all shopping lists
+// PagingResponse describes a page of a paginated listing
+type PagingResponse struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// ShoppingListPageResponse represents a page of shopping lists
+type ShoppingListPageResponse struct {
+	Lists  interface{}    `json:"lists"`
+	Paging PagingResponse `json:"paging"`
+}
+
+// ShoppingListSummaryResponse represents a shopping list listed without its items
+type ShoppingListSummaryResponse struct {
+	*entities.ShoppingList
+	Items      []entities.Item      `json:"items,omitempty"`
+	ItemCounts *entities.ItemCounts `json:"item_counts,omitempty"`
+}
+
+// GetAllShoppingLists retrieves a page of shopping lists.
+// It supports ?limit, ?cursor, ?sort (name, created_at or updated_at, "-" for descending),
+// ?name to match part of the name and ?updated_since with an RFC 3339 timestamp.
+// ?include=counts replaces the items of each list with item counts and ?include=none drops them.
 func (h *ShoppingListHandler) GetAllShoppingLists(c *gin.Context) {
-	lists, err := h.service.GetAllShoppingLists(c.Request.Context())
+	input := services.ListShoppingListsInput{
+		Cursor:       c.Query("cursor"),
+		Sort:         c.Query("sort"),
+		NameContains: c.Query("name"),
+		Include:      services.ListInclude(c.Query("include")),
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return
+		}
+		input.Limit = value
+	}
+
+	if updatedSince := c.Query("updated_since"); updatedSince != "" {
+		value, err := time.Parse(time.RFC3339, updatedSince)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid updated_since, expected an RFC 3339 timestamp"})
+			return
+		}
+		input.UpdatedSince = &value
+	}
+
+	page, err := h.service.GetAllShoppingLists(c.Request.Context(), input)
 	if err != nil {
+		if err == entities.ErrInvalidInput {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit, cursor, sort or include"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve shopping lists"})
 		return
 	}
 
-	c.JSON(http.StatusOK, lists)
+	response := ShoppingListPageResponse{
+		Lists:  page.Lists,
+		Paging: PagingResponse{Limit: page.Limit, NextCursor: page.NextCursor},
+	}
+	if input.Include == services.IncludeCounts || input.Include == services.IncludeNone {
+		summaries := make([]ShoppingListSummaryResponse, len(page.Lists))
+		for i, list := range page.Lists {
+			summaries[i] = ShoppingListSummaryResponse{ShoppingList: list}
+			if input.Include == services.IncludeCounts {
+				counts := page.ItemCounts[list.ID]
+				summaries[i].ItemCounts = &counts
+			}
+		}
+		response.Lists = summaries
+	}
+
+	c.JSON(http.StatusOK, response)
 }
 
-// UpdateShoppingList updates an existing shopping list
+// UpdateShoppingList updates an existing shopping list, honoring If-Match
 func (h *ShoppingListHandler) UpdateShoppingList(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := uuid.Parse(idParam)
@@ -95,18 +217,89 @@ func (h *ShoppingListHandler) UpdateShoppingList(c *gin.Context) {
 		return
 	}
 
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
 	var req UpdateShoppingListRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	list, err := h.service.UpdateShoppingList(c.Request.Context(), id, req.Name, req.Description)
+	list, err := h.service.UpdateShoppingList(c.Request.Context(), id, services.ShoppingListInput{
+		Name:        req.Name,
+		Description: req.Description,
+		Budget:      req.Budget,
+		Currency:    req.Currency,
+		Version:     version,
+	})
+	if err != nil {
+		if err == entities.ErrForbidden {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Not allowed to change this shopping list"})
+			return
+		}
+		if err == entities.ErrShoppingListNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Shopping list not found"})
+			return
+		}
+		if err == entities.ErrVersionConflict {
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": "Shopping list has been modified since it was read"})
+			return
+		}
+		if err == entities.ErrInvalidInput {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update shopping list"})
+		return
+	}
+
+	setETag(c, list.Version)
+	c.JSON(http.StatusOK, list)
+}
+
+// PatchShoppingList applies a JSON merge-patch document to a shopping list, honoring If-Match
+func (h *ShoppingListHandler) PatchShoppingList(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	doc, ok := readMergePatch(c)
+	if !ok {
+		return
+	}
+
+	patch, err := doc.shoppingListPatch()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	patch.Version = version
+
+	list, err := h.service.PatchShoppingList(c.Request.Context(), id, patch)
 	if err != nil {
+		if err == entities.ErrForbidden {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Not allowed to change this shopping list"})
+			return
+		}
 		if err == entities.ErrShoppingListNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Shopping list not found"})
 			return
 		}
+		if err == entities.ErrVersionConflict {
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": "Shopping list has been modified since it was read"})
+			return
+		}
 		if err == entities.ErrInvalidInput {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
@@ -115,6 +308,7 @@ func (h *ShoppingListHandler) UpdateShoppingList(c *gin.Context) {
 		return
 	}
 
+	setETag(c, list.Version)
 	c.JSON(http.StatusOK, list)
 }
 
@@ -129,6 +323,10 @@ func (h *ShoppingListHandler) DeleteShoppingList(c *gin.Context) {
 
 	err = h.service.DeleteShoppingList(c.Request.Context(), id)
 	if err != nil {
+		if err == entities.ErrForbidden {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only owners can delete the shopping list"})
+			return
+		}
 		if err == entities.ErrShoppingListNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Shopping list not found"})
 			return
@@ -139,3 +337,96 @@ func (h *ShoppingListHandler) DeleteShoppingList(c *gin.Context) {
 
 	c.JSON(http.StatusNoContent, nil)
 }
+
+// CloneShoppingListRequest represents the optional request body for cloning a shopping list
+type CloneShoppingListRequest struct {
+	Name            string `json:"name"`
+	ResetCompletion bool   `json:"reset_completion"`
+	IncompleteOnly  bool   `json:"incomplete_only"`
+}
+
+// CloneShoppingList copies a shopping list and its items into a new list
+func (h *ShoppingListHandler) CloneShoppingList(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req CloneShoppingListRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	list, err := h.service.CloneShoppingList(c.Request.Context(), id, services.CloneShoppingListInput{
+		Name:            req.Name,
+		ResetCompletion: req.ResetCompletion,
+		IncompleteOnly:  req.IncompleteOnly,
+	})
+	if err != nil {
+		if err == entities.ErrShoppingListNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Shopping list not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to clone shopping list"})
+		return
+	}
+
+	setETag(c, list.Version)
+	c.JSON(http.StatusCreated, list)
+}
+
+// ListActionResponse reports how many items a list action affected
+type ListActionResponse struct {
+	Affected int64 `json:"affected"`
+}
+
+// ClearCompleted moves the completed items of a shopping list to the trash
+func (h *ShoppingListHandler) ClearCompleted(c *gin.Context) {
+	h.runListAction(c, h.service.ClearCompleted, "Failed to clear completed items")
+}
+
+// CompleteAll marks every item of a shopping list as completed
+func (h *ShoppingListHandler) CompleteAll(c *gin.Context) {
+	h.runListAction(c, h.service.CompleteAll, "Failed to complete items")
+}
+
+// ResetShoppingList marks every item of a shopping list as not completed
+func (h *ShoppingListHandler) ResetShoppingList(c *gin.Context) {
+	h.runListAction(c, h.service.ResetShoppingList, "Failed to reset shopping list")
+}
+
+// runListAction applies an action to the items of the shopping list named in the path
+// and writes the number of affected items
+func (h *ShoppingListHandler) runListAction(
+	c *gin.Context,
+	action func(ctx context.Context, id uuid.UUID) (int64, error),
+	failure string,
+) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	affected, err := action(c.Request.Context(), id)
+	if err != nil {
+		if err == entities.ErrForbidden {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Not allowed to change this shopping list"})
+			return
+		}
+		if err == entities.ErrShoppingListNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Shopping list not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": failure})
+		return
+	}
+
+	c.JSON(http.StatusOK, ListActionResponse{Affected: affected})
+}
@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -28,9 +30,9 @@ var _ services.ShoppingListServiceInterface = (*MockShoppingListService)(nil)
 
 func (m *MockShoppingListService) CreateShoppingList(
 	ctx context.Context,
-	name, description string,
+	input services.ShoppingListInput,
 ) (*entities.ShoppingList, error) {
-	args := m.Called(ctx, name, description)
+	args := m.Called(ctx, input)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -45,20 +47,46 @@ func (m *MockShoppingListService) GetShoppingList(ctx context.Context, id uuid.U
 	return args.Get(0).(*entities.ShoppingList), args.Error(1)
 }
 
-func (m *MockShoppingListService) GetAllShoppingLists(ctx context.Context) ([]*entities.ShoppingList, error) {
-	args := m.Called(ctx)
+func (m *MockShoppingListService) GetShoppingListGroupedByCategory(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.ShoppingList, []entities.ItemGroup, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*entities.ShoppingList), args.Get(1).([]entities.ItemGroup), args.Error(2)
+}
+
+func (m *MockShoppingListService) GetAllShoppingLists(
+	ctx context.Context,
+	input services.ListShoppingListsInput,
+) (*services.ShoppingListPage, error) {
+	args := m.Called(ctx, input)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*entities.ShoppingList), args.Error(1)
+	return args.Get(0).(*services.ShoppingListPage), args.Error(1)
 }
 
 func (m *MockShoppingListService) UpdateShoppingList(
 	ctx context.Context,
 	id uuid.UUID,
-	name, description string,
+	input services.ShoppingListInput,
 ) (*entities.ShoppingList, error) {
-	args := m.Called(ctx, id, name, description)
+	args := m.Called(ctx, id, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ShoppingList), args.Error(1)
+}
+
+func (m *MockShoppingListService) PatchShoppingList(
+	ctx context.Context,
+	id uuid.UUID,
+	patch services.ShoppingListPatch,
+) (*entities.ShoppingList, error) {
+	args := m.Called(ctx, id, patch)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -70,6 +98,33 @@ func (m *MockShoppingListService) DeleteShoppingList(ctx context.Context, id uui
 	return args.Error(0)
 }
 
+func (m *MockShoppingListService) CloneShoppingList(
+	ctx context.Context,
+	id uuid.UUID,
+	input services.CloneShoppingListInput,
+) (*entities.ShoppingList, error) {
+	args := m.Called(ctx, id, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ShoppingList), args.Error(1)
+}
+
+func (m *MockShoppingListService) ClearCompleted(ctx context.Context, id uuid.UUID) (int64, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockShoppingListService) CompleteAll(ctx context.Context, id uuid.UUID) (int64, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockShoppingListService) ResetShoppingList(ctx context.Context, id uuid.UUID) (int64, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func setupTestRouter() *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	return gin.New()
@@ -104,7 +159,7 @@ func TestShoppingListHandler_CreateShoppingList(t *testing.T) {
 					Description: "Weekly groceries",
 					Items:       []entities.Item{},
 				}
-				m.On("CreateShoppingList", mock.Anything, "Grocery List", "Weekly groceries").Return(expectedList, nil)
+				m.On("CreateShoppingList", mock.Anything, services.ShoppingListInput{Name: "Grocery List", Description: "Weekly groceries"}).Return(expectedList, nil)
 			},
 			expectedStatus: http.StatusCreated,
 			expectedBody: func(t *testing.T, body map[string]interface{}) {
@@ -126,7 +181,7 @@ func TestShoppingListHandler_CreateShoppingList(t *testing.T) {
 					Description: "",
 					Items:       []entities.Item{},
 				}
-				m.On("CreateShoppingList", mock.Anything, "Quick List", "").Return(expectedList, nil)
+				m.On("CreateShoppingList", mock.Anything, services.ShoppingListInput{Name: "Quick List"}).Return(expectedList, nil)
 			},
 			expectedStatus: http.StatusCreated,
 			expectedBody: func(t *testing.T, body map[string]interface{}) {
@@ -159,7 +214,7 @@ func TestShoppingListHandler_CreateShoppingList(t *testing.T) {
 				Description: "Test",
 			},
 			mockSetup: func(m *MockShoppingListService) {
-				m.On("CreateShoppingList", mock.Anything, "ValidName", "Test").Return(nil, entities.ErrInvalidInput)
+				m.On("CreateShoppingList", mock.Anything, services.ShoppingListInput{Name: "ValidName", Description: "Test"}).Return(nil, entities.ErrInvalidInput)
 			},
 			expectedStatus: http.StatusBadRequest,
 			expectedBody: func(t *testing.T, body map[string]interface{}) {
@@ -173,7 +228,7 @@ func TestShoppingListHandler_CreateShoppingList(t *testing.T) {
 				Description: "Test",
 			},
 			mockSetup: func(m *MockShoppingListService) {
-				m.On("CreateShoppingList", mock.Anything, "Test List", "Test").Return(nil, fmt.Errorf("database error"))
+				m.On("CreateShoppingList", mock.Anything, services.ShoppingListInput{Name: "Test List", Description: "Test"}).Return(nil, fmt.Errorf("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: func(t *testing.T, body map[string]interface{}) {
@@ -242,6 +297,35 @@ func TestShoppingListHandler_GetShoppingList(t *testing.T) {
 			expectedBody: func(t *testing.T, body map[string]interface{}) {
 				assert.Equal(t, "Test List", body["name"])
 				assert.Equal(t, "Test Description", body["description"])
+				assert.Empty(t, body["totals"])
+			},
+		},
+		{
+			name:   "includes money totals",
+			listID: uuid.New().String(),
+			mockSetup: func(m *MockShoppingListService) {
+				budget := int64(2000)
+				price := int64(350)
+				expectedList := &entities.ShoppingList{
+					ID:       uuid.New(),
+					Name:     "Budgeted List",
+					Budget:   &budget,
+					Currency: "EUR",
+					Items: []entities.Item{
+						{ID: uuid.New(), Name: "Wine", Quantity: 2, UnitPrice: &price, Currency: "EUR", Completed: true},
+					},
+				}
+				m.On("GetShoppingList", mock.Anything, mock.AnythingOfType("uuid.UUID")).Return(expectedList, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				totals := body["totals"].([]interface{})
+				require.Len(t, totals, 1)
+				eur := totals[0].(map[string]interface{})
+				assert.Equal(t, "EUR", eur["currency"])
+				assert.Equal(t, float64(700), eur["estimated_total"])
+				assert.Equal(t, float64(700), eur["completed_total"])
+				assert.Equal(t, float64(1300), eur["remaining_budget"])
 			},
 		},
 		{
@@ -303,9 +387,99 @@ func TestShoppingListHandler_GetShoppingList(t *testing.T) {
 	}
 }
 
+func TestShoppingListHandler_GetShoppingListGroupedByCategory(t *testing.T) {
+	produce := entities.NewCategory("Produce", 1)
+	apple := *entities.NewItem("Apples", 6)
+	apple.CategoryID = &produce.ID
+	soap := *entities.NewItem("Soap", 1)
+
+	tests := []struct {
+		name           string
+		query          string
+		mockSetup      func(*MockShoppingListService)
+		expectedStatus int
+		expectedBody   func(*testing.T, map[string]interface{})
+	}{
+		{
+			name:  "groups items by category",
+			query: "?group_by=category",
+			mockSetup: func(m *MockShoppingListService) {
+				list := &entities.ShoppingList{ID: uuid.New(), Name: "Groceries", Items: []entities.Item{apple, soap}}
+				groups := []entities.ItemGroup{
+					{Category: produce, Items: []entities.Item{apple}},
+					{Items: []entities.Item{soap}},
+				}
+				m.On("GetShoppingListGroupedByCategory", mock.Anything, mock.AnythingOfType("uuid.UUID")).Return(list, groups, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Groceries", body["name"])
+				assert.NotContains(t, body, "items")
+
+				groups := body["groups"].([]interface{})
+				require.Len(t, groups, 2)
+				first := groups[0].(map[string]interface{})
+				assert.Equal(t, "Produce", first["category"].(map[string]interface{})["name"])
+				assert.Len(t, first["items"], 1)
+				last := groups[1].(map[string]interface{})
+				assert.Nil(t, last["category"])
+			},
+		},
+		{
+			name:           "fails with unsupported group_by",
+			query:          "?group_by=aisle",
+			mockSetup:      func(m *MockShoppingListService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Contains(t, body["error"], "group_by")
+			},
+		},
+		{
+			name:  "fails with not found error",
+			query: "?group_by=category",
+			mockSetup: func(m *MockShoppingListService) {
+				m.On("GetShoppingListGroupedByCategory", mock.Anything, mock.AnythingOfType("uuid.UUID")).
+					Return(nil, nil, entities.ErrShoppingListNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Shopping list not found", body["error"])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockShoppingListService{}
+			tt.mockSetup(mockService)
+
+			handler := NewShoppingListHandler(mockService)
+			router := setupTestRouter()
+			router.GET("/lists/:id", handler.GetShoppingList)
+
+			req := httptest.NewRequest(http.MethodGet, "/lists/"+uuid.New().String()+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var responseBody map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &responseBody)
+			require.NoError(t, err)
+
+			tt.expectedBody(t, responseBody)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
 func TestShoppingListHandler_GetAllShoppingLists(t *testing.T) {
+	since := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+
 	tests := []struct {
 		name           string
+		query          string
 		mockSetup      func(*MockShoppingListService)
 		expectedStatus int
 		expectedBody   func(*testing.T, interface{})
@@ -327,31 +501,143 @@ func TestShoppingListHandler_GetAllShoppingLists(t *testing.T) {
 						Items:       []entities.Item{},
 					},
 				}
-				m.On("GetAllShoppingLists", mock.Anything).Return(expectedLists, nil)
+				m.On("GetAllShoppingLists", mock.Anything, services.ListShoppingListsInput{}).
+					Return(&services.ShoppingListPage{Lists: expectedLists, Limit: 20, NextCursor: "next"}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: func(t *testing.T, body interface{}) {
-				lists, ok := body.([]interface{})
+				bodyMap, ok := body.(map[string]interface{})
+				require.True(t, ok)
+				lists, ok := bodyMap["lists"].([]interface{})
 				require.True(t, ok)
 				assert.Len(t, lists, 2)
+				assert.Equal(t, map[string]interface{}{"limit": float64(20), "next_cursor": "next"}, bodyMap["paging"])
 			},
 		},
 		{
 			name: "successfully gets empty list",
 			mockSetup: func(m *MockShoppingListService) {
-				m.On("GetAllShoppingLists", mock.Anything).Return([]*entities.ShoppingList{}, nil)
+				m.On("GetAllShoppingLists", mock.Anything, services.ListShoppingListsInput{}).
+					Return(&services.ShoppingListPage{Lists: []*entities.ShoppingList{}, Limit: 20}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: func(t *testing.T, body interface{}) {
-				lists, ok := body.([]interface{})
+				bodyMap, ok := body.(map[string]interface{})
+				require.True(t, ok)
+				lists, ok := bodyMap["lists"].([]interface{})
 				require.True(t, ok)
 				assert.Empty(t, lists)
+				assert.Equal(t, map[string]interface{}{"limit": float64(20)}, bodyMap["paging"])
+			},
+		},
+		{
+			name:  "passes query parameters to the service",
+			query: "?limit=5&cursor=abc&sort=-name&name=groceries&updated_since=2026-03-01T09:30:00Z",
+			mockSetup: func(m *MockShoppingListService) {
+				m.On("GetAllShoppingLists", mock.Anything, services.ListShoppingListsInput{
+					Limit:        5,
+					Cursor:       "abc",
+					Sort:         "-name",
+					NameContains: "groceries",
+					UpdatedSince: &since,
+				}).Return(&services.ShoppingListPage{Lists: []*entities.ShoppingList{}, Limit: 5}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, body interface{}) {
+				bodyMap, ok := body.(map[string]interface{})
+				require.True(t, ok)
+				assert.Equal(t, map[string]interface{}{"limit": float64(5)}, bodyMap["paging"])
+			},
+		},
+		{
+			name:  "replaces items with counts",
+			query: "?include=counts",
+			mockSetup: func(m *MockShoppingListService) {
+				lists := []*entities.ShoppingList{
+					{ID: uuid.New(), Name: "List 1"},
+					{ID: uuid.New(), Name: "List 2"},
+				}
+				m.On("GetAllShoppingLists", mock.Anything, services.ListShoppingListsInput{Include: services.IncludeCounts}).
+					Return(&services.ShoppingListPage{
+						Lists:      lists,
+						ItemCounts: map[uuid.UUID]entities.ItemCounts{lists[0].ID: {Total: 4, Completed: 3}},
+						Limit:      20,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, body interface{}) {
+				bodyMap, ok := body.(map[string]interface{})
+				require.True(t, ok)
+				lists, ok := bodyMap["lists"].([]interface{})
+				require.True(t, ok)
+				require.Len(t, lists, 2)
+				first := lists[0].(map[string]interface{})
+				assert.NotContains(t, first, "items")
+				assert.Equal(t, map[string]interface{}{"total": float64(4), "completed": float64(3)}, first["item_counts"])
+				second := lists[1].(map[string]interface{})
+				assert.Equal(t, map[string]interface{}{"total": float64(0), "completed": float64(0)}, second["item_counts"])
+			},
+		},
+		{
+			name:  "omits items",
+			query: "?include=none",
+			mockSetup: func(m *MockShoppingListService) {
+				m.On("GetAllShoppingLists", mock.Anything, services.ListShoppingListsInput{Include: services.IncludeNone}).
+					Return(&services.ShoppingListPage{Lists: []*entities.ShoppingList{{ID: uuid.New(), Name: "List 1"}}, Limit: 20}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, body interface{}) {
+				bodyMap, ok := body.(map[string]interface{})
+				require.True(t, ok)
+				lists, ok := bodyMap["lists"].([]interface{})
+				require.True(t, ok)
+				require.Len(t, lists, 1)
+				first := lists[0].(map[string]interface{})
+				assert.NotContains(t, first, "items")
+				assert.NotContains(t, first, "item_counts")
+				assert.Equal(t, "List 1", first["name"])
+			},
+		},
+		{
+			name:           "fails with non-numeric limit",
+			query:          "?limit=ten",
+			mockSetup:      func(m *MockShoppingListService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, body interface{}) {
+				bodyMap, ok := body.(map[string]interface{})
+				require.True(t, ok)
+				assert.Equal(t, "Invalid limit", bodyMap["error"])
+			},
+		},
+		{
+			name:           "fails with malformed updated_since",
+			query:          "?updated_since=yesterday",
+			mockSetup:      func(m *MockShoppingListService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, body interface{}) {
+				bodyMap, ok := body.(map[string]interface{})
+				require.True(t, ok)
+				assert.Equal(t, "Invalid updated_since, expected an RFC 3339 timestamp", bodyMap["error"])
+			},
+		},
+		{
+			name:  "fails with invalid paging input",
+			query: "?sort=budget",
+			mockSetup: func(m *MockShoppingListService) {
+				m.On("GetAllShoppingLists", mock.Anything, services.ListShoppingListsInput{Sort: "budget"}).
+					Return(nil, entities.ErrInvalidInput)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, body interface{}) {
+				bodyMap, ok := body.(map[string]interface{})
+				require.True(t, ok)
+				assert.Equal(t, "Invalid limit, cursor, sort or include", bodyMap["error"])
 			},
 		},
 		{
 			name: "fails with internal server error",
 			mockSetup: func(m *MockShoppingListService) {
-				m.On("GetAllShoppingLists", mock.Anything).Return(nil, fmt.Errorf("database error"))
+				m.On("GetAllShoppingLists", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: func(t *testing.T, body interface{}) {
@@ -371,7 +657,7 @@ func TestShoppingListHandler_GetAllShoppingLists(t *testing.T) {
 			router := setupTestRouter()
 			router.GET("/lists", handler.GetAllShoppingLists)
 
-			req := httptest.NewRequest(http.MethodGet, "/lists", nil)
+			req := httptest.NewRequest(http.MethodGet, "/lists"+tt.query, nil)
 			w := httptest.NewRecorder()
 
 			router.ServeHTTP(w, req)
@@ -389,9 +675,12 @@ func TestShoppingListHandler_GetAllShoppingLists(t *testing.T) {
 }
 
 func TestShoppingListHandler_UpdateShoppingList(t *testing.T) {
+	version := 2
+
 	tests := []struct {
 		name           string
 		listID         string
+		ifMatch        string
 		requestBody    interface{}
 		mockSetup      func(*MockShoppingListService)
 		expectedStatus int
@@ -411,7 +700,7 @@ func TestShoppingListHandler_UpdateShoppingList(t *testing.T) {
 					Description: "Updated Description",
 					Items:       []entities.Item{},
 				}
-				m.On("UpdateShoppingList", mock.Anything, mock.AnythingOfType("uuid.UUID"), "Updated List", "Updated Description").Return(expectedList, nil)
+				m.On("UpdateShoppingList", mock.Anything, mock.AnythingOfType("uuid.UUID"), services.ShoppingListInput{Name: "Updated List", Description: "Updated Description"}).Return(expectedList, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: func(t *testing.T, body map[string]interface{}) {
@@ -447,13 +736,50 @@ func TestShoppingListHandler_UpdateShoppingList(t *testing.T) {
 				Description: "Test",
 			},
 			mockSetup: func(m *MockShoppingListService) {
-				m.On("UpdateShoppingList", mock.Anything, mock.AnythingOfType("uuid.UUID"), "Test List", "Test").Return(nil, entities.ErrShoppingListNotFound)
+				m.On("UpdateShoppingList", mock.Anything, mock.AnythingOfType("uuid.UUID"), services.ShoppingListInput{Name: "Test List", Description: "Test"}).Return(nil, entities.ErrShoppingListNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
 			expectedBody: func(t *testing.T, body map[string]interface{}) {
 				assert.Equal(t, "Shopping list not found", body["error"])
 			},
 		},
+		{
+			name:        "passes If-Match version and returns the new ETag",
+			listID:      uuid.New().String(),
+			ifMatch:     `"2"`,
+			requestBody: UpdateShoppingListRequest{Name: "Test List"},
+			mockSetup: func(m *MockShoppingListService) {
+				m.On("UpdateShoppingList", mock.Anything, mock.AnythingOfType("uuid.UUID"), services.ShoppingListInput{Name: "Test List", Version: &version}).Return(&entities.ShoppingList{Name: "Test List", Version: 3}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, float64(3), body["version"])
+			},
+		},
+		{
+			name:           "fails with a weak If-Match tag",
+			listID:         uuid.New().String(),
+			ifMatch:        `W/"2"`,
+			requestBody:    UpdateShoppingListRequest{Name: "Test List"},
+			mockSetup:      func(m *MockShoppingListService) {},
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "If-Match does not match the current version", body["error"])
+			},
+		},
+		{
+			name:        "fails with stale version",
+			listID:      uuid.New().String(),
+			ifMatch:     `"2"`,
+			requestBody: UpdateShoppingListRequest{Name: "Test List"},
+			mockSetup: func(m *MockShoppingListService) {
+				m.On("UpdateShoppingList", mock.Anything, mock.AnythingOfType("uuid.UUID"), services.ShoppingListInput{Name: "Test List", Version: &version}).Return(nil, entities.ErrVersionConflict)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Shopping list has been modified since it was read", body["error"])
+			},
+		},
 		{
 			name:   "fails with invalid input error",
 			listID: uuid.New().String(),
@@ -462,7 +788,7 @@ func TestShoppingListHandler_UpdateShoppingList(t *testing.T) {
 				Description: "Test",
 			},
 			mockSetup: func(m *MockShoppingListService) {
-				m.On("UpdateShoppingList", mock.Anything, mock.AnythingOfType("uuid.UUID"), "ValidName", "Test").Return(nil, entities.ErrInvalidInput)
+				m.On("UpdateShoppingList", mock.Anything, mock.AnythingOfType("uuid.UUID"), services.ShoppingListInput{Name: "ValidName", Description: "Test"}).Return(nil, entities.ErrInvalidInput)
 			},
 			expectedStatus: http.StatusBadRequest,
 			expectedBody: func(t *testing.T, body map[string]interface{}) {
@@ -477,7 +803,7 @@ func TestShoppingListHandler_UpdateShoppingList(t *testing.T) {
 				Description: "Test",
 			},
 			mockSetup: func(m *MockShoppingListService) {
-				m.On("UpdateShoppingList", mock.Anything, mock.AnythingOfType("uuid.UUID"), "Test List", "Test").Return(nil, fmt.Errorf("database error"))
+				m.On("UpdateShoppingList", mock.Anything, mock.AnythingOfType("uuid.UUID"), services.ShoppingListInput{Name: "Test List", Description: "Test"}).Return(nil, fmt.Errorf("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: func(t *testing.T, body map[string]interface{}) {
@@ -500,6 +826,9 @@ func TestShoppingListHandler_UpdateShoppingList(t *testing.T) {
 
 			req := httptest.NewRequest(http.MethodPut, "/lists/"+tt.listID, bytes.NewBuffer(body))
 			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
 			w := httptest.NewRecorder()
 
 			router.ServeHTTP(w, req)
@@ -510,6 +839,108 @@ func TestShoppingListHandler_UpdateShoppingList(t *testing.T) {
 			err = json.Unmarshal(w.Body.Bytes(), &responseBody)
 			require.NoError(t, err)
 
+			if w.Code == http.StatusOK {
+				assert.Equal(t, fmt.Sprintf("%q", fmt.Sprint(responseBody["version"])), w.Header().Get("ETag"))
+			}
+
+			tt.expectedBody(t, responseBody)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestShoppingListHandler_PatchShoppingList(t *testing.T) {
+	name := "Party"
+	budget := int64(5000)
+	empty := ""
+
+	tests := []struct {
+		name           string
+		body           string
+		contentType    string
+		mockSetup      func(*MockShoppingListService)
+		expectedStatus int
+		expectedBody   func(*testing.T, map[string]interface{})
+	}{
+		{
+			name:        "patches only the supplied fields",
+			body:        `{"name": "Party", "budget": 5000}`,
+			contentType: "application/merge-patch+json",
+			mockSetup: func(m *MockShoppingListService) {
+				m.On("PatchShoppingList", mock.Anything, mock.AnythingOfType("uuid.UUID"), services.ShoppingListPatch{Name: &name, Budget: &budget}).Return(&entities.ShoppingList{Name: "Party", Budget: &budget}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Party", body["name"])
+			},
+		},
+		{
+			name:        "null removes budget and currency",
+			body:        `{"budget": null, "currency": null}`,
+			contentType: "application/merge-patch+json",
+			mockSetup: func(m *MockShoppingListService) {
+				m.On("PatchShoppingList", mock.Anything, mock.AnythingOfType("uuid.UUID"), services.ShoppingListPatch{ClearBudget: true, Currency: &empty}).Return(&entities.ShoppingList{Name: "Groceries"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Nil(t, body["budget"])
+			},
+		},
+		{
+			name:           "fails when removing the name",
+			body:           `{"name": null}`,
+			contentType:    "application/merge-patch+json",
+			mockSetup:      func(m *MockShoppingListService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "name cannot be null", body["error"])
+			},
+		},
+		{
+			name:           "fails with unsupported content type",
+			body:           `{"name": "Party"}`,
+			contentType:    "text/plain",
+			mockSetup:      func(m *MockShoppingListService) {},
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Contains(t, body["error"], "application/merge-patch+json")
+			},
+		},
+		{
+			name:        "fails with invalid input error",
+			body:        `{"budget": 5000}`,
+			contentType: "application/json",
+			mockSetup: func(m *MockShoppingListService) {
+				m.On("PatchShoppingList", mock.Anything, mock.AnythingOfType("uuid.UUID"), services.ShoppingListPatch{Budget: &budget}).Return(nil, entities.ErrInvalidInput)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, entities.ErrInvalidInput.Error(), body["error"])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockShoppingListService{}
+			tt.mockSetup(mockService)
+
+			handler := NewShoppingListHandler(mockService)
+			router := setupTestRouter()
+			router.PATCH("/lists/:id", handler.PatchShoppingList)
+
+			req := httptest.NewRequest(http.MethodPatch, "/lists/"+uuid.New().String(), bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var responseBody map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &responseBody)
+			require.NoError(t, err)
+
 			tt.expectedBody(t, responseBody)
 			mockService.AssertExpectations(t)
 		})
@@ -598,3 +1029,202 @@ func TestShoppingListHandler_DeleteShoppingList(t *testing.T) {
 		})
 	}
 }
+
+func TestShoppingListHandler_CloneShoppingList(t *testing.T) {
+	listID := uuid.New()
+	clone := &entities.ShoppingList{ID: uuid.New(), Name: "Weekly Groceries", Version: 1, Items: []entities.Item{}}
+
+	tests := []struct {
+		name           string
+		listID         string
+		requestBody    string
+		mockSetup      func(*MockShoppingListService)
+		expectedStatus int
+		expectedBody   func(*testing.T, map[string]interface{})
+	}{
+		{
+			name:   "clones without options",
+			listID: listID.String(),
+			mockSetup: func(m *MockShoppingListService) {
+				m.On("CloneShoppingList", mock.Anything, listID, services.CloneShoppingListInput{}).Return(clone, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, clone.ID.String(), body["id"])
+			},
+		},
+		{
+			name:        "clones with options",
+			listID:      listID.String(),
+			requestBody: `{"name": "Next Week", "reset_completion": true, "incomplete_only": true}`,
+			mockSetup: func(m *MockShoppingListService) {
+				m.On("CloneShoppingList", mock.Anything, listID, services.CloneShoppingListInput{
+					Name:            "Next Week",
+					ResetCompletion: true,
+					IncompleteOnly:  true,
+				}).Return(clone, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, clone.Name, body["name"])
+			},
+		},
+		{
+			name:           "fails with invalid ID",
+			listID:         "invalid-uuid",
+			mockSetup:      func(m *MockShoppingListService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Invalid ID format", body["error"])
+			},
+		},
+		{
+			name:           "fails with malformed body",
+			listID:         listID.String(),
+			requestBody:    `{"name": 3}`,
+			mockSetup:      func(m *MockShoppingListService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.NotEmpty(t, body["error"])
+			},
+		},
+		{
+			name:   "fails with not found error",
+			listID: listID.String(),
+			mockSetup: func(m *MockShoppingListService) {
+				m.On("CloneShoppingList", mock.Anything, listID, mock.Anything).Return(nil, entities.ErrShoppingListNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Shopping list not found", body["error"])
+			},
+		},
+		{
+			name:   "fails with internal server error",
+			listID: listID.String(),
+			mockSetup: func(m *MockShoppingListService) {
+				m.On("CloneShoppingList", mock.Anything, listID, mock.Anything).Return(nil, fmt.Errorf("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Failed to clone shopping list", body["error"])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockShoppingListService{}
+			tt.mockSetup(mockService)
+
+			handler := NewShoppingListHandler(mockService)
+			router := setupTestRouter()
+			router.POST("/lists/:id/clone", handler.CloneShoppingList)
+
+			req := httptest.NewRequest(http.MethodPost, "/lists/"+tt.listID+"/clone", strings.NewReader(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusCreated {
+				assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+			}
+
+			var responseBody map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &responseBody)
+			require.NoError(t, err)
+
+			tt.expectedBody(t, responseBody)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestShoppingListHandler_ListActions(t *testing.T) {
+	actions := []struct {
+		path    string
+		method  string
+		handler func(*ShoppingListHandler) gin.HandlerFunc
+		failure string
+	}{
+		{"clear-completed", "ClearCompleted", func(h *ShoppingListHandler) gin.HandlerFunc { return h.ClearCompleted },
+			"Failed to clear completed items"},
+		{"complete-all", "CompleteAll", func(h *ShoppingListHandler) gin.HandlerFunc { return h.CompleteAll },
+			"Failed to complete items"},
+		{"reset", "ResetShoppingList", func(h *ShoppingListHandler) gin.HandlerFunc { return h.ResetShoppingList },
+			"Failed to reset shopping list"},
+	}
+
+	for _, action := range actions {
+		listID := uuid.New()
+
+		tests := []struct {
+			name           string
+			listID         string
+			mockSetup      func(*MockShoppingListService)
+			expectedStatus int
+			expectedBody   map[string]interface{}
+		}{
+			{
+				name:   "reports affected items",
+				listID: listID.String(),
+				mockSetup: func(m *MockShoppingListService) {
+					m.On(action.method, mock.Anything, listID).Return(int64(3), nil)
+				},
+				expectedStatus: http.StatusOK,
+				expectedBody:   map[string]interface{}{"affected": float64(3)},
+			},
+			{
+				name:           "fails with invalid ID",
+				listID:         "invalid-uuid",
+				mockSetup:      func(m *MockShoppingListService) {},
+				expectedStatus: http.StatusBadRequest,
+				expectedBody:   map[string]interface{}{"error": "Invalid ID format"},
+			},
+			{
+				name:   "fails with not found error",
+				listID: listID.String(),
+				mockSetup: func(m *MockShoppingListService) {
+					m.On(action.method, mock.Anything, listID).Return(int64(0), entities.ErrShoppingListNotFound)
+				},
+				expectedStatus: http.StatusNotFound,
+				expectedBody:   map[string]interface{}{"error": "Shopping list not found"},
+			},
+			{
+				name:   "fails with internal server error",
+				listID: listID.String(),
+				mockSetup: func(m *MockShoppingListService) {
+					m.On(action.method, mock.Anything, listID).Return(int64(0), fmt.Errorf("database error"))
+				},
+				expectedStatus: http.StatusInternalServerError,
+				expectedBody:   map[string]interface{}{"error": action.failure},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(action.path+"/"+tt.name, func(t *testing.T) {
+				mockService := &MockShoppingListService{}
+				tt.mockSetup(mockService)
+
+				handler := NewShoppingListHandler(mockService)
+				router := setupTestRouter()
+				router.POST("/lists/:id/"+action.path, action.handler(handler))
+
+				req := httptest.NewRequest(http.MethodPost, "/lists/"+tt.listID+"/"+action.path, nil)
+				w := httptest.NewRecorder()
+
+				router.ServeHTTP(w, req)
+
+				assert.Equal(t, tt.expectedStatus, w.Code)
+
+				var responseBody map[string]interface{}
+				err := json.Unmarshal(w.Body.Bytes(), &responseBody)
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedBody, responseBody)
+				mockService.AssertExpectations(t)
+			})
+		}
+	}
+}
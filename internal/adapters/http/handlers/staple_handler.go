@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/application/services"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// StapleHandler handles HTTP requests for staples
+type StapleHandler struct {
+	service services.ReplenishmentServiceInterface
+}
+
+// NewStapleHandler creates a new staple handler
+func NewStapleHandler(service services.ReplenishmentServiceInterface) *StapleHandler {
+	return &StapleHandler{service: service}
+}
+
+// StapleRequest represents the request body for creating or updating a staple
+type StapleRequest struct {
+	Name           string        `json:"name" binding:"required"`
+	Unit           entities.Unit `json:"unit"`
+	OnHand         float64       `json:"on_hand"`
+	MinQuantity    float64       `json:"min_quantity" binding:"required"`
+	ShoppingListID uuid.UUID     `json:"shopping_list_id" binding:"required"`
+}
+
+// CreateStaple registers a staple
+func (h *StapleHandler) CreateStaple(c *gin.Context) {
+	var req StapleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	staple, err := h.service.CreateStaple(c.Request.Context(), req.toInput())
+	if err != nil {
+		if err == entities.ErrForbidden {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Not allowed to change this shopping list"})
+			return
+		}
+		if err == entities.ErrShoppingListNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Shopping list not found"})
+			return
+		}
+		if err == entities.ErrInvalidInput {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create staple"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, staple)
+}
+
+// GetStaple retrieves a staple by ID
+func (h *StapleHandler) GetStaple(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	staple, err := h.service.GetStaple(c.Request.Context(), id)
+	if err != nil {
+		if err == entities.ErrStapleNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Staple not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve staple"})
+		return
+	}
+
+	c.JSON(http.StatusOK, staple)
+}
+
+// GetAllStaples retrieves all staples
+func (h *StapleHandler) GetAllStaples(c *gin.Context) {
+	staples, err := h.service.GetAllStaples(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve staples"})
+		return
+	}
+
+	c.JSON(http.StatusOK, staples)
+}
+
+// GetLowStaples retrieves the staples currently below their minimum
+func (h *StapleHandler) GetLowStaples(c *gin.Context) {
+	staples, err := h.service.GetLowStaples(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve staples"})
+		return
+	}
+
+	c.JSON(http.StatusOK, staples)
+}
+
+// UpdateStaple replaces the attributes of a staple
+func (h *StapleHandler) UpdateStaple(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req StapleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	staple, err := h.service.UpdateStaple(c.Request.Context(), id, req.toInput())
+	if err != nil {
+		if err == entities.ErrForbidden {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Not allowed to change this shopping list"})
+			return
+		}
+		if err == entities.ErrStapleNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Staple not found"})
+			return
+		}
+		if err == entities.ErrShoppingListNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Shopping list not found"})
+			return
+		}
+		if err == entities.ErrInvalidInput {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update staple"})
+		return
+	}
+
+	c.JSON(http.StatusOK, staple)
+}
+
+// DeleteStaple deletes a staple
+func (h *StapleHandler) DeleteStaple(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	err = h.service.DeleteStaple(c.Request.Context(), id)
+	if err != nil {
+		if err == entities.ErrStapleNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Staple not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete staple"})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// toInput converts the request to a service input
+func (req StapleRequest) toInput() services.StapleInput {
+	return services.StapleInput{
+		Name:           req.Name,
+		Unit:           req.Unit,
+		OnHand:         req.OnHand,
+		MinQuantity:    req.MinQuantity,
+		ShoppingListID: req.ShoppingListID,
+	}
+}
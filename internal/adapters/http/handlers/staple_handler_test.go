@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/application/services"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// MockReplenishmentService is a mock implementation of the replenishment service interface
+type MockReplenishmentService struct {
+	mock.Mock
+}
+
+// Ensure MockReplenishmentService implements the interface
+var _ services.ReplenishmentServiceInterface = (*MockReplenishmentService)(nil)
+
+func (m *MockReplenishmentService) CreateStaple(ctx context.Context, input services.StapleInput) (*entities.Staple, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Staple), args.Error(1)
+}
+
+func (m *MockReplenishmentService) GetStaple(ctx context.Context, id uuid.UUID) (*entities.Staple, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Staple), args.Error(1)
+}
+
+func (m *MockReplenishmentService) GetAllStaples(ctx context.Context) ([]*entities.Staple, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Staple), args.Error(1)
+}
+
+func (m *MockReplenishmentService) GetLowStaples(ctx context.Context) ([]*entities.Staple, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Staple), args.Error(1)
+}
+
+func (m *MockReplenishmentService) UpdateStaple(ctx context.Context, id uuid.UUID, input services.StapleInput) (*entities.Staple, error) {
+	args := m.Called(ctx, id, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Staple), args.Error(1)
+}
+
+func (m *MockReplenishmentService) DeleteStaple(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestStapleHandler_CreateStaple(t *testing.T) {
+	listID := uuid.New()
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(*MockReplenishmentService)
+		expectedStatus int
+	}{
+		{
+			name: "creates a staple",
+			body: fmt.Sprintf(`{"name": "Coffee", "unit": "pack", "on_hand": 1, "min_quantity": 2, "shopping_list_id": %q}`, listID),
+			mockSetup: func(m *MockReplenishmentService) {
+				input := services.StapleInput{Name: "Coffee", Unit: entities.UnitPack, OnHand: 1, MinQuantity: 2, ShoppingListID: listID}
+				m.On("CreateStaple", mock.Anything, input).Return(entities.NewStaple("Coffee", entities.UnitPack, 1, 2, listID), nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "fails without a shopping list",
+			body:           `{"name": "Coffee", "min_quantity": 2}`,
+			mockSetup:      func(m *MockReplenishmentService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "fails when the shopping list does not exist",
+			body: fmt.Sprintf(`{"name": "Coffee", "min_quantity": 2, "shopping_list_id": %q}`, listID),
+			mockSetup: func(m *MockReplenishmentService) {
+				m.On("CreateStaple", mock.Anything, mock.Anything).Return(nil, entities.ErrShoppingListNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "fails with invalid input",
+			body: fmt.Sprintf(`{"name": "Coffee", "on_hand": -1, "min_quantity": 2, "shopping_list_id": %q}`, listID),
+			mockSetup: func(m *MockReplenishmentService) {
+				m.On("CreateStaple", mock.Anything, mock.Anything).Return(nil, entities.ErrInvalidInput)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "fails with internal error",
+			body: fmt.Sprintf(`{"name": "Coffee", "min_quantity": 2, "shopping_list_id": %q}`, listID),
+			mockSetup: func(m *MockReplenishmentService) {
+				m.On("CreateStaple", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockReplenishmentService{}
+			tt.mockSetup(mockService)
+
+			handler := NewStapleHandler(mockService)
+			router := setupTestRouter()
+			router.POST("/staples", handler.CreateStaple)
+
+			req := httptest.NewRequest(http.MethodPost, "/staples", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStapleHandler_GetLowStaples(t *testing.T) {
+	mockService := &MockReplenishmentService{}
+	low := entities.NewStaple("Coffee", entities.UnitPack, 0, 2, uuid.New())
+	mockService.On("GetLowStaples", mock.Anything).Return([]*entities.Staple{low}, nil)
+
+	handler := NewStapleHandler(mockService)
+	router := setupTestRouter()
+	router.GET("/staples/low", handler.GetLowStaples)
+	router.GET("/staples/:id", handler.GetStaple)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/staples/low", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var body []map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Len(t, body, 1)
+	assert.Equal(t, "Coffee", body[0]["name"])
+	assert.Equal(t, float64(2), body[0]["min_quantity"])
+	mockService.AssertExpectations(t)
+}
+
+func TestStapleHandler_UpdateStaple(t *testing.T) {
+	listID := uuid.New()
+	body := fmt.Sprintf(`{"name": "Coffee", "min_quantity": 2, "shopping_list_id": %q}`, listID)
+
+	tests := []struct {
+		name           string
+		id             string
+		mockSetup      func(*MockReplenishmentService)
+		expectedStatus int
+	}{
+		{
+			name: "updates the staple",
+			id:   uuid.New().String(),
+			mockSetup: func(m *MockReplenishmentService) {
+				m.On("UpdateStaple", mock.Anything, mock.AnythingOfType("uuid.UUID"), mock.Anything).
+					Return(entities.NewStaple("Coffee", entities.UnitPieces, 0, 2, listID), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "fails when the staple does not exist",
+			id:   uuid.New().String(),
+			mockSetup: func(m *MockReplenishmentService) {
+				m.On("UpdateStaple", mock.Anything, mock.Anything, mock.Anything).Return(nil, entities.ErrStapleNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "fails with invalid UUID",
+			id:             "invalid-uuid",
+			mockSetup:      func(m *MockReplenishmentService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockReplenishmentService{}
+			tt.mockSetup(mockService)
+
+			handler := NewStapleHandler(mockService)
+			router := setupTestRouter()
+			router.PUT("/staples/:id", handler.UpdateStaple)
+
+			req := httptest.NewRequest(http.MethodPut, "/staples/"+tt.id, bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStapleHandler_GetAndDeleteStaple(t *testing.T) {
+	mockService := &MockReplenishmentService{}
+	staple := entities.NewStaple("Coffee", entities.UnitPack, 1, 2, uuid.New())
+	missing := uuid.New()
+	mockService.On("GetStaple", mock.Anything, staple.ID).Return(staple, nil)
+	mockService.On("GetStaple", mock.Anything, missing).Return(nil, entities.ErrStapleNotFound)
+	mockService.On("DeleteStaple", mock.Anything, staple.ID).Return(nil)
+	mockService.On("DeleteStaple", mock.Anything, missing).Return(entities.ErrStapleNotFound)
+
+	handler := NewStapleHandler(mockService)
+	router := setupTestRouter()
+	router.GET("/staples/:id", handler.GetStaple)
+	router.DELETE("/staples/:id", handler.DeleteStaple)
+
+	requests := []struct {
+		method         string
+		id             uuid.UUID
+		expectedStatus int
+	}{
+		{method: http.MethodGet, id: staple.ID, expectedStatus: http.StatusOK},
+		{method: http.MethodGet, id: missing, expectedStatus: http.StatusNotFound},
+		{method: http.MethodDelete, id: staple.ID, expectedStatus: http.StatusNoContent},
+		{method: http.MethodDelete, id: missing, expectedStatus: http.StatusNotFound},
+	}
+	for _, r := range requests {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(r.method, "/staples/"+r.id.String(), nil))
+		assert.Equal(t, r.expectedStatus, w.Code, "%s %s", r.method, r.id)
+	}
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/application/services"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// TemplateHandler handles HTTP requests for shopping list templates
+type TemplateHandler struct {
+	service services.TemplateServiceInterface
+}
+
+// NewTemplateHandler creates a new template handler
+func NewTemplateHandler(service services.TemplateServiceInterface) *TemplateHandler {
+	return &TemplateHandler{service: service}
+}
+
+// TemplateRequest represents the request body for creating or updating a template.
+// Recurrence is an RRULE subset such as "FREQ=WEEKLY;BYDAY=SA" that makes the template
+// create a list on a schedule starting at StartsAt, evaluated in the IANA TimeZone (UTC by default).
+type TemplateRequest struct {
+	Name        string                `json:"name" binding:"required"`
+	Description string                `json:"description"`
+	Budget      *int64                `json:"budget"`
+	Currency    string                `json:"currency"`
+	Recurrence  string                `json:"recurrence"`
+	TimeZone    string                `json:"time_zone"`
+	StartsAt    *time.Time            `json:"starts_at"`
+	Items       []TemplateItemRequest `json:"items" binding:"dive"`
+}
+
+// TemplateItemRequest represents an item of a template request
+type TemplateItemRequest struct {
+	Name       string        `json:"name" binding:"required"`
+	Quantity   float64       `json:"quantity"`
+	Unit       entities.Unit `json:"unit"`
+	CategoryID *uuid.UUID    `json:"category_id"`
+	UnitPrice  *int64        `json:"unit_price"`
+	Currency   string        `json:"currency"`
+}
+
+// InstantiateTemplateRequest represents the optional request body for creating a list from a template
+type InstantiateTemplateRequest struct {
+	Name string `json:"name"`
+}
+
+// CreateTemplate creates a new template
+func (h *TemplateHandler) CreateTemplate(c *gin.Context) {
+	var req TemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template, err := h.service.CreateTemplate(c.Request.Context(), req.toInput())
+	if err != nil {
+		if status, message, ok := templateInputError(err); ok {
+			c.JSON(status, gin.H{"error": message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create template"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+// GetTemplate retrieves a template by ID
+func (h *TemplateHandler) GetTemplate(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	template, err := h.service.GetTemplate(c.Request.Context(), id)
+	if err != nil {
+		if err == entities.ErrTemplateNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve template"})
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// GetAllTemplates retrieves all templates
+func (h *TemplateHandler) GetAllTemplates(c *gin.Context) {
+	templates, err := h.service.GetAllTemplates(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve templates"})
+		return
+	}
+
+	c.JSON(http.StatusOK, templates)
+}
+
+// UpdateTemplate replaces an existing template and its items
+func (h *TemplateHandler) UpdateTemplate(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req TemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template, err := h.service.UpdateTemplate(c.Request.Context(), id, req.toInput())
+	if err != nil {
+		if err == entities.ErrTemplateNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+			return
+		}
+		if status, message, ok := templateInputError(err); ok {
+			c.JSON(status, gin.H{"error": message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update template"})
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// DeleteTemplate deletes a template
+func (h *TemplateHandler) DeleteTemplate(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	err = h.service.DeleteTemplate(c.Request.Context(), id)
+	if err != nil {
+		if err == entities.ErrTemplateNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete template"})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// InstantiateTemplate creates a new shopping list from a template
+func (h *TemplateHandler) InstantiateTemplate(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var req InstantiateTemplateRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	list, err := h.service.InstantiateTemplate(c.Request.Context(), id, req.Name)
+	if err != nil {
+		if err == entities.ErrTemplateNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create shopping list from template"})
+		return
+	}
+
+	setETag(c, list.Version)
+	c.JSON(http.StatusCreated, list)
+}
+
+// toInput converts the request to a service input, defaulting item quantities to 1
+func (req TemplateRequest) toInput() services.TemplateInput {
+	input := services.TemplateInput{
+		Name:        req.Name,
+		Description: req.Description,
+		Budget:      req.Budget,
+		Currency:    req.Currency,
+		Recurrence:  req.Recurrence,
+		TimeZone:    req.TimeZone,
+		StartsAt:    req.StartsAt,
+		Items:       make([]services.TemplateItemInput, len(req.Items)),
+	}
+	for i, item := range req.Items {
+		quantity := item.Quantity
+		if quantity <= 0 {
+			quantity = 1
+		}
+		input.Items[i] = services.TemplateItemInput{
+			Name:       item.Name,
+			Quantity:   quantity,
+			Unit:       item.Unit,
+			CategoryID: item.CategoryID,
+			UnitPrice:  item.UnitPrice,
+			Currency:   item.Currency,
+		}
+	}
+	return input
+}
+
+// templateInputError maps a validation error of a template to an HTTP status and message
+func templateInputError(err error) (int, string, bool) {
+	switch err {
+	case entities.ErrInvalidInput:
+		return http.StatusBadRequest, err.Error(), true
+	case entities.ErrCategoryNotFound:
+		return http.StatusBadRequest, "Category not found", true
+	}
+	return 0, "", false
+}
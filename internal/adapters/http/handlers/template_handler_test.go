@@ -0,0 +1,426 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/application/services"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// MockTemplateService is a mock implementation of the template service interface
+type MockTemplateService struct {
+	mock.Mock
+}
+
+// Ensure MockTemplateService implements the interface
+var _ services.TemplateServiceInterface = (*MockTemplateService)(nil)
+
+func (m *MockTemplateService) CreateTemplate(ctx context.Context, input services.TemplateInput) (*entities.Template, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Template), args.Error(1)
+}
+
+func (m *MockTemplateService) GetTemplate(ctx context.Context, id uuid.UUID) (*entities.Template, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Template), args.Error(1)
+}
+
+func (m *MockTemplateService) GetAllTemplates(ctx context.Context) ([]*entities.Template, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Template), args.Error(1)
+}
+
+func (m *MockTemplateService) UpdateTemplate(
+	ctx context.Context,
+	id uuid.UUID,
+	input services.TemplateInput,
+) (*entities.Template, error) {
+	args := m.Called(ctx, id, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Template), args.Error(1)
+}
+
+func (m *MockTemplateService) DeleteTemplate(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTemplateService) InstantiateTemplate(ctx context.Context, id uuid.UUID, name string) (*entities.ShoppingList, error) {
+	args := m.Called(ctx, id, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ShoppingList), args.Error(1)
+}
+
+func (m *MockTemplateService) MaterializeDueTemplates(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func TestTemplateHandler_CreateTemplate(t *testing.T) {
+	template := entities.NewTemplate("Weekly basics", "")
+	template.SetItems([]entities.TemplateItem{{Name: "Milk", Quantity: 1, Unit: entities.UnitPieces}})
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		mockSetup      func(*MockTemplateService)
+		expectedStatus int
+		expectedBody   func(*testing.T, map[string]interface{})
+	}{
+		{
+			name: "successfully creates template",
+			requestBody: map[string]interface{}{
+				"name":  "Weekly basics",
+				"items": []map[string]interface{}{{"name": "Milk"}},
+			},
+			mockSetup: func(m *MockTemplateService) {
+				m.On("CreateTemplate", mock.Anything, services.TemplateInput{
+					Name:  "Weekly basics",
+					Items: []services.TemplateItemInput{{Name: "Milk", Quantity: 1}},
+				}).Return(template, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Weekly basics", body["name"])
+				items := body["items"].([]interface{})
+				require.Len(t, items, 1)
+				assert.Equal(t, "Milk", items[0].(map[string]interface{})["name"])
+			},
+		},
+		{
+			name:           "fails with missing name",
+			requestBody:    map[string]interface{}{"description": "Every week"},
+			mockSetup:      func(m *MockTemplateService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Contains(t, body["error"], "required")
+			},
+		},
+		{
+			name: "fails with unnamed item",
+			requestBody: map[string]interface{}{
+				"name":  "Weekly basics",
+				"items": []map[string]interface{}{{"quantity": 2}},
+			},
+			mockSetup:      func(m *MockTemplateService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Contains(t, body["error"], "required")
+			},
+		},
+		{
+			name: "fails with unknown category",
+			requestBody: map[string]interface{}{
+				"name":  "Weekly basics",
+				"items": []map[string]interface{}{{"name": "Milk", "category_id": uuid.New()}},
+			},
+			mockSetup: func(m *MockTemplateService) {
+				m.On("CreateTemplate", mock.Anything, mock.Anything).Return(nil, entities.ErrCategoryNotFound)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Category not found", body["error"])
+			},
+		},
+		{
+			name:        "fails with internal server error",
+			requestBody: map[string]interface{}{"name": "Weekly basics"},
+			mockSetup: func(m *MockTemplateService) {
+				m.On("CreateTemplate", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Failed to create template", body["error"])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockTemplateService{}
+			tt.mockSetup(mockService)
+
+			handler := NewTemplateHandler(mockService)
+			router := setupTestRouter()
+			router.POST("/templates", handler.CreateTemplate)
+
+			body, err := json.Marshal(tt.requestBody)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/templates", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var responseBody map[string]interface{}
+			err = json.Unmarshal(w.Body.Bytes(), &responseBody)
+			require.NoError(t, err)
+
+			tt.expectedBody(t, responseBody)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTemplateHandler_GetTemplate(t *testing.T) {
+	tests := []struct {
+		name           string
+		templateID     string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "successfully retrieves template", templateID: uuid.New().String(), expectedStatus: http.StatusOK},
+		{name: "fails with invalid UUID", templateID: "invalid-uuid", expectedStatus: http.StatusBadRequest},
+		{
+			name:           "fails with not found error",
+			templateID:     uuid.New().String(),
+			serviceErr:     entities.ErrTemplateNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockTemplateService{}
+			if tt.templateID != "invalid-uuid" {
+				if tt.serviceErr != nil {
+					mockService.On("GetTemplate", mock.Anything, uuid.MustParse(tt.templateID)).Return(nil, tt.serviceErr)
+				} else {
+					mockService.On("GetTemplate", mock.Anything, uuid.MustParse(tt.templateID)).
+						Return(entities.NewTemplate("Weekly basics", ""), nil)
+				}
+			}
+
+			handler := NewTemplateHandler(mockService)
+			router := setupTestRouter()
+			router.GET("/templates/:id", handler.GetTemplate)
+
+			req := httptest.NewRequest(http.MethodGet, "/templates/"+tt.templateID, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTemplateHandler_GetAllTemplates(t *testing.T) {
+	mockService := &MockTemplateService{}
+	mockService.On("GetAllTemplates", mock.Anything).Return([]*entities.Template{
+		entities.NewTemplate("BBQ party", ""),
+		entities.NewTemplate("Weekly basics", ""),
+	}, nil)
+
+	handler := NewTemplateHandler(mockService)
+	router := setupTestRouter()
+	router.GET("/templates", handler.GetAllTemplates)
+
+	req := httptest.NewRequest(http.MethodGet, "/templates", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var responseBody []map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &responseBody))
+	require.Len(t, responseBody, 2)
+	assert.Equal(t, "BBQ party", responseBody[0]["name"])
+	mockService.AssertExpectations(t)
+}
+
+func TestTemplateHandler_UpdateTemplate(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "successfully updates template", expectedStatus: http.StatusOK},
+		{name: "fails with not found error", serviceErr: entities.ErrTemplateNotFound, expectedStatus: http.StatusNotFound},
+		{name: "fails with invalid input", serviceErr: entities.ErrInvalidInput, expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := uuid.New()
+			mockService := &MockTemplateService{}
+			input := services.TemplateInput{
+				Name:  "BBQ party",
+				Items: []services.TemplateItemInput{{Name: "Sausages", Quantity: 12}},
+			}
+			if tt.serviceErr != nil {
+				mockService.On("UpdateTemplate", mock.Anything, id, input).Return(nil, tt.serviceErr)
+			} else {
+				mockService.On("UpdateTemplate", mock.Anything, id, input).Return(entities.NewTemplate("BBQ party", ""), nil)
+			}
+
+			handler := NewTemplateHandler(mockService)
+			router := setupTestRouter()
+			router.PUT("/templates/:id", handler.UpdateTemplate)
+
+			body, err := json.Marshal(map[string]interface{}{
+				"name":  "BBQ party",
+				"items": []map[string]interface{}{{"name": "Sausages", "quantity": 12}},
+			})
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPut, "/templates/"+id.String(), bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTemplateHandler_DeleteTemplate(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "successfully deletes template", expectedStatus: http.StatusNoContent},
+		{name: "fails with not found error", serviceErr: entities.ErrTemplateNotFound, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockTemplateService{}
+			mockService.On("DeleteTemplate", mock.Anything, mock.AnythingOfType("uuid.UUID")).Return(tt.serviceErr)
+
+			handler := NewTemplateHandler(mockService)
+			router := setupTestRouter()
+			router.DELETE("/templates/:id", handler.DeleteTemplate)
+
+			req := httptest.NewRequest(http.MethodDelete, "/templates/"+uuid.New().String(), nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTemplateHandler_InstantiateTemplate(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name           string
+		templateID     string
+		body           string
+		mockSetup      func(*MockTemplateService)
+		expectedStatus int
+		expectedBody   func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "creates a list named after the template",
+			templateID: id.String(),
+			mockSetup: func(m *MockTemplateService) {
+				list := entities.NewShoppingList("Weekly basics", "")
+				list.Items = []entities.Item{*entities.NewItem("Milk", 1)}
+				m.On("InstantiateTemplate", mock.Anything, id, "").Return(list, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+				var body map[string]interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				assert.Equal(t, "Weekly basics", body["name"])
+				assert.Len(t, body["items"], 1)
+			},
+		},
+		{
+			name:       "creates a list with the given name",
+			templateID: id.String(),
+			body:       `{"name": "Week 42"}`,
+			mockSetup: func(m *MockTemplateService) {
+				m.On("InstantiateTemplate", mock.Anything, id, "Week 42").Return(entities.NewShoppingList("Week 42", ""), nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "Week 42")
+			},
+		},
+		{
+			name:           "fails with invalid UUID",
+			templateID:     "invalid-uuid",
+			mockSetup:      func(m *MockTemplateService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   func(t *testing.T, w *httptest.ResponseRecorder) {},
+		},
+		{
+			name:       "fails with not found error",
+			templateID: id.String(),
+			mockSetup: func(m *MockTemplateService) {
+				m.On("InstantiateTemplate", mock.Anything, id, "").Return(nil, entities.ErrTemplateNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Body.String(), "Template not found")
+			},
+		},
+		{
+			name:       "fails with internal server error",
+			templateID: id.String(),
+			mockSetup: func(m *MockTemplateService) {
+				m.On("InstantiateTemplate", mock.Anything, id, "").Return(nil, fmt.Errorf("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   func(t *testing.T, w *httptest.ResponseRecorder) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockTemplateService{}
+			tt.mockSetup(mockService)
+
+			handler := NewTemplateHandler(mockService)
+			router := setupTestRouter()
+			router.POST("/templates/:id/instantiate", handler.InstantiateTemplate)
+
+			req := httptest.NewRequest(http.MethodPost, "/templates/"+tt.templateID+"/instantiate", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			tt.expectedBody(t, w)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/application/services"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// TrashHandler handles HTTP requests for deleted shopping lists and items
+type TrashHandler struct {
+	service services.TrashServiceInterface
+}
+
+// NewTrashHandler creates a new trash handler
+func NewTrashHandler(service services.TrashServiceInterface) *TrashHandler {
+	return &TrashHandler{service: service}
+}
+
+// GetTrash retrieves the shopping lists and items in the trash
+func (h *TrashHandler) GetTrash(c *gin.Context) {
+	trash, err := h.service.GetTrash(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve trash"})
+		return
+	}
+
+	c.JSON(http.StatusOK, trash)
+}
+
+// RestoreShoppingList takes a shopping list out of the trash
+func (h *TrashHandler) RestoreShoppingList(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	list, err := h.service.RestoreShoppingList(c.Request.Context(), id)
+	if err != nil {
+		if err == entities.ErrForbidden {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only owners can restore the shopping list"})
+			return
+		}
+		if err == entities.ErrShoppingListNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Shopping list not found in trash"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to restore shopping list"})
+		return
+	}
+
+	setETag(c, list.Version)
+	c.JSON(http.StatusOK, list)
+}
+
+// RestoreItem takes an item out of the trash
+func (h *TrashHandler) RestoreItem(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	item, err := h.service.RestoreItem(c.Request.Context(), id)
+	if err != nil {
+		if err == entities.ErrForbidden {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Not allowed to change this shopping list"})
+			return
+		}
+		if err == entities.ErrItemNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Item not found in trash"})
+			return
+		}
+		if err == entities.ErrShoppingListNotFound {
+			c.JSON(http.StatusConflict, gin.H{"error": "Restore the item's shopping list first"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to restore item"})
+		return
+	}
+
+	setETag(c, item.Version)
+	c.JSON(http.StatusOK, item)
+}
@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/application/services"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// MockTrashService is a mock implementation of the trash service interface
+type MockTrashService struct {
+	mock.Mock
+}
+
+// Ensure MockTrashService implements the interface
+var _ services.TrashServiceInterface = (*MockTrashService)(nil)
+
+func (m *MockTrashService) GetTrash(ctx context.Context) (*entities.Trash, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Trash), args.Error(1)
+}
+
+func (m *MockTrashService) RestoreShoppingList(ctx context.Context, id uuid.UUID) (*entities.ShoppingList, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ShoppingList), args.Error(1)
+}
+
+func (m *MockTrashService) RestoreItem(ctx context.Context, id uuid.UUID) (*entities.Item, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Item), args.Error(1)
+}
+
+func (m *MockTrashService) PurgeTrash(ctx context.Context, retention time.Duration) (int64, int64, error) {
+	args := m.Called(ctx, retention)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
+
+func TestTrashHandler_GetTrash(t *testing.T) {
+	mockService := &MockTrashService{}
+	deletedAt := time.Now()
+	mockService.On("GetTrash", mock.Anything).Return(&entities.Trash{
+		Lists: []*entities.ShoppingList{{ID: uuid.New(), Name: "Groceries", DeletedAt: &deletedAt}},
+		Items: []*entities.Item{},
+	}, nil)
+
+	handler := NewTrashHandler(mockService)
+	router := setupTestRouter()
+	router.GET("/trash", handler.GetTrash)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/trash", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var body struct {
+		Lists []map[string]interface{} `json:"lists"`
+		Items []map[string]interface{} `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Len(t, body.Lists, 1)
+	assert.Equal(t, "Groceries", body.Lists[0]["name"])
+	assert.NotNil(t, body.Lists[0]["deleted_at"])
+	assert.Empty(t, body.Items)
+	mockService.AssertExpectations(t)
+}
+
+func TestTrashHandler_RestoreShoppingList(t *testing.T) {
+	tests := []struct {
+		name           string
+		listID         string
+		mockSetup      func(*MockTrashService)
+		expectedStatus int
+		expectedBody   func(*testing.T, map[string]interface{})
+	}{
+		{
+			name:   "successfully restores shopping list",
+			listID: uuid.New().String(),
+			mockSetup: func(m *MockTrashService) {
+				m.On("RestoreShoppingList", mock.Anything, mock.AnythingOfType("uuid.UUID")).Return(&entities.ShoppingList{Name: "Groceries"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Groceries", body["name"])
+			},
+		},
+		{
+			name:           "fails with invalid UUID",
+			listID:         "invalid-uuid",
+			mockSetup:      func(m *MockTrashService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Invalid ID format", body["error"])
+			},
+		},
+		{
+			name:   "fails when list is not in trash",
+			listID: uuid.New().String(),
+			mockSetup: func(m *MockTrashService) {
+				m.On("RestoreShoppingList", mock.Anything, mock.AnythingOfType("uuid.UUID")).Return(nil, entities.ErrShoppingListNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Shopping list not found in trash", body["error"])
+			},
+		},
+		{
+			name:   "fails with internal server error",
+			listID: uuid.New().String(),
+			mockSetup: func(m *MockTrashService) {
+				m.On("RestoreShoppingList", mock.Anything, mock.AnythingOfType("uuid.UUID")).Return(nil, fmt.Errorf("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Failed to restore shopping list", body["error"])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockTrashService{}
+			tt.mockSetup(mockService)
+
+			handler := NewTrashHandler(mockService)
+			router := setupTestRouter()
+			router.POST("/trash/lists/:id/restore", handler.RestoreShoppingList)
+
+			req := httptest.NewRequest(http.MethodPost, "/trash/lists/"+tt.listID+"/restore", nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var responseBody map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &responseBody)
+			require.NoError(t, err)
+
+			tt.expectedBody(t, responseBody)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTrashHandler_RestoreItem(t *testing.T) {
+	tests := []struct {
+		name           string
+		mockSetup      func(*MockTrashService)
+		expectedStatus int
+		expectedBody   func(*testing.T, map[string]interface{})
+	}{
+		{
+			name: "successfully restores item",
+			mockSetup: func(m *MockTrashService) {
+				m.On("RestoreItem", mock.Anything, mock.AnythingOfType("uuid.UUID")).Return(&entities.Item{Name: "Milk"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Milk", body["name"])
+			},
+		},
+		{
+			name: "fails when item is not in trash",
+			mockSetup: func(m *MockTrashService) {
+				m.On("RestoreItem", mock.Anything, mock.AnythingOfType("uuid.UUID")).Return(nil, entities.ErrItemNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Item not found in trash", body["error"])
+			},
+		},
+		{
+			name: "fails when its list is in trash",
+			mockSetup: func(m *MockTrashService) {
+				m.On("RestoreItem", mock.Anything, mock.AnythingOfType("uuid.UUID")).Return(nil, entities.ErrShoppingListNotFound)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "Restore the item's shopping list first", body["error"])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockTrashService{}
+			tt.mockSetup(mockService)
+
+			handler := NewTrashHandler(mockService)
+			router := setupTestRouter()
+			router.POST("/trash/items/:id/restore", handler.RestoreItem)
+
+			req := httptest.NewRequest(http.MethodPost, "/trash/items/"+uuid.New().String()+"/restore", nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var responseBody map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &responseBody)
+			require.NoError(t, err)
+
+			tt.expectedBody(t, responseBody)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
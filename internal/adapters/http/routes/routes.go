@@ -11,26 +11,115 @@ func SetupRoutes(
 	router *gin.Engine,
 	shoppingListHandler *handlers.ShoppingListHandler,
 	itemHandler *handlers.ItemHandler,
+	categoryHandler *handlers.CategoryHandler,
+	trashHandler *handlers.TrashHandler,
+	templateHandler *handlers.TemplateHandler,
+	searchHandler *handlers.SearchHandler,
+	itemImageHandler *handlers.ItemImageHandler,
+	pantryHandler *handlers.PantryHandler,
+	stapleHandler *handlers.StapleHandler,
+	recipeHandler *handlers.RecipeHandler,
+	authHandler *handlers.AuthHandler,
+	apiKeyHandler *handlers.APIKeyHandler,
+	listMemberHandler *handlers.ListMemberHandler,
+	invitationHandler *handlers.InvitationHandler,
 ) {
 	// API v1 routes
 	v1 := router.Group("/api/v1")
+
+	// Authentication routes are public
+	v1.POST("/auth/register", authHandler.Register)
+	v1.POST("/auth/login", authHandler.Login)
+	v1.POST("/auth/refresh", authHandler.Refresh)
+
+	// Every other route acts on behalf of the authenticated user
+	api := v1.Group("", authHandler.RequireAuth)
 	{
 		// Shopping list routes
-		v1.POST("/lists", shoppingListHandler.CreateShoppingList)
-		v1.GET("/lists", shoppingListHandler.GetAllShoppingLists)
-		v1.GET("/lists/:id", shoppingListHandler.GetShoppingList)
-		v1.PUT("/lists/:id", shoppingListHandler.UpdateShoppingList)
-		v1.DELETE("/lists/:id", shoppingListHandler.DeleteShoppingList)
+		api.POST("/lists", shoppingListHandler.CreateShoppingList)
+		api.GET("/lists", shoppingListHandler.GetAllShoppingLists)
+		api.GET("/lists/:id", shoppingListHandler.GetShoppingList)
+		api.PUT("/lists/:id", shoppingListHandler.UpdateShoppingList)
+		api.PATCH("/lists/:id", shoppingListHandler.PatchShoppingList)
+		api.DELETE("/lists/:id", shoppingListHandler.DeleteShoppingList)
+		api.POST("/lists/:id/clone", shoppingListHandler.CloneShoppingList)
+		api.POST("/lists/:id/clear-completed", shoppingListHandler.ClearCompleted)
+		api.POST("/lists/:id/complete-all", shoppingListHandler.CompleteAll)
+		api.POST("/lists/:id/reset", shoppingListHandler.ResetShoppingList)
+		api.POST("/lists/:id/import/recipe", recipeHandler.ImportRecipe)
+
+		// Members of shared shopping lists
+		api.GET("/lists/:id/members", listMemberHandler.GetMembers)
+		api.POST("/lists/:id/members", listMemberHandler.AddMember)
+		api.PUT("/lists/:id/members/:userId", listMemberHandler.UpdateMember)
+		api.DELETE("/lists/:id/members/:userId", listMemberHandler.RemoveMember)
+
+		// Invitations to shared shopping lists
+		api.POST("/lists/:id/invitations", invitationHandler.CreateInvitation)
+		api.GET("/lists/:id/invitations", invitationHandler.GetInvitations)
+		api.DELETE("/lists/:id/invitations/:invitationId", invitationHandler.RevokeInvitation)
+		api.POST("/invitations/:token/accept", invitationHandler.AcceptInvitation)
 
 		// Items within a specific shopping list (using different path to avoid conflicts)
-		v1.POST("/shopping-lists/:listId/items", itemHandler.CreateItem)
-		v1.GET("/shopping-lists/:listId/items", itemHandler.GetItemsByShoppingListID)
+		api.POST("/shopping-lists/:listId/items", itemHandler.CreateItem)
+		api.GET("/shopping-lists/:listId/items", itemHandler.GetItemsByShoppingListID)
+		api.PUT("/shopping-lists/:listId/items/order", itemHandler.ReorderItems)
+		// Gin cannot escape the colon of "items:batch", so the action is matched as a parameter
+		api.POST("/shopping-lists/:listId/items:action", itemHandler.ItemAction)
 
 		// Item routes (for direct item operations)
-		v1.GET("/items/:id", itemHandler.GetItem)
-		v1.PUT("/items/:id", itemHandler.UpdateItem)
-		v1.DELETE("/items/:id", itemHandler.DeleteItem)
-		v1.PATCH("/items/:id/toggle", itemHandler.ToggleItemCompletion)
+		api.GET("/items/:id", itemHandler.GetItem)
+		api.PUT("/items/:id", itemHandler.UpdateItem)
+		api.PATCH("/items/:id", itemHandler.PatchItem)
+		api.DELETE("/items/:id", itemHandler.DeleteItem)
+		api.PATCH("/items/:id/toggle", itemHandler.ToggleItemCompletion)
+		api.POST("/items/:id/image", itemImageHandler.UploadImage)
+		api.GET("/items/:id/image", itemImageHandler.GetImage)
+		api.DELETE("/items/:id/image", itemImageHandler.DeleteImage)
+
+		// Category routes
+		api.POST("/categories", categoryHandler.CreateCategory)
+		api.GET("/categories", categoryHandler.GetAllCategories)
+		api.GET("/categories/:id", categoryHandler.GetCategory)
+		api.PUT("/categories/:id", categoryHandler.UpdateCategory)
+		api.DELETE("/categories/:id", categoryHandler.DeleteCategory)
+
+		// Template routes
+		api.POST("/templates", templateHandler.CreateTemplate)
+		api.GET("/templates", templateHandler.GetAllTemplates)
+		api.GET("/templates/:id", templateHandler.GetTemplate)
+		api.PUT("/templates/:id", templateHandler.UpdateTemplate)
+		api.DELETE("/templates/:id", templateHandler.DeleteTemplate)
+		api.POST("/templates/:id/instantiate", templateHandler.InstantiateTemplate)
+
+		// Pantry routes
+		api.POST("/pantry", pantryHandler.CreatePantryItem)
+		api.GET("/pantry", pantryHandler.GetPantryItems)
+		api.GET("/pantry/:id", pantryHandler.GetPantryItem)
+		api.PUT("/pantry/:id", pantryHandler.UpdatePantryItem)
+		api.DELETE("/pantry/:id", pantryHandler.DeletePantryItem)
+
+		// Staple routes
+		api.POST("/staples", stapleHandler.CreateStaple)
+		api.GET("/staples", stapleHandler.GetAllStaples)
+		api.GET("/staples/low", stapleHandler.GetLowStaples)
+		api.GET("/staples/:id", stapleHandler.GetStaple)
+		api.PUT("/staples/:id", stapleHandler.UpdateStaple)
+		api.DELETE("/staples/:id", stapleHandler.DeleteStaple)
+
+		// Search routes
+		api.GET("/search", searchHandler.Search)
+
+		// API key routes need a login session rather than an API key
+		apiKeys := api.Group("/api-keys", authHandler.RequireSession)
+		apiKeys.POST("", apiKeyHandler.CreateAPIKey)
+		apiKeys.GET("", apiKeyHandler.GetAPIKeys)
+		apiKeys.DELETE("/:id", apiKeyHandler.RevokeAPIKey)
+
+		// Trash routes
+		api.GET("/trash", trashHandler.GetTrash)
+		api.POST("/trash/lists/:id/restore", trashHandler.RestoreShoppingList)
+		api.POST("/trash/items/:id/restore", trashHandler.RestoreItem)
 	}
 
 	// Health check endpoint
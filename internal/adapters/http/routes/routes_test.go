@@ -4,9 +4,12 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/uriberma/go-shopping-list-api/internal/adapters/http/handlers"
+	"github.com/uriberma/go-shopping-list-api/internal/application/services"
 )
 
 func TestSetupRoutes(t *testing.T) {
@@ -15,7 +18,7 @@ func TestSetupRoutes(t *testing.T) {
 
 	// Create router and setup routes with nil handlers for basic route testing
 	router := gin.New()
-	SetupRoutes(router, nil, nil)
+	SetupRoutes(router, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	// Test that the router was created and routes were set up
 	// We can't test individual routes with nil handlers, but we can test the setup
@@ -36,7 +39,7 @@ func TestSetupRoutes_HealthEndpoint(t *testing.T) {
 
 	// Create router and setup routes with nil handlers for health endpoint test
 	router := gin.New()
-	SetupRoutes(router, nil, nil)
+	SetupRoutes(router, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	req, err := http.NewRequest("GET", "/health", nil)
 	assert.NoError(t, err)
@@ -49,3 +52,19 @@ func TestSetupRoutes_HealthEndpoint(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "shopping-list-api")
 	assert.Contains(t, w.Body.String(), "v1.0.0")
 }
+
+func TestSetupRoutes_RequiresAuthentication(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	authService := services.NewAuthService(nil, services.AuthConfig{Secret: []byte("test-secret")}, time.Now)
+	router := gin.New()
+	SetupRoutes(router, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, handlers.NewAuthHandler(authService, nil), nil, nil, nil)
+
+	req, err := http.NewRequest("GET", "/api/v1/lists", nil)
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+)
+
+// CreateAPIKeyInput holds the attributes of a new API key.
+// An empty scope defaults to read-only, and a nil ExpiresAt never expires.
+type CreateAPIKeyInput struct {
+	Name      string
+	Scope     entities.APIKeyScope
+	ExpiresAt *time.Time
+}
+
+// APIKeyService handles the API keys scripts authenticate with
+type APIKeyService struct {
+	apiKeyRepo repositories.APIKeyRepository
+	clock      Clock
+}
+
+// NewAPIKeyService creates a new API key service
+func NewAPIKeyService(apiKeyRepo repositories.APIKeyRepository, clock Clock) *APIKeyService {
+	return &APIKeyService{
+		apiKeyRepo: apiKeyRepo,
+		clock:      clock,
+	}
+}
+
+// CreateAPIKey creates an API key for the caller and returns it along with the key itself,
+// which is only available now
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, input CreateAPIKeyInput) (*entities.APIKey, string, error) {
+	userID, ok := CallerFromContext(ctx)
+	if !ok {
+		return nil, "", entities.ErrUnauthenticated
+	}
+
+	scope := input.Scope
+	if scope == "" {
+		scope = entities.APIKeyScopeReadOnly
+	}
+	if input.Name == "" || utf8.RuneCountInString(input.Name) > entities.MaxAPIKeyNameLength || !scope.IsValid() {
+		return nil, "", entities.ErrInvalidInput
+	}
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(s.clock()) {
+		return nil, "", entities.ErrInvalidInput
+	}
+
+	apiKey, key, err := entities.NewAPIKey(userID, input.Name, scope, input.ExpiresAt)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.apiKeyRepo.Create(ctx, apiKey); err != nil {
+		return nil, "", err
+	}
+
+	return apiKey, key, nil
+}
+
+// GetAPIKeys retrieves the caller's API keys, newest first
+func (s *APIKeyService) GetAPIKeys(ctx context.Context) ([]*entities.APIKey, error) {
+	userID, ok := CallerFromContext(ctx)
+	if !ok {
+		return nil, entities.ErrUnauthenticated
+	}
+	return s.apiKeyRepo.GetByUserID(ctx, userID)
+}
+
+// RevokeAPIKey deletes one of the caller's API keys; keys of other users are reported as not found
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+	userID, ok := CallerFromContext(ctx)
+	if !ok {
+		return entities.ErrUnauthenticated
+	}
+
+	apiKey, err := s.apiKeyRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if apiKey.UserID != userID {
+		return entities.ErrAPIKeyNotFound
+	}
+
+	return s.apiKeyRepo.Delete(ctx, id)
+}
+
+// Authenticate looks up an unexpired API key and records that it was used
+func (s *APIKeyService) Authenticate(ctx context.Context, key string) (*entities.APIKey, error) {
+	apiKey, err := s.apiKeyRepo.GetByHash(ctx, entities.HashAPIKey(key))
+	if err != nil {
+		if err == entities.ErrAPIKeyNotFound {
+			return nil, entities.ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	now := s.clock()
+	if apiKey.IsExpired(now) {
+		return nil, entities.ErrInvalidToken
+	}
+
+	if err := s.apiKeyRepo.TouchLastUsed(ctx, apiKey.ID, now); err != nil {
+		return nil, err
+	}
+	apiKey.LastUsedAt = &now
+
+	return apiKey, nil
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// MockAPIKeyRepository is a mock implementation of APIKeyRepository
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *entities.APIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.APIKey, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*entities.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*entities.APIKey, error) {
+	args := m.Called(ctx, keyHash)
+	return args.Get(0).(*entities.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.APIKey, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]*entities.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	args := m.Called(ctx, id, usedAt)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestAPIKeyService_CreateAPIKey(t *testing.T) {
+	now := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+	ctx := WithCaller(context.Background(), userID)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	t.Run("creates a read-only key by default", func(t *testing.T) {
+		repo := &MockAPIKeyRepository{}
+		service := NewAPIKeyService(repo, func() time.Time { return now })
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*entities.APIKey")).Return(nil)
+
+		apiKey, key, err := service.CreateAPIKey(ctx, CreateAPIKeyInput{Name: "Home Assistant", ExpiresAt: &future})
+
+		require.NoError(t, err)
+		assert.Equal(t, userID, apiKey.UserID)
+		assert.Equal(t, entities.APIKeyScopeReadOnly, apiKey.Scope)
+		assert.Equal(t, entities.HashAPIKey(key), apiKey.KeyHash)
+		repo.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		input         CreateAPIKeyInput
+		expectedError error
+	}{
+		{name: "requires a caller", ctx: context.Background(), input: CreateAPIKeyInput{Name: "Script"}, expectedError: entities.ErrUnauthenticated},
+		{name: "requires a name", ctx: ctx, input: CreateAPIKeyInput{}, expectedError: entities.ErrInvalidInput},
+		{name: "rejects unknown scopes", ctx: ctx, input: CreateAPIKeyInput{Name: "Script", Scope: "admin"}, expectedError: entities.ErrInvalidInput},
+		{name: "rejects past expiry", ctx: ctx, input: CreateAPIKeyInput{Name: "Script", ExpiresAt: &past}, expectedError: entities.ErrInvalidInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewAPIKeyService(&MockAPIKeyRepository{}, func() time.Time { return now })
+			_, _, err := service.CreateAPIKey(tt.ctx, tt.input)
+			assert.Equal(t, tt.expectedError, err)
+		})
+	}
+}
+
+func TestAPIKeyService_RevokeAPIKey(t *testing.T) {
+	repo := &MockAPIKeyRepository{}
+	service := NewAPIKeyService(repo, time.Now)
+	userID := uuid.New()
+	mine := &entities.APIKey{ID: uuid.New(), UserID: userID}
+	theirs := &entities.APIKey{ID: uuid.New(), UserID: uuid.New()}
+	repo.On("GetByID", mock.Anything, mine.ID).Return(mine, nil)
+	repo.On("GetByID", mock.Anything, theirs.ID).Return(theirs, nil)
+	repo.On("Delete", mock.Anything, mine.ID).Return(nil)
+	ctx := WithCaller(context.Background(), userID)
+
+	assert.NoError(t, service.RevokeAPIKey(ctx, mine.ID))
+	assert.Equal(t, entities.ErrAPIKeyNotFound, service.RevokeAPIKey(ctx, theirs.ID))
+	repo.AssertNotCalled(t, "Delete", mock.Anything, theirs.ID)
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	now := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+	repo := &MockAPIKeyRepository{}
+	service := NewAPIKeyService(repo, func() time.Time { return now })
+
+	valid, validKey, err := entities.NewAPIKey(uuid.New(), "Valid", entities.APIKeyScopeReadWrite, nil)
+	require.NoError(t, err)
+	expiredAt := now.Add(-time.Minute)
+	expired, expiredKey, err := entities.NewAPIKey(uuid.New(), "Expired", entities.APIKeyScopeReadWrite, &expiredAt)
+	require.NoError(t, err)
+	repo.On("GetByHash", mock.Anything, valid.KeyHash).Return(valid, nil)
+	repo.On("GetByHash", mock.Anything, expired.KeyHash).Return(expired, nil)
+	repo.On("GetByHash", mock.Anything, mock.Anything).Return((*entities.APIKey)(nil), entities.ErrAPIKeyNotFound)
+	repo.On("TouchLastUsed", mock.Anything, valid.ID, now).Return(nil)
+
+	apiKey, err := service.Authenticate(context.Background(), validKey)
+	require.NoError(t, err)
+	assert.Equal(t, valid.UserID, apiKey.UserID)
+	assert.Equal(t, &now, apiKey.LastUsedAt)
+
+	_, err = service.Authenticate(context.Background(), expiredKey)
+	assert.Equal(t, entities.ErrInvalidToken, err)
+	_, err = service.Authenticate(context.Background(), "slk_unknown")
+	assert.Equal(t, entities.ErrInvalidToken, err)
+	repo.AssertNumberOfCalls(t, "TouchLastUsed", 1)
+}
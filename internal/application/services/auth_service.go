@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Token types, carried in the "typ" claim so that a refresh token cannot be used as an access token
+const (
+	accessTokenType  = "access"
+	refreshTokenType = "refresh"
+)
+
+// AuthConfig holds the secret tokens are signed with and how long they stay valid.
+// LegacyOwnerEmail names an existing account that takes over the data created before accounts existed.
+type AuthConfig struct {
+	Secret           []byte
+	AccessTTL        time.Duration
+	RefreshTTL       time.Duration
+	LegacyOwnerEmail string
+}
+
+// TokenPair is a short-lived access token and the refresh token that renews it
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    time.Duration
+}
+
+// tokenClaims are the claims of the tokens issued by the auth service
+type tokenClaims struct {
+	Type string `json:"typ"`
+	jwt.RegisteredClaims
+}
+
+// AuthService handles user registration and the HS256-signed JWTs that authenticate them.
+// Tokens are stateless: a refresh token stays valid until it expires.
+type AuthService struct {
+	userRepo repositories.UserRepository
+	config   AuthConfig
+	clock    Clock
+}
+
+// NewAuthService creates a new auth service
+func NewAuthService(userRepo repositories.UserRepository, config AuthConfig, clock Clock) *AuthService {
+	return &AuthService{
+		userRepo: userRepo,
+		config:   config,
+		clock:    clock,
+	}
+}
+
+// Register creates a user with a bcrypt hash of their password
+func (s *AuthService) Register(ctx context.Context, email, password string) (*entities.User, error) {
+	email, err := entities.NormalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	if err := entities.ValidatePassword(password); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.userRepo.GetByEmail(ctx, email); err == nil {
+		return nil, entities.ErrDuplicateEmail
+	} else if err != entities.ErrUserNotFound {
+		return nil, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	user := entities.NewUser(email, string(hash))
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// AdoptLegacyData assigns the data created before accounts existed to the legacy owner.
+// Registering never hands the data over, as nobody proves they own an email by registering it;
+// the operator registers the account first and only then configures it as the legacy owner.
+// It does nothing while the legacy owner is not configured or does not exist, so it is safe to run on every start.
+func (s *AuthService) AdoptLegacyData(ctx context.Context) (int64, error) {
+	email := s.legacyOwnerEmail()
+	if email == "" {
+		return 0, nil
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if err == entities.ErrUserNotFound {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return s.userRepo.AdoptUnowned(ctx, user.ID)
+}
+
+// legacyOwnerEmail returns the normalized email of the legacy owner, or "" when none is configured
+func (s *AuthService) legacyOwnerEmail() string {
+	email, err := entities.NormalizeEmail(s.config.LegacyOwnerEmail)
+	if err != nil {
+		return ""
+	}
+	return email
+}
+
+// Login checks the credentials of a user and issues them a token pair.
+// Unknown emails and wrong passwords are both reported as invalid credentials.
+func (s *AuthService) Login(ctx context.Context, email, password string) (*TokenPair, error) {
+	email, err := entities.NormalizeEmail(email)
+	if err != nil {
+		return nil, entities.ErrInvalidCredentials
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if err == entities.ErrUserNotFound {
+			return nil, entities.ErrInvalidCredentials
+		}
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return nil, entities.ErrInvalidCredentials
+	}
+
+	return s.issueTokens(user.ID)
+}
+
+// Refresh exchanges a refresh token for a new token pair, as long as its user still exists
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	userID, err := s.verify(refreshToken, refreshTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		if err == entities.ErrUserNotFound {
+			return nil, entities.ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	return s.issueTokens(userID)
+}
+
+// Authenticate verifies an access token and returns the ID of the user it was issued to
+func (s *AuthService) Authenticate(ctx context.Context, accessToken string) (uuid.UUID, error) {
+	return s.verify(accessToken, accessTokenType)
+}
+
+// issueTokens signs a new access and refresh token for a user
+func (s *AuthService) issueTokens(userID uuid.UUID) (*TokenPair, error) {
+	accessToken, err := s.sign(userID, accessTokenType, s.config.AccessTTL)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := s.sign(userID, refreshTokenType, s.config.RefreshTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    s.config.AccessTTL,
+	}, nil
+}
+
+// sign creates a token of the given type for a user
+func (s *AuthService) sign(userID uuid.UUID, tokenType string, ttl time.Duration) (string, error) {
+	now := s.clock()
+	claims := tokenClaims{
+		Type: tokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Subject:   userID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.config.Secret)
+}
+
+// verify checks the signature, expiry and type of a token and returns its subject
+func (s *AuthService) verify(token, tokenType string) (uuid.UUID, error) {
+	var claims tokenClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+		return s.config.Secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(s.clock),
+	)
+	if err != nil || claims.Type != tokenType {
+		return uuid.Nil, entities.ErrInvalidToken
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return uuid.Nil, entities.ErrInvalidToken
+	}
+	return userID, nil
+}
@@ -0,0 +1,242 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// MockUserRepository is a mock implementation of UserRepository
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) Create(ctx context.Context, user *entities.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*entities.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
+	args := m.Called(ctx, email)
+	return args.Get(0).(*entities.User), args.Error(1)
+}
+
+func (m *MockUserRepository) AdoptUnowned(ctx context.Context, userID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// newTestAuthService creates an auth service whose clock can be moved by the test
+func newTestAuthService(now *time.Time) (*AuthService, *MockUserRepository) {
+	userRepo := &MockUserRepository{}
+	config := AuthConfig{
+		Secret:     []byte("test-secret"),
+		AccessTTL:  15 * time.Minute,
+		RefreshTTL: 24 * time.Hour,
+	}
+	return NewAuthService(userRepo, config, func() time.Time { return *now }), userRepo
+}
+
+// newTestUser creates a user with a hash of the given password
+func newTestUser(t *testing.T, email, password string) *entities.User {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+	return entities.NewUser(email, string(hash))
+}
+
+func TestAuthService_Register(t *testing.T) {
+	now := time.Now()
+	service, userRepo := newTestAuthService(&now)
+	userRepo.On("GetByEmail", mock.Anything, "ana@example.com").Return((*entities.User)(nil), entities.ErrUserNotFound)
+	userRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.User")).Return(nil)
+
+	user, err := service.Register(context.Background(), " Ana@Example.com ", "correct horse")
+
+	require.NoError(t, err)
+	assert.Equal(t, "ana@example.com", user.Email)
+	assert.NotEqual(t, "correct horse", user.PasswordHash)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte("correct horse")))
+	userRepo.AssertExpectations(t)
+}
+
+func TestAuthService_Register_Errors(t *testing.T) {
+	now := time.Now()
+
+	t.Run("rejects invalid emails and passwords", func(t *testing.T) {
+		service, _ := newTestAuthService(&now)
+		_, err := service.Register(context.Background(), "not an email", "correct horse")
+		assert.Equal(t, entities.ErrInvalidInput, err)
+		_, err = service.Register(context.Background(), "ana@example.com", "short")
+		assert.Equal(t, entities.ErrInvalidInput, err)
+	})
+
+	t.Run("rejects registered emails", func(t *testing.T) {
+		service, userRepo := newTestAuthService(&now)
+		existing := entities.NewUser("ana@example.com", "hash")
+		userRepo.On("GetByEmail", mock.Anything, "ana@example.com").Return(existing, nil)
+
+		_, err := service.Register(context.Background(), "ana@example.com", "correct horse")
+
+		assert.Equal(t, entities.ErrDuplicateEmail, err)
+		userRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthService_Register_DoesNotAdoptLegacyData(t *testing.T) {
+	now := time.Now()
+	service, userRepo := newTestAuthService(&now)
+	service.config.LegacyOwnerEmail = "Ana@Example.com"
+	userRepo.On("GetByEmail", mock.Anything, mock.Anything).Return((*entities.User)(nil), entities.ErrUserNotFound)
+	userRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.User")).Return(nil)
+	userRepo.On("AdoptUnowned", mock.Anything, mock.AnythingOfType("uuid.UUID")).Return(int64(3), nil)
+
+	// Anyone may register the legacy owner's email, so registering it must not hand over the data
+	_, err := service.Register(context.Background(), "ana@example.com", "correct horse")
+	require.NoError(t, err)
+	userRepo.AssertNotCalled(t, "AdoptUnowned", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_AdoptLegacyData(t *testing.T) {
+	now := time.Now()
+
+	t.Run("does nothing without a legacy owner", func(t *testing.T) {
+		service, userRepo := newTestAuthService(&now)
+
+		adopted, err := service.AdoptLegacyData(context.Background())
+
+		require.NoError(t, err)
+		assert.Zero(t, adopted)
+		userRepo.AssertNotCalled(t, "GetByEmail", mock.Anything, mock.Anything)
+	})
+
+	t.Run("waits for the legacy owner to register", func(t *testing.T) {
+		service, userRepo := newTestAuthService(&now)
+		service.config.LegacyOwnerEmail = "ana@example.com"
+		userRepo.On("GetByEmail", mock.Anything, "ana@example.com").Return((*entities.User)(nil), entities.ErrUserNotFound)
+
+		adopted, err := service.AdoptLegacyData(context.Background())
+
+		require.NoError(t, err)
+		assert.Zero(t, adopted)
+		userRepo.AssertNotCalled(t, "AdoptUnowned", mock.Anything, mock.Anything)
+	})
+
+	t.Run("assigns unowned data to the legacy owner", func(t *testing.T) {
+		service, userRepo := newTestAuthService(&now)
+		service.config.LegacyOwnerEmail = "ana@example.com"
+		user := entities.NewUser("ana@example.com", "hash")
+		userRepo.On("GetByEmail", mock.Anything, "ana@example.com").Return(user, nil)
+		userRepo.On("AdoptUnowned", mock.Anything, user.ID).Return(int64(5), nil)
+
+		adopted, err := service.AdoptLegacyData(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), adopted)
+		userRepo.AssertExpectations(t)
+	})
+}
+
+func TestAuthService_LoginAndAuthenticate(t *testing.T) {
+	now := time.Now()
+	service, userRepo := newTestAuthService(&now)
+	user := newTestUser(t, "ana@example.com", "correct horse")
+	userRepo.On("GetByEmail", mock.Anything, "ana@example.com").Return(user, nil)
+	userRepo.On("GetByEmail", mock.Anything, "bob@example.com").Return((*entities.User)(nil), entities.ErrUserNotFound)
+	ctx := context.Background()
+
+	tokens, err := service.Login(ctx, "ANA@example.com", "correct horse")
+	require.NoError(t, err)
+	assert.Equal(t, 15*time.Minute, tokens.ExpiresIn)
+
+	userID, err := service.Authenticate(ctx, tokens.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, userID)
+
+	// A refresh token cannot stand in for an access token
+	_, err = service.Authenticate(ctx, tokens.RefreshToken)
+	assert.Equal(t, entities.ErrInvalidToken, err)
+
+	_, err = service.Login(ctx, "ana@example.com", "wrong password")
+	assert.Equal(t, entities.ErrInvalidCredentials, err)
+	_, err = service.Login(ctx, "bob@example.com", "correct horse")
+	assert.Equal(t, entities.ErrInvalidCredentials, err)
+
+	now = now.Add(16 * time.Minute)
+	_, err = service.Authenticate(ctx, tokens.AccessToken)
+	assert.Equal(t, entities.ErrInvalidToken, err)
+}
+
+func TestAuthService_Refresh(t *testing.T) {
+	now := time.Now()
+	service, userRepo := newTestAuthService(&now)
+	user := newTestUser(t, "ana@example.com", "correct horse")
+	userRepo.On("GetByEmail", mock.Anything, "ana@example.com").Return(user, nil)
+	userRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	ctx := context.Background()
+
+	tokens, err := service.Login(ctx, "ana@example.com", "correct horse")
+	require.NoError(t, err)
+
+	now = now.Add(time.Hour)
+	refreshed, err := service.Refresh(ctx, tokens.RefreshToken)
+	require.NoError(t, err)
+	userID, err := service.Authenticate(ctx, refreshed.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, userID)
+
+	_, err = service.Refresh(ctx, tokens.AccessToken)
+	assert.Equal(t, entities.ErrInvalidToken, err)
+
+	now = now.Add(48 * time.Hour)
+	_, err = service.Refresh(ctx, tokens.RefreshToken)
+	assert.Equal(t, entities.ErrInvalidToken, err)
+}
+
+func TestAuthService_Refresh_DeletedUser(t *testing.T) {
+	now := time.Now()
+	service, userRepo := newTestAuthService(&now)
+	user := newTestUser(t, "ana@example.com", "correct horse")
+	userRepo.On("GetByEmail", mock.Anything, "ana@example.com").Return(user, nil)
+	userRepo.On("GetByID", mock.Anything, user.ID).Return((*entities.User)(nil), entities.ErrUserNotFound)
+
+	tokens, err := service.Login(context.Background(), "ana@example.com", "correct horse")
+	require.NoError(t, err)
+
+	_, err = service.Refresh(context.Background(), tokens.RefreshToken)
+	assert.Equal(t, entities.ErrInvalidToken, err)
+}
+
+func TestAuthService_Authenticate_RejectsForgedTokens(t *testing.T) {
+	now := time.Now()
+	service, _ := newTestAuthService(&now)
+	claims := tokenClaims{
+		Type: accessTokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   uuid.NewString(),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+	}
+
+	otherSecret, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("other-secret"))
+	require.NoError(t, err)
+	unsigned, err := jwt.NewWithClaims(jwt.SigningMethodNone, claims).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	for _, token := range []string{otherSecret, unsigned, "not-a-token"} {
+		_, err := service.Authenticate(context.Background(), token)
+		assert.Equal(t, entities.ErrInvalidToken, err)
+	}
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+)
+
+// callerKey is the context key of the user a request acts on behalf of
+type callerKey struct{}
+
+// WithCaller returns a context that acts on behalf of a user. Services scope what they
+// read and write to that user; a context without a caller is trusted internal work,
+// such as the background jobs, and is not scoped.
+func WithCaller(ctx context.Context, userID uuid.UUID) context.Context {
+	return context.WithValue(ctx, callerKey{}, userID)
+}
+
+// CallerFromContext returns the user a context acts on behalf of, if any
+func CallerFromContext(ctx context.Context) (uuid.UUID, bool) {
+	userID, ok := ctx.Value(callerKey{}).(uuid.UUID)
+	return userID, ok
+}
+
+// callerScope returns the owner to scope repository queries to, or nil for internal work
+func callerScope(ctx context.Context) *uuid.UUID {
+	if userID, ok := CallerFromContext(ctx); ok {
+		return &userID
+	}
+	return nil
+}
+
+// isVisibleTo reports whether something with the given owner may be accessed from a context
+func isVisibleTo(ctx context.Context, ownerID *uuid.UUID) bool {
+	userID, ok := CallerFromContext(ctx)
+	return !ok || (ownerID != nil && *ownerID == userID)
+}
+
+// getListWithRole retrieves a shopping list the caller holds at least a role in.
+// Lists the caller holds no role in are reported as not found, so that their existence
+// does not leak; a lesser role fails with ErrForbidden.
+func getListWithRole(
+	ctx context.Context,
+	shoppingListRepo repositories.ShoppingListRepository,
+	id uuid.UUID,
+	required entities.ListRole,
+) (*entities.ShoppingList, error) {
+	list, err := shoppingListRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRole(ctx, list, required); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// checkRole verifies that the caller holds at least a role in a loaded shopping list,
+// reporting lists the caller holds no role in as not found
+func checkRole(ctx context.Context, list *entities.ShoppingList, required entities.ListRole) error {
+	userID, ok := CallerFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	role, isMember := list.RoleOf(userID)
+	if !isMember {
+		return entities.ErrShoppingListNotFound
+	}
+	if !role.Allows(required) {
+		return entities.ErrForbidden
+	}
+	return nil
+}
+
+// getAccessibleList retrieves a shopping list the caller may view
+func getAccessibleList(
+	ctx context.Context,
+	shoppingListRepo repositories.ShoppingListRepository,
+	id uuid.UUID,
+) (*entities.ShoppingList, error) {
+	return getListWithRole(ctx, shoppingListRepo, id, entities.ListRoleViewer)
+}
+
+// getEditableList retrieves a shopping list the caller may change
+func getEditableList(
+	ctx context.Context,
+	shoppingListRepo repositories.ShoppingListRepository,
+	id uuid.UUID,
+) (*entities.ShoppingList, error) {
+	return getListWithRole(ctx, shoppingListRepo, id, entities.ListRoleEditor)
+}
+
+// checkListAccess verifies that the caller holds at least a role in a shopping list
+// without loading it for internal work
+func checkListAccess(
+	ctx context.Context,
+	shoppingListRepo repositories.ShoppingListRepository,
+	id uuid.UUID,
+	required entities.ListRole,
+) error {
+	if _, ok := CallerFromContext(ctx); !ok {
+		return nil
+	}
+	_, err := getListWithRole(ctx, shoppingListRepo, id, required)
+	return err
+}
+
+// getItemWithRole retrieves an item of a shopping list the caller holds at least a role in.
+// Items of lists the caller holds no role in are reported as not found.
+func getItemWithRole(
+	ctx context.Context,
+	itemRepo repositories.ItemRepository,
+	shoppingListRepo repositories.ShoppingListRepository,
+	id uuid.UUID,
+	required entities.ListRole,
+) (*entities.Item, error) {
+	item, err := itemRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkListAccess(ctx, shoppingListRepo, item.ShoppingListID, required); err != nil {
+		if err == entities.ErrShoppingListNotFound {
+			return nil, entities.ErrItemNotFound
+		}
+		return nil, err
+	}
+	return item, nil
+}
+
+// getAccessibleItem retrieves an item the caller may view
+func getAccessibleItem(
+	ctx context.Context,
+	itemRepo repositories.ItemRepository,
+	shoppingListRepo repositories.ShoppingListRepository,
+	id uuid.UUID,
+) (*entities.Item, error) {
+	return getItemWithRole(ctx, itemRepo, shoppingListRepo, id, entities.ListRoleViewer)
+}
+
+// getEditableItem retrieves an item the caller may change
+func getEditableItem(
+	ctx context.Context,
+	itemRepo repositories.ItemRepository,
+	shoppingListRepo repositories.ShoppingListRepository,
+	id uuid.UUID,
+) (*entities.Item, error) {
+	return getItemWithRole(ctx, itemRepo, shoppingListRepo, id, entities.ListRoleEditor)
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+)
+
+// CategoryService handles business logic for item categories.
+// Callers only see and change their own categories.
+type CategoryService struct {
+	categoryRepo repositories.CategoryRepository
+}
+
+// NewCategoryService creates a new category service
+func NewCategoryService(categoryRepo repositories.CategoryRepository) *CategoryService {
+	return &CategoryService{categoryRepo: categoryRepo}
+}
+
+// CreateCategory creates a new category with a name unique among the caller's categories
+func (s *CategoryService) CreateCategory(ctx context.Context, name string, position int) (*entities.Category, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, entities.ErrInvalidInput
+	}
+
+	if err := s.ensureNameAvailable(ctx, name, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	category := entities.NewCategory(name, position)
+	category.OwnerID = callerScope(ctx)
+	if err := s.categoryRepo.Create(ctx, category); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// GetCategory retrieves a category by ID
+func (s *CategoryService) GetCategory(ctx context.Context, id uuid.UUID) (*entities.Category, error) {
+	return s.getAccessibleCategory(ctx, id)
+}
+
+// GetAllCategories retrieves the caller's categories in display order
+func (s *CategoryService) GetAllCategories(ctx context.Context) ([]*entities.Category, error) {
+	return s.categoryRepo.GetAll(ctx, callerScope(ctx))
+}
+
+// UpdateCategory renames or repositions an existing category
+func (s *CategoryService) UpdateCategory(ctx context.Context, id uuid.UUID, name string, position int) (*entities.Category, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, entities.ErrInvalidInput
+	}
+
+	category, err := s.getAccessibleCategory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureNameAvailable(ctx, name, id); err != nil {
+		return nil, err
+	}
+
+	category.Name = name
+	category.Position = position
+
+	if err := s.categoryRepo.Update(ctx, category); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// DeleteCategory deletes a category, leaving its items uncategorized
+func (s *CategoryService) DeleteCategory(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.getAccessibleCategory(ctx, id); err != nil {
+		return err
+	}
+	return s.categoryRepo.Delete(ctx, id)
+}
+
+// getAccessibleCategory retrieves a category the caller owns; other users' categories are reported as not found
+func (s *CategoryService) getAccessibleCategory(ctx context.Context, id uuid.UUID) (*entities.Category, error) {
+	category, err := s.categoryRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !isVisibleTo(ctx, category.OwnerID) {
+		return nil, entities.ErrCategoryNotFound
+	}
+	return category, nil
+}
+
+// ensureNameAvailable checks that no other category of the caller uses the given name
+func (s *CategoryService) ensureNameAvailable(ctx context.Context, name string, id uuid.UUID) error {
+	existing, err := s.categoryRepo.GetByName(ctx, callerScope(ctx), name)
+	if err == entities.ErrCategoryNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.ID != id {
+		return entities.ErrDuplicateCategory
+	}
+	return nil
+}
+
+// verifyCategoryOwner checks that an optional category exists and belongs to an owner.
+// Categories of other owners are reported as not found.
+func verifyCategoryOwner(
+	ctx context.Context,
+	categoryRepo repositories.CategoryRepository,
+	ownerID *uuid.UUID,
+	categoryID *uuid.UUID,
+) error {
+	if categoryID == nil {
+		return nil
+	}
+	category, err := categoryRepo.GetByID(ctx, *categoryID)
+	if err != nil {
+		return err
+	}
+	if !sameOwner(category.OwnerID, ownerID) {
+		return entities.ErrCategoryNotFound
+	}
+	return nil
+}
+
+// sameOwner reports whether two optional owners are the same
+func sameOwner(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
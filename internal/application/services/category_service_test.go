@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+func TestNewCategoryService(t *testing.T) {
+	categoryRepo := &MockCategoryRepository{}
+
+	service := NewCategoryService(categoryRepo)
+
+	assert.NotNil(t, service)
+	assert.Equal(t, categoryRepo, service.categoryRepo)
+}
+
+func TestCategoryService_CreateCategory(t *testing.T) {
+	tests := []struct {
+		name          string
+		categoryName  string
+		setupMocks    func(*MockCategoryRepository)
+		expectedError error
+	}{
+		{
+			name:         "successful creation",
+			categoryName: "  Produce ",
+			setupMocks: func(repo *MockCategoryRepository) {
+				repo.On("GetByName", mock.Anything, mock.Anything, "Produce").Return((*entities.Category)(nil), entities.ErrCategoryNotFound)
+				repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:          "empty name should fail",
+			categoryName:  "   ",
+			setupMocks:    func(repo *MockCategoryRepository) {},
+			expectedError: entities.ErrInvalidInput,
+		},
+		{
+			name:         "duplicate name should fail",
+			categoryName: "produce",
+			setupMocks: func(repo *MockCategoryRepository) {
+				repo.On("GetByName", mock.Anything, mock.Anything, "produce").Return(&entities.Category{ID: uuid.New(), Name: "Produce"}, nil)
+			},
+			expectedError: entities.ErrDuplicateCategory,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockCategoryRepository{}
+			service := NewCategoryService(repo)
+			tt.setupMocks(repo)
+
+			result, err := service.CreateCategory(context.Background(), tt.categoryName, 3)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "Produce", result.Name)
+				assert.Equal(t, 3, result.Position)
+			}
+
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCategoryService_UpdateCategory(t *testing.T) {
+	categoryID := uuid.New()
+
+	tests := []struct {
+		name          string
+		categoryName  string
+		setupMocks    func(*MockCategoryRepository)
+		expectedError error
+	}{
+		{
+			name:         "renames category keeping its own name available",
+			categoryName: "Fruit & Vegetables",
+			setupMocks: func(repo *MockCategoryRepository) {
+				repo.On("GetByID", mock.Anything, categoryID).Return(&entities.Category{ID: categoryID, Name: "Produce"}, nil)
+				repo.On("GetByName", mock.Anything, mock.Anything, "Fruit & Vegetables").Return(&entities.Category{ID: categoryID}, nil)
+				repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:         "category not found",
+			categoryName: "Produce",
+			setupMocks: func(repo *MockCategoryRepository) {
+				repo.On("GetByID", mock.Anything, categoryID).Return((*entities.Category)(nil), entities.ErrCategoryNotFound)
+			},
+			expectedError: entities.ErrCategoryNotFound,
+		},
+		{
+			name:         "name used by another category",
+			categoryName: "Dairy",
+			setupMocks: func(repo *MockCategoryRepository) {
+				repo.On("GetByID", mock.Anything, categoryID).Return(&entities.Category{ID: categoryID, Name: "Produce"}, nil)
+				repo.On("GetByName", mock.Anything, mock.Anything, "Dairy").Return(&entities.Category{ID: uuid.New(), Name: "Dairy"}, nil)
+			},
+			expectedError: entities.ErrDuplicateCategory,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockCategoryRepository{}
+			service := NewCategoryService(repo)
+			tt.setupMocks(repo)
+
+			result, err := service.UpdateCategory(context.Background(), categoryID, tt.categoryName, 5)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.categoryName, result.Name)
+				assert.Equal(t, 5, result.Position)
+			}
+
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCategoryService_DeleteCategory(t *testing.T) {
+	repo := &MockCategoryRepository{}
+	service := NewCategoryService(repo)
+
+	categoryID := uuid.New()
+	repo.On("GetByID", mock.Anything, categoryID).Return(&entities.Category{ID: categoryID}, nil)
+	repo.On("Delete", mock.Anything, categoryID).Return(nil)
+
+	err := service.DeleteCategory(context.Background(), categoryID)
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestCategoryService_ScopedToCaller(t *testing.T) {
+	ana, bob := uuid.New(), uuid.New()
+	ctx := WithCaller(context.Background(), ana)
+	bobs := &entities.Category{ID: uuid.New(), Name: "Produce", OwnerID: &bob}
+
+	t.Run("creates categories owned by the caller", func(t *testing.T) {
+		repo := &MockCategoryRepository{}
+		service := NewCategoryService(repo)
+		repo.On("GetByName", mock.Anything, &ana, "Produce").Return((*entities.Category)(nil), entities.ErrCategoryNotFound)
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Category")).Return(nil)
+
+		category, err := service.CreateCategory(ctx, "Produce", 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, &ana, category.OwnerID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("hides the categories of other users", func(t *testing.T) {
+		repo := &MockCategoryRepository{}
+		service := NewCategoryService(repo)
+		repo.On("GetByID", mock.Anything, bobs.ID).Return(bobs, nil)
+
+		_, err := service.GetCategory(ctx, bobs.ID)
+		assert.Equal(t, entities.ErrCategoryNotFound, err)
+		_, err = service.UpdateCategory(ctx, bobs.ID, "Fruit", 1)
+		assert.Equal(t, entities.ErrCategoryNotFound, err)
+		assert.Equal(t, entities.ErrCategoryNotFound, service.DeleteCategory(ctx, bobs.ID))
+
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,6 @@
+package services
+
+import "time"
+
+// Clock tells the current time; tests pass a fixed clock to control time-dependent behavior
+type Clock func() time.Time
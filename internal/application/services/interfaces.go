@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
@@ -9,23 +10,140 @@ import (
 
 // ShoppingListServiceInterface defines the interface for shopping list service
 type ShoppingListServiceInterface interface {
-	CreateShoppingList(ctx context.Context, name, description string) (*entities.ShoppingList, error)
+	CreateShoppingList(ctx context.Context, input ShoppingListInput) (*entities.ShoppingList, error)
 	GetShoppingList(ctx context.Context, id uuid.UUID) (*entities.ShoppingList, error)
-	GetAllShoppingLists(ctx context.Context) ([]*entities.ShoppingList, error)
-	UpdateShoppingList(ctx context.Context, id uuid.UUID, name, description string) (*entities.ShoppingList, error)
+	GetShoppingListGroupedByCategory(ctx context.Context, id uuid.UUID) (*entities.ShoppingList, []entities.ItemGroup, error)
+	GetAllShoppingLists(ctx context.Context, input ListShoppingListsInput) (*ShoppingListPage, error)
+	UpdateShoppingList(ctx context.Context, id uuid.UUID, input ShoppingListInput) (*entities.ShoppingList, error)
+	PatchShoppingList(ctx context.Context, id uuid.UUID, patch ShoppingListPatch) (*entities.ShoppingList, error)
 	DeleteShoppingList(ctx context.Context, id uuid.UUID) error
+	CloneShoppingList(ctx context.Context, id uuid.UUID, input CloneShoppingListInput) (*entities.ShoppingList, error)
+	ClearCompleted(ctx context.Context, id uuid.UUID) (int64, error)
+	CompleteAll(ctx context.Context, id uuid.UUID) (int64, error)
+	ResetShoppingList(ctx context.Context, id uuid.UUID) (int64, error)
 }
 
 // ItemServiceInterface defines the interface for item service
 type ItemServiceInterface interface {
-	CreateItem(ctx context.Context, shoppingListID uuid.UUID, name string, quantity int) (*entities.Item, error)
+	CreateItem(ctx context.Context, shoppingListID uuid.UUID, input CreateItemInput) (*entities.Item, bool, error)
 	GetItem(ctx context.Context, id uuid.UUID) (*entities.Item, error)
 	GetItemsByShoppingListID(ctx context.Context, shoppingListID uuid.UUID) ([]*entities.Item, error)
-	UpdateItem(ctx context.Context, id uuid.UUID, name string, quantity int, completed bool) (*entities.Item, error)
+	UpdateItem(ctx context.Context, id uuid.UUID, input UpdateItemInput) (*entities.Item, error)
+	PatchItem(ctx context.Context, id uuid.UUID, patch ItemPatch) (*entities.Item, error)
 	DeleteItem(ctx context.Context, id uuid.UUID) error
-	ToggleItemCompletion(ctx context.Context, id uuid.UUID) (*entities.Item, error)
+	ToggleItemCompletion(ctx context.Context, id uuid.UUID, version *int, addToPantry bool) (*entities.Item, error)
+	ReorderItems(ctx context.Context, shoppingListID uuid.UUID, itemIDs []uuid.UUID) ([]*entities.Item, error)
+	BatchItems(ctx context.Context, shoppingListID uuid.UUID, mode BatchMode, operations []ItemOperation) (*ItemBatchResult, error)
+}
+
+// CategoryServiceInterface defines the interface for category service
+type CategoryServiceInterface interface {
+	CreateCategory(ctx context.Context, name string, position int) (*entities.Category, error)
+	GetCategory(ctx context.Context, id uuid.UUID) (*entities.Category, error)
+	GetAllCategories(ctx context.Context) ([]*entities.Category, error)
+	UpdateCategory(ctx context.Context, id uuid.UUID, name string, position int) (*entities.Category, error)
+	DeleteCategory(ctx context.Context, id uuid.UUID) error
+}
+
+// TemplateServiceInterface defines the interface for template service
+type TemplateServiceInterface interface {
+	CreateTemplate(ctx context.Context, input TemplateInput) (*entities.Template, error)
+	GetTemplate(ctx context.Context, id uuid.UUID) (*entities.Template, error)
+	GetAllTemplates(ctx context.Context) ([]*entities.Template, error)
+	UpdateTemplate(ctx context.Context, id uuid.UUID, input TemplateInput) (*entities.Template, error)
+	DeleteTemplate(ctx context.Context, id uuid.UUID) error
+	InstantiateTemplate(ctx context.Context, id uuid.UUID, name string) (*entities.ShoppingList, error)
+	MaterializeDueTemplates(ctx context.Context) (int, error)
+}
+
+// SearchServiceInterface defines the interface for search service
+type SearchServiceInterface interface {
+	Search(ctx context.Context, text string, limit int) ([]entities.SearchHit, error)
+}
+
+// ItemImageServiceInterface defines the interface for item image service
+type ItemImageServiceInterface interface {
+	UploadImage(ctx context.Context, itemID uuid.UUID, data []byte) (*entities.ItemImage, error)
+	GetImage(ctx context.Context, itemID uuid.UUID, thumbnail bool) (*entities.ItemImage, []byte, error)
+	DeleteImage(ctx context.Context, itemID uuid.UUID) error
+}
+
+// PantryServiceInterface defines the interface for pantry service
+type PantryServiceInterface interface {
+	CreatePantryItem(ctx context.Context, input PantryItemInput) (*entities.PantryItem, error)
+	GetPantryItem(ctx context.Context, id uuid.UUID) (*entities.PantryItem, error)
+	GetPantryItems(ctx context.Context, input ListPantryItemsInput) ([]*entities.PantryItem, error)
+	UpdatePantryItem(ctx context.Context, id uuid.UUID, input PantryItemInput) (*entities.PantryItem, error)
+	DeletePantryItem(ctx context.Context, id uuid.UUID) error
+}
+
+// ReplenishmentServiceInterface defines the interface for replenishment service
+type ReplenishmentServiceInterface interface {
+	CreateStaple(ctx context.Context, input StapleInput) (*entities.Staple, error)
+	GetStaple(ctx context.Context, id uuid.UUID) (*entities.Staple, error)
+	GetAllStaples(ctx context.Context) ([]*entities.Staple, error)
+	GetLowStaples(ctx context.Context) ([]*entities.Staple, error)
+	UpdateStaple(ctx context.Context, id uuid.UUID, input StapleInput) (*entities.Staple, error)
+	DeleteStaple(ctx context.Context, id uuid.UUID) error
+}
+
+// RecipeServiceInterface defines the interface for recipe service
+type RecipeServiceInterface interface {
+	ImportRecipe(ctx context.Context, shoppingListID uuid.UUID, input ImportRecipeInput) (*RecipeImport, error)
+}
+
+// AuthServiceInterface defines the interface for auth service
+type AuthServiceInterface interface {
+	Register(ctx context.Context, email, password string) (*entities.User, error)
+	Login(ctx context.Context, email, password string) (*TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string) (*TokenPair, error)
+	Authenticate(ctx context.Context, accessToken string) (uuid.UUID, error)
+}
+
+// APIKeyServiceInterface defines the interface for API key service
+type APIKeyServiceInterface interface {
+	CreateAPIKey(ctx context.Context, input CreateAPIKeyInput) (*entities.APIKey, string, error)
+	GetAPIKeys(ctx context.Context) ([]*entities.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id uuid.UUID) error
+	Authenticate(ctx context.Context, key string) (*entities.APIKey, error)
+}
+
+// ListMemberServiceInterface defines the interface for list member service
+type ListMemberServiceInterface interface {
+	GetMembers(ctx context.Context, shoppingListID uuid.UUID) ([]*entities.ListMember, error)
+	AddMember(ctx context.Context, shoppingListID, userID uuid.UUID, role entities.ListRole) (*entities.ListMember, error)
+	UpdateMember(ctx context.Context, shoppingListID, userID uuid.UUID, role entities.ListRole) (*entities.ListMember, error)
+	RemoveMember(ctx context.Context, shoppingListID, userID uuid.UUID) error
+}
+
+// InvitationServiceInterface defines the interface for invitation service
+type InvitationServiceInterface interface {
+	CreateInvitation(ctx context.Context, shoppingListID uuid.UUID, input CreateInvitationInput) (*entities.ListInvitation, string, error)
+	GetInvitations(ctx context.Context, shoppingListID uuid.UUID) ([]*entities.ListInvitation, error)
+	RevokeInvitation(ctx context.Context, shoppingListID, id uuid.UUID) error
+	AcceptInvitation(ctx context.Context, token string) (*entities.ListMember, error)
+}
+
+// TrashServiceInterface defines the interface for trash service
+type TrashServiceInterface interface {
+	GetTrash(ctx context.Context) (*entities.Trash, error)
+	RestoreShoppingList(ctx context.Context, id uuid.UUID) (*entities.ShoppingList, error)
+	RestoreItem(ctx context.Context, id uuid.UUID) (*entities.Item, error)
+	PurgeTrash(ctx context.Context, retention time.Duration) (lists, items int64, err error)
 }
 
 // Ensure that the concrete services implement the interfaces
 var _ ShoppingListServiceInterface = (*ShoppingListService)(nil)
 var _ ItemServiceInterface = (*ItemService)(nil)
+var _ CategoryServiceInterface = (*CategoryService)(nil)
+var _ TemplateServiceInterface = (*TemplateService)(nil)
+var _ SearchServiceInterface = (*SearchService)(nil)
+var _ ItemImageServiceInterface = (*ItemImageService)(nil)
+var _ PantryServiceInterface = (*PantryService)(nil)
+var _ ReplenishmentServiceInterface = (*ReplenishmentService)(nil)
+var _ RecipeServiceInterface = (*RecipeService)(nil)
+var _ TrashServiceInterface = (*TrashService)(nil)
+var _ AuthServiceInterface = (*AuthService)(nil)
+var _ APIKeyServiceInterface = (*APIKeyService)(nil)
+var _ ListMemberServiceInterface = (*ListMemberService)(nil)
+var _ InvitationServiceInterface = (*InvitationService)(nil)
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+)
+
+// DefaultInvitationTTL is how long an invitation created without an expiry can be accepted
+const DefaultInvitationTTL = 7 * 24 * time.Hour
+
+// CreateInvitationInput holds the attributes of a new invitation.
+// An empty role defaults to viewer and a nil ExpiresAt to DefaultInvitationTTL from now.
+// A nil MaxUses allows any number of uses, while a MaxUses of 1 makes the invitation single-use.
+type CreateInvitationInput struct {
+	Role      entities.ListRole
+	ExpiresAt *time.Time
+	MaxUses   *int
+}
+
+// InvitationService handles the invitation links users join shared shopping lists with.
+// Only owners of a list may create, list and revoke its invitations.
+type InvitationService struct {
+	shoppingListRepo repositories.ShoppingListRepository
+	memberRepo       repositories.ListMemberRepository
+	invitationRepo   repositories.ListInvitationRepository
+	transactor       repositories.Transactor
+	clock            Clock
+}
+
+// NewInvitationService creates a new invitation service
+func NewInvitationService(
+	shoppingListRepo repositories.ShoppingListRepository,
+	memberRepo repositories.ListMemberRepository,
+	invitationRepo repositories.ListInvitationRepository,
+	transactor repositories.Transactor,
+	clock Clock,
+) *InvitationService {
+	return &InvitationService{
+		shoppingListRepo: shoppingListRepo,
+		memberRepo:       memberRepo,
+		invitationRepo:   invitationRepo,
+		transactor:       transactor,
+		clock:            clock,
+	}
+}
+
+// CreateInvitation creates an invitation to a shopping list and returns it along with its token,
+// which is only available now
+func (s *InvitationService) CreateInvitation(
+	ctx context.Context,
+	shoppingListID uuid.UUID,
+	input CreateInvitationInput,
+) (*entities.ListInvitation, string, error) {
+	role := input.Role
+	if role == "" {
+		role = entities.ListRoleViewer
+	}
+	if !role.IsValid() || (input.MaxUses != nil && *input.MaxUses < 1) {
+		return nil, "", entities.ErrInvalidInput
+	}
+
+	now := s.clock()
+	expiresAt := now.Add(DefaultInvitationTTL)
+	if input.ExpiresAt != nil {
+		if !input.ExpiresAt.After(now) {
+			return nil, "", entities.ErrInvalidInput
+		}
+		expiresAt = *input.ExpiresAt
+	}
+
+	if _, err := getListWithRole(ctx, s.shoppingListRepo, shoppingListID, entities.ListRoleOwner); err != nil {
+		return nil, "", err
+	}
+
+	invitation, token, err := entities.NewListInvitation(shoppingListID, role, expiresAt, input.MaxUses)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.invitationRepo.Create(ctx, invitation); err != nil {
+		return nil, "", err
+	}
+
+	return invitation, token, nil
+}
+
+// GetInvitations retrieves the invitations to a shopping list that can still be accepted, newest first
+func (s *InvitationService) GetInvitations(ctx context.Context, shoppingListID uuid.UUID) ([]*entities.ListInvitation, error) {
+	if _, err := getListWithRole(ctx, s.shoppingListRepo, shoppingListID, entities.ListRoleOwner); err != nil {
+		return nil, err
+	}
+	return s.invitationRepo.GetOutstanding(ctx, shoppingListID, s.clock())
+}
+
+// RevokeInvitation deletes an invitation to a shopping list, so that it can no longer be accepted
+func (s *InvitationService) RevokeInvitation(ctx context.Context, shoppingListID, id uuid.UUID) error {
+	if _, err := getListWithRole(ctx, s.shoppingListRepo, shoppingListID, entities.ListRoleOwner); err != nil {
+		return err
+	}
+
+	invitation, err := s.invitationRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if invitation.ShoppingListID != shoppingListID {
+		return entities.ErrInvitationNotFound
+	}
+
+	return s.invitationRepo.Delete(ctx, id)
+}
+
+// AcceptInvitation makes the caller a member of the shopping list an invitation is for, in the
+// role of the invitation. Users who already hold a role in the list cannot accept invitations to it,
+// so that an invitation never lowers a role; their attempt does not count as a use.
+func (s *InvitationService) AcceptInvitation(ctx context.Context, token string) (*entities.ListMember, error) {
+	userID, ok := CallerFromContext(ctx)
+	if !ok {
+		return nil, entities.ErrUnauthenticated
+	}
+
+	invitation, err := s.invitationRepo.GetByHash(ctx, entities.HashInvitationToken(token))
+	if err != nil {
+		return nil, err
+	}
+	now := s.clock()
+	if !invitation.IsUsable(now) {
+		return nil, entities.ErrInvitationExpired
+	}
+
+	list, err := s.shoppingListRepo.GetByID(ctx, invitation.ShoppingListID)
+	if err != nil {
+		return nil, err
+	}
+	if _, isMember := list.RoleOf(userID); isMember {
+		return nil, entities.ErrDuplicateMember
+	}
+
+	member := entities.NewListMember(list.ID, userID, invitation.Role)
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.invitationRepo.Redeem(ctx, invitation.ID, now); err != nil {
+			return err
+		}
+		return s.memberRepo.Create(ctx, member)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// MockListInvitationRepository is a mock implementation of ListInvitationRepository
+type MockListInvitationRepository struct {
+	mock.Mock
+}
+
+func (m *MockListInvitationRepository) Create(ctx context.Context, invitation *entities.ListInvitation) error {
+	args := m.Called(ctx, invitation)
+	return args.Error(0)
+}
+
+func (m *MockListInvitationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.ListInvitation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ListInvitation), args.Error(1)
+}
+
+func (m *MockListInvitationRepository) GetByHash(ctx context.Context, tokenHash string) (*entities.ListInvitation, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ListInvitation), args.Error(1)
+}
+
+func (m *MockListInvitationRepository) GetOutstanding(
+	ctx context.Context,
+	shoppingListID uuid.UUID,
+	now time.Time,
+) ([]*entities.ListInvitation, error) {
+	args := m.Called(ctx, shoppingListID, now)
+	return args.Get(0).([]*entities.ListInvitation), args.Error(1)
+}
+
+func (m *MockListInvitationRepository) Redeem(ctx context.Context, id uuid.UUID, now time.Time) error {
+	args := m.Called(ctx, id, now)
+	return args.Error(0)
+}
+
+func (m *MockListInvitationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+var invitationNow = time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+
+// newTestInvitationService creates an invitation service for a shared list and a fixed clock
+func newTestInvitationService(f sharedListFixture) (*InvitationService, *MockListInvitationRepository, *MockListMemberRepository) {
+	shoppingListRepo := &MockShoppingListRepository{}
+	shoppingListRepo.On("GetByID", mock.Anything, f.list.ID).Return(f.list, nil)
+	invitationRepo := &MockListInvitationRepository{}
+	memberRepo := &MockListMemberRepository{}
+	service := NewInvitationService(shoppingListRepo, memberRepo, invitationRepo, passThroughTransactor{}, func() time.Time {
+		return invitationNow
+	})
+	return service, invitationRepo, memberRepo
+}
+
+func TestInvitationService_CreateInvitation(t *testing.T) {
+	f := newSharedListFixture()
+	past := invitationNow.Add(-time.Hour)
+	zero := 0
+
+	tests := []struct {
+		name          string
+		caller        uuid.UUID
+		input         CreateInvitationInput
+		expectedError error
+	}{
+		{name: "owner creates an invitation", caller: f.owner, input: CreateInvitationInput{}},
+		{name: "editors cannot invite", caller: f.editor, input: CreateInvitationInput{}, expectedError: entities.ErrForbidden},
+		{
+			name:          "rejects unknown roles",
+			caller:        f.owner,
+			input:         CreateInvitationInput{Role: "admin"},
+			expectedError: entities.ErrInvalidInput,
+		},
+		{
+			name:          "rejects past expiry",
+			caller:        f.owner,
+			input:         CreateInvitationInput{ExpiresAt: &past},
+			expectedError: entities.ErrInvalidInput,
+		},
+		{
+			name:          "rejects zero max uses",
+			caller:        f.owner,
+			input:         CreateInvitationInput{MaxUses: &zero},
+			expectedError: entities.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, invitationRepo, _ := newTestInvitationService(f)
+			invitationRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.ListInvitation")).Return(nil)
+
+			invitation, token, err := service.CreateInvitation(WithCaller(context.Background(), tt.caller), f.list.ID, tt.input)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				invitationRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, token)
+			assert.Equal(t, entities.ListRoleViewer, invitation.Role)
+			assert.Equal(t, invitationNow.Add(DefaultInvitationTTL), invitation.ExpiresAt)
+			assert.Nil(t, invitation.MaxUses)
+		})
+	}
+}
+
+func TestInvitationService_AcceptInvitation(t *testing.T) {
+	f := newSharedListFixture()
+	newcomer := uuid.New()
+	once := 1
+
+	usable := func() *entities.ListInvitation {
+		return &entities.ListInvitation{
+			ID:             uuid.New(),
+			ShoppingListID: f.list.ID,
+			Role:           entities.ListRoleEditor,
+			MaxUses:        &once,
+			ExpiresAt:      invitationNow.Add(time.Hour),
+		}
+	}
+
+	t.Run("joins the list", func(t *testing.T) {
+		service, invitationRepo, memberRepo := newTestInvitationService(f)
+		invitation := usable()
+		invitationRepo.On("GetByHash", mock.Anything, entities.HashInvitationToken("token")).Return(invitation, nil)
+		invitationRepo.On("Redeem", mock.Anything, invitation.ID, invitationNow).Return(nil)
+		memberRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.ListMember")).Return(nil)
+
+		member, err := service.AcceptInvitation(WithCaller(context.Background(), newcomer), "token")
+
+		require.NoError(t, err)
+		assert.Equal(t, newcomer, member.UserID)
+		assert.Equal(t, f.list.ID, member.ShoppingListID)
+		assert.Equal(t, entities.ListRoleEditor, member.Role)
+		invitationRepo.AssertExpectations(t)
+		memberRepo.AssertExpectations(t)
+	})
+
+	t.Run("fails when the invitation is used up", func(t *testing.T) {
+		service, invitationRepo, memberRepo := newTestInvitationService(f)
+		invitation := usable()
+		invitation.Uses = 1
+		invitationRepo.On("GetByHash", mock.Anything, mock.Anything).Return(invitation, nil)
+
+		_, err := service.AcceptInvitation(WithCaller(context.Background(), newcomer), "token")
+
+		assert.Equal(t, entities.ErrInvitationExpired, err)
+		memberRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails when another acceptance used it up first", func(t *testing.T) {
+		service, invitationRepo, memberRepo := newTestInvitationService(f)
+		invitation := usable()
+		invitationRepo.On("GetByHash", mock.Anything, mock.Anything).Return(invitation, nil)
+		invitationRepo.On("Redeem", mock.Anything, invitation.ID, invitationNow).Return(entities.ErrInvitationExpired)
+
+		_, err := service.AcceptInvitation(WithCaller(context.Background(), newcomer), "token")
+
+		assert.Equal(t, entities.ErrInvitationExpired, err)
+		memberRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails for existing members without using it", func(t *testing.T) {
+		service, invitationRepo, _ := newTestInvitationService(f)
+		invitationRepo.On("GetByHash", mock.Anything, mock.Anything).Return(usable(), nil)
+
+		_, err := service.AcceptInvitation(WithCaller(context.Background(), f.viewer), "token")
+
+		assert.Equal(t, entities.ErrDuplicateMember, err)
+		invitationRepo.AssertNotCalled(t, "Redeem", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails for unknown tokens", func(t *testing.T) {
+		service, invitationRepo, _ := newTestInvitationService(f)
+		invitationRepo.On("GetByHash", mock.Anything, mock.Anything).Return(nil, entities.ErrInvitationNotFound)
+
+		_, err := service.AcceptInvitation(WithCaller(context.Background(), newcomer), "unknown")
+
+		assert.Equal(t, entities.ErrInvitationNotFound, err)
+	})
+
+	t.Run("requires a caller", func(t *testing.T) {
+		service, _, _ := newTestInvitationService(f)
+
+		_, err := service.AcceptInvitation(context.Background(), "token")
+
+		assert.Equal(t, entities.ErrUnauthenticated, err)
+	})
+}
+
+func TestInvitationService_GetAndRevokeInvitations(t *testing.T) {
+	f := newSharedListFixture()
+	service, invitationRepo, _ := newTestInvitationService(f)
+	invitation := &entities.ListInvitation{ID: uuid.New(), ShoppingListID: f.list.ID, Role: entities.ListRoleViewer}
+	foreign := &entities.ListInvitation{ID: uuid.New(), ShoppingListID: uuid.New(), Role: entities.ListRoleViewer}
+	invitationRepo.On("GetOutstanding", mock.Anything, f.list.ID, invitationNow).Return([]*entities.ListInvitation{invitation}, nil)
+	invitationRepo.On("GetByID", mock.Anything, invitation.ID).Return(invitation, nil)
+	invitationRepo.On("GetByID", mock.Anything, foreign.ID).Return(foreign, nil)
+	invitationRepo.On("Delete", mock.Anything, invitation.ID).Return(nil)
+	ownerCtx := WithCaller(context.Background(), f.owner)
+
+	invitations, err := service.GetInvitations(ownerCtx, f.list.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []*entities.ListInvitation{invitation}, invitations)
+
+	_, err = service.GetInvitations(WithCaller(context.Background(), f.viewer), f.list.ID)
+	assert.Equal(t, entities.ErrForbidden, err)
+
+	err = service.RevokeInvitation(WithCaller(context.Background(), f.editor), f.list.ID, invitation.ID)
+	assert.Equal(t, entities.ErrForbidden, err)
+
+	err = service.RevokeInvitation(ownerCtx, f.list.ID, foreign.ID)
+	assert.Equal(t, entities.ErrInvitationNotFound, err)
+	invitationRepo.AssertNotCalled(t, "Delete", mock.Anything, foreign.ID)
+
+	err = service.RevokeInvitation(ownerCtx, f.list.ID, invitation.ID)
+	assert.NoError(t, err)
+}
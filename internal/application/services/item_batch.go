@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+)
+
+// MaxBatchOperations is the largest number of operations a single item batch may hold
+const MaxBatchOperations = 100
+
+// ItemOperationType names an operation of an item batch
+type ItemOperationType string
+
+// Supported item operations
+const (
+	ItemOpCreate   ItemOperationType = "create"
+	ItemOpUpdate   ItemOperationType = "update"
+	ItemOpComplete ItemOperationType = "complete"
+	ItemOpDelete   ItemOperationType = "delete"
+)
+
+// BatchMode controls what happens to an item batch when some of its operations fail
+type BatchMode string
+
+// Supported batch modes
+const (
+	BatchAtomic     BatchMode = "atomic"
+	BatchBestEffort BatchMode = "best_effort"
+)
+
+// IsValid reports whether the mode is supported; the empty mode means atomic
+func (m BatchMode) IsValid() bool {
+	switch m {
+	case "", BatchAtomic, BatchBestEffort:
+		return true
+	}
+	return false
+}
+
+// ItemOperation is one operation of an item batch.
+// Create is used by create operations and Update by update operations; ItemID
+// names the item that update, complete and delete operations apply to.
+// Version, when set, must match the current version of that item.
+type ItemOperation struct {
+	Type    ItemOperationType
+	ItemID  uuid.UUID
+	Version *int
+	Create  CreateItemInput
+	Update  UpdateItemInput
+}
+
+// ItemOperationResult is the outcome of one operation of an item batch.
+// Created reports whether a create operation added an item rather than merging it.
+type ItemOperationResult struct {
+	Item    *entities.Item
+	Created bool
+	Err     error
+}
+
+// ItemBatchResult holds the outcome of every operation of an item batch, in order.
+// An atomic batch with a failed operation is not committed, and its other
+// operations fail with ErrOperationAborted.
+type ItemBatchResult struct {
+	Results   []ItemOperationResult
+	Committed bool
+}
+
+// itemBatchPlan tracks the state of a shopping list's items as the operations of a batch are planned
+type itemBatchPlan struct {
+	list  *entities.ShoppingList
+	live  []*entities.Item
+	batch repositories.ItemBatch
+}
+
+// BatchItems applies many item operations to a shopping list at once.
+// Operations are checked in order against the outcome of the ones before them, while
+// versions always refer to the stored items. In atomic mode a failed operation
+// aborts the whole batch; in best-effort mode the operations that succeed are stored.
+// Either way the stored changes are written in one transaction.
+func (s *ItemService) BatchItems(
+	ctx context.Context,
+	shoppingListID uuid.UUID,
+	mode BatchMode,
+	operations []ItemOperation,
+) (*ItemBatchResult, error) {
+	if len(operations) == 0 || len(operations) > MaxBatchOperations || !mode.IsValid() {
+		return nil, entities.ErrInvalidInput
+	}
+
+	list, err := getEditableList(ctx, s.shoppingListRepo, shoppingListID)
+	if err != nil {
+		if err == entities.ErrForbidden {
+			return nil, err
+		}
+		return nil, entities.ErrShoppingListNotFound
+	}
+
+	items, err := s.itemRepo.GetByShoppingListID(ctx, shoppingListID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &itemBatchPlan{list: list, live: items}
+	result := &ItemBatchResult{Results: make([]ItemOperationResult, len(operations))}
+	failed := false
+	for i, operation := range operations {
+		result.Results[i] = s.planOperation(ctx, plan, operation)
+		failed = failed || result.Results[i].Err != nil
+	}
+
+	if failed && mode != BatchBestEffort {
+		for i := range result.Results {
+			if result.Results[i].Err == nil {
+				result.Results[i] = ItemOperationResult{Err: entities.ErrOperationAborted}
+			}
+		}
+		return result, nil
+	}
+
+	if err := s.itemRepo.ApplyBatch(ctx, plan.batch); err != nil {
+		return nil, err
+	}
+
+	result.Committed = true
+	return result, nil
+}
+
+// planOperation checks an operation against the planned state of the items and records its changes
+func (s *ItemService) planOperation(ctx context.Context, plan *itemBatchPlan, operation ItemOperation) ItemOperationResult {
+	if operation.Type == ItemOpCreate {
+		return s.planCreate(ctx, plan, operation.Create)
+	}
+
+	item := plan.find(operation.ItemID)
+	if item == nil {
+		return ItemOperationResult{Err: entities.ErrItemNotFound}
+	}
+	if err := checkVersion(operation.Version, item.Version); err != nil {
+		return ItemOperationResult{Err: err}
+	}
+
+	switch operation.Type {
+	case ItemOpUpdate:
+		if operation.Update.Name == "" {
+			return ItemOperationResult{Err: entities.ErrInvalidInput}
+		}
+		// Validate a copy so a failed update leaves the planned item as it was
+		updated := *item
+		if err := s.prepareUpdate(ctx, &updated, operation.Update); err != nil {
+			return ItemOperationResult{Err: err}
+		}
+		*item = updated
+	case ItemOpComplete:
+		item.MarkCompleted()
+	case ItemOpDelete:
+		plan.remove(item)
+		return ItemOperationResult{}
+	default:
+		return ItemOperationResult{Err: entities.ErrInvalidInput}
+	}
+
+	plan.changed(item)
+	return ItemOperationResult{Item: item}
+}
+
+// planCreate plans a new item, merging it into an open duplicate when the input asks to
+func (s *ItemService) planCreate(ctx context.Context, plan *itemBatchPlan, input CreateItemInput) ItemOperationResult {
+	unit, err := checkCreateInput(input)
+	if err != nil {
+		return ItemOperationResult{Err: err}
+	}
+
+	item, err := s.buildItem(ctx, plan.list, input, unit)
+	if err != nil {
+		return ItemOperationResult{Err: err}
+	}
+	item.ShoppingListID = plan.list.ID
+
+	if !input.OnDuplicate.allows() {
+		if existing := plan.openDuplicate(input.Name); existing != nil {
+			if input.OnDuplicate != DuplicateMerge {
+				return ItemOperationResult{Err: entities.ErrDuplicateItem}
+			}
+			if err := mergeQuantity(existing, input.Quantity, unit); err != nil {
+				return ItemOperationResult{Err: err}
+			}
+			plan.changed(existing)
+			return ItemOperationResult{Item: existing}
+		}
+	}
+
+	plan.live = append(plan.live, item)
+	plan.batch.Create = append(plan.batch.Create, item)
+	return ItemOperationResult{Item: item, Created: true}
+}
+
+// find returns the planned item with the given ID, if any
+func (p *itemBatchPlan) find(id uuid.UUID) *entities.Item {
+	for _, item := range p.live {
+		if item.ID == id {
+			return item
+		}
+	}
+	return nil
+}
+
+// openDuplicate returns the planned open item whose name matches, if any
+func (p *itemBatchPlan) openDuplicate(name string) *entities.Item {
+	for _, item := range p.live {
+		if item.IsDuplicateOf(name) {
+			return item
+		}
+	}
+	return nil
+}
+
+// changed records a change to a planned item; new items are simply created in their final state
+func (p *itemBatchPlan) changed(item *entities.Item) {
+	if indexOf(p.batch.Create, item) < 0 && indexOf(p.batch.Update, item) < 0 {
+		p.batch.Update = append(p.batch.Update, item)
+	}
+}
+
+// remove records the deletion of a planned item
+func (p *itemBatchPlan) remove(item *entities.Item) {
+	p.live = removeItem(p.live, item)
+	if indexOf(p.batch.Create, item) >= 0 {
+		p.batch.Create = removeItem(p.batch.Create, item)
+		return
+	}
+	p.batch.Update = removeItem(p.batch.Update, item)
+	p.batch.Delete = append(p.batch.Delete, item.ID)
+}
+
+// indexOf returns the index of an item in a slice, or -1
+func indexOf(items []*entities.Item, item *entities.Item) int {
+	for i, candidate := range items {
+		if candidate == item {
+			return i
+		}
+	}
+	return -1
+}
+
+// removeItem returns the slice without the given item
+func removeItem(items []*entities.Item, item *entities.Item) []*entities.Item {
+	if i := indexOf(items, item); i >= 0 {
+		return append(items[:i], items[i+1:]...)
+	}
+	return items
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+)
+
+func setupBatchTest(items ...*entities.Item) (*ItemService, *MockItemRepository, *entities.ShoppingList) {
+	itemRepo := &MockItemRepository{}
+	listRepo := &MockShoppingListRepository{}
+	service := NewItemService(itemRepo, listRepo, &MockCategoryRepository{}, &MockPantryRepository{}, passThroughTransactor{})
+
+	list := &entities.ShoppingList{ID: uuid.New(), Name: "Groceries"}
+	for _, item := range items {
+		item.ShoppingListID = list.ID
+	}
+	listRepo.On("GetByID", mock.Anything, list.ID).Return(list, nil)
+	itemRepo.On("GetByShoppingListID", mock.Anything, list.ID).Return(items, nil)
+	return service, itemRepo, list
+}
+
+func TestItemService_BatchItems(t *testing.T) {
+	milk := &entities.Item{ID: uuid.New(), Name: "Milk", Quantity: 1, Unit: entities.UnitPieces, Version: 2}
+	bread := &entities.Item{ID: uuid.New(), Name: "Bread", Quantity: 1, Unit: entities.UnitPieces, Version: 1}
+	eggs := &entities.Item{ID: uuid.New(), Name: "Eggs", Quantity: 6, Unit: entities.UnitPieces, Version: 1}
+	service, itemRepo, list := setupBatchTest(milk, bread, eggs)
+
+	version := 2
+	var batch repositories.ItemBatch
+	itemRepo.On("ApplyBatch", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { batch = args.Get(1).(repositories.ItemBatch) }).
+		Return(nil)
+
+	result, err := service.BatchItems(context.Background(), list.ID, BatchAtomic, []ItemOperation{
+		{Type: ItemOpCreate, Create: CreateItemInput{Name: "Butter", Quantity: 1}},
+		{Type: ItemOpCreate, Create: CreateItemInput{Name: "milk", Quantity: 2, OnDuplicate: DuplicateMerge}},
+		{Type: ItemOpCreate, Create: CreateItemInput{Name: "Milk", Quantity: 1, OnDuplicate: DuplicateMerge}},
+		{Type: ItemOpUpdate, ItemID: bread.ID, Update: UpdateItemInput{Name: "Rye Bread", Quantity: 2}},
+		{Type: ItemOpComplete, ItemID: milk.ID, Version: &version},
+		{Type: ItemOpDelete, ItemID: eggs.ID},
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.Committed)
+	require.Len(t, result.Results, 6)
+	for _, outcome := range result.Results {
+		assert.NoError(t, outcome.Err)
+	}
+
+	assert.True(t, result.Results[0].Created)
+	assert.Equal(t, "Butter", result.Results[0].Item.Name)
+	assert.Equal(t, list.ID, result.Results[0].Item.ShoppingListID)
+	assert.False(t, result.Results[1].Created)
+	assert.Equal(t, milk, result.Results[2].Item)
+	assert.Equal(t, float64(4), milk.Quantity)
+	assert.True(t, milk.Completed)
+	assert.Equal(t, "Rye Bread", bread.Name)
+	assert.Nil(t, result.Results[5].Item)
+
+	assert.Equal(t, []*entities.Item{result.Results[0].Item}, batch.Create)
+	assert.Equal(t, []*entities.Item{milk, bread}, batch.Update)
+	assert.Equal(t, []uuid.UUID{eggs.ID}, batch.Delete)
+}
+
+func TestItemService_BatchItems_AtomicFailure(t *testing.T) {
+	milk := &entities.Item{ID: uuid.New(), Name: "Milk", Quantity: 1, Unit: entities.UnitPieces, Version: 3}
+	service, itemRepo, list := setupBatchTest(milk)
+
+	stale := 2
+	result, err := service.BatchItems(context.Background(), list.ID, "", []ItemOperation{
+		{Type: ItemOpCreate, Create: CreateItemInput{Name: "Butter", Quantity: 1}},
+		{Type: ItemOpComplete, ItemID: milk.ID, Version: &stale},
+		{Type: ItemOpDelete, ItemID: uuid.New()},
+		{Type: ItemOpCreate, Create: CreateItemInput{Name: "Milk", Quantity: 1, OnDuplicate: DuplicateReject}},
+		{Type: "rename", ItemID: milk.ID},
+	})
+
+	require.NoError(t, err)
+	assert.False(t, result.Committed)
+	assert.Equal(t, []ItemOperationResult{
+		{Err: entities.ErrOperationAborted},
+		{Err: entities.ErrVersionConflict},
+		{Err: entities.ErrItemNotFound},
+		{Err: entities.ErrDuplicateItem},
+		{Err: entities.ErrInvalidInput},
+	}, result.Results)
+	itemRepo.AssertNotCalled(t, "ApplyBatch", mock.Anything, mock.Anything)
+}
+
+func TestItemService_BatchItems_BestEffort(t *testing.T) {
+	milk := &entities.Item{ID: uuid.New(), Name: "Milk", Quantity: 1, Unit: entities.UnitPieces, Version: 1}
+	service, itemRepo, list := setupBatchTest(milk)
+
+	itemRepo.On("ApplyBatch", mock.Anything, repositories.ItemBatch{Delete: []uuid.UUID{milk.ID}}).Return(nil)
+
+	result, err := service.BatchItems(context.Background(), list.ID, BatchBestEffort, []ItemOperation{
+		{Type: ItemOpUpdate, ItemID: milk.ID, Update: UpdateItemInput{Name: "Milk", Quantity: 1.5}},
+		{Type: ItemOpDelete, ItemID: milk.ID},
+		{Type: ItemOpComplete, ItemID: milk.ID},
+	})
+
+	require.NoError(t, err)
+	assert.True(t, result.Committed)
+	assert.Equal(t, entities.ErrInvalidInput, result.Results[0].Err)
+	assert.Equal(t, float64(1), milk.Quantity)
+	assert.NoError(t, result.Results[1].Err)
+	assert.Equal(t, entities.ErrItemNotFound, result.Results[2].Err)
+	itemRepo.AssertExpectations(t)
+}
+
+func TestItemService_BatchItems_InvalidBatch(t *testing.T) {
+	service := NewItemService(
+		&MockItemRepository{}, &MockShoppingListRepository{}, &MockCategoryRepository{}, &MockPantryRepository{}, passThroughTransactor{},
+	)
+	create := ItemOperation{Type: ItemOpCreate, Create: CreateItemInput{Name: "Milk", Quantity: 1}}
+
+	for name, tt := range map[string]struct {
+		mode       BatchMode
+		operations []ItemOperation
+	}{
+		"no operations":      {mode: BatchAtomic},
+		"too many":           {mode: BatchAtomic, operations: make([]ItemOperation, MaxBatchOperations+1)},
+		"unknown batch mode": {mode: "eventually", operations: []ItemOperation{create}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := service.BatchItems(context.Background(), uuid.New(), tt.mode, tt.operations)
+			assert.Equal(t, entities.ErrInvalidInput, err)
+		})
+	}
+
+	t.Run("shopping list not found", func(t *testing.T) {
+		listRepo := &MockShoppingListRepository{}
+		service := NewItemService(&MockItemRepository{}, listRepo, &MockCategoryRepository{}, &MockPantryRepository{}, passThroughTransactor{})
+		listRepo.On("GetByID", mock.Anything, mock.Anything).Return((*entities.ShoppingList)(nil), entities.ErrShoppingListNotFound)
+
+		_, err := service.BatchItems(context.Background(), uuid.New(), BatchAtomic, []ItemOperation{create})
+		assert.Equal(t, entities.ErrShoppingListNotFound, err)
+	})
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+)
+
+// ItemImageService handles business logic for the images attached to items
+type ItemImageService struct {
+	imageRepo        repositories.ItemImageRepository
+	itemRepo         repositories.ItemRepository
+	shoppingListRepo repositories.ShoppingListRepository
+	blobs            repositories.BlobStore
+}
+
+// NewItemImageService creates a new item image service
+func NewItemImageService(
+	imageRepo repositories.ItemImageRepository,
+	itemRepo repositories.ItemRepository,
+	shoppingListRepo repositories.ShoppingListRepository,
+	blobs repositories.BlobStore,
+) *ItemImageService {
+	return &ItemImageService{
+		imageRepo:        imageRepo,
+		itemRepo:         itemRepo,
+		shoppingListRepo: shoppingListRepo,
+		blobs:            blobs,
+	}
+}
+
+// UploadImage attaches an image to an item along with a thumbnail, replacing the image it had.
+// The content type is sniffed from the data; only JPEG, PNG and GIF images are accepted.
+func (s *ItemImageService) UploadImage(ctx context.Context, itemID uuid.UUID, data []byte) (*entities.ItemImage, error) {
+	if len(data) == 0 {
+		return nil, entities.ErrInvalidInput
+	}
+	if len(data) > entities.MaxImageSize {
+		return nil, entities.ErrImageTooLarge
+	}
+	contentType := http.DetectContentType(data)
+	if !entities.IsSupportedImageType(contentType) {
+		return nil, entities.ErrUnsupportedImageType
+	}
+
+	if _, err := getEditableItem(ctx, s.itemRepo, s.shoppingListRepo, itemID); err != nil {
+		return nil, err
+	}
+
+	// Check the dimensions before decoding so a small file cannot claim a huge canvas
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, entities.ErrInvalidInput
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, entities.ErrInvalidInput
+	}
+	if config.Width*config.Height > entities.MaxImagePixels {
+		return nil, entities.ErrImageTooLarge
+	}
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, entities.ErrInvalidInput
+	}
+	thumbnail, thumbnailType, err := makeThumbnail(decoded, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("items/%s/%s", itemID, uuid.New())
+	img := &entities.ItemImage{
+		ItemID:               itemID,
+		ContentType:          contentType,
+		Size:                 int64(len(data)),
+		Width:                config.Width,
+		Height:               config.Height,
+		BlobKey:              key,
+		ThumbnailKey:         key + "-thumbnail",
+		ThumbnailContentType: thumbnailType,
+	}
+
+	previous, err := s.imageRepo.GetByItemID(ctx, itemID)
+	if err != nil && err != entities.ErrImageNotFound {
+		return nil, err
+	}
+
+	if err := s.storeBlobs(ctx, img, data, thumbnail); err != nil {
+		return nil, err
+	}
+	if err := s.imageRepo.Save(ctx, img); err != nil {
+		deleteImageBlobs(ctx, s.blobs, img)
+		return nil, err
+	}
+
+	if previous != nil {
+		deleteImageBlobs(ctx, s.blobs, previous)
+	}
+
+	return img, nil
+}
+
+// GetImage retrieves the image of an item, or its thumbnail, along with its metadata
+func (s *ItemImageService) GetImage(ctx context.Context, itemID uuid.UUID, thumbnail bool) (*entities.ItemImage, []byte, error) {
+	if _, err := getAccessibleItem(ctx, s.itemRepo, s.shoppingListRepo, itemID); err != nil {
+		return nil, nil, err
+	}
+
+	img, err := s.imageRepo.GetByItemID(ctx, itemID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key := img.BlobKey
+	if thumbnail {
+		key = img.ThumbnailKey
+	}
+	data, err := s.blobs.Get(ctx, key)
+	if err != nil {
+		if err == entities.ErrBlobNotFound {
+			return nil, nil, entities.ErrImageNotFound
+		}
+		return nil, nil, err
+	}
+
+	return img, data, nil
+}
+
+// DeleteImage removes the image of an item and its thumbnail
+func (s *ItemImageService) DeleteImage(ctx context.Context, itemID uuid.UUID) error {
+	if _, ok := CallerFromContext(ctx); ok {
+		if _, err := getEditableItem(ctx, s.itemRepo, s.shoppingListRepo, itemID); err != nil {
+			return err
+		}
+	}
+
+	img, err := s.imageRepo.GetByItemID(ctx, itemID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.imageRepo.Delete(ctx, itemID); err != nil {
+		return err
+	}
+
+	deleteImageBlobs(ctx, s.blobs, img)
+	return nil
+}
+
+// storeBlobs writes an image and its thumbnail, removing the image again when the thumbnail fails
+func (s *ItemImageService) storeBlobs(ctx context.Context, img *entities.ItemImage, data, thumbnail []byte) error {
+	if err := s.blobs.Put(ctx, img.BlobKey, data); err != nil {
+		return err
+	}
+	if err := s.blobs.Put(ctx, img.ThumbnailKey, thumbnail); err != nil {
+		_ = s.blobs.Delete(ctx, img.BlobKey)
+		return err
+	}
+	return nil
+}
+
+// deleteImageBlobs removes an image and its thumbnail from blob storage.
+// It runs once the metadata no longer points at them, so a failure only leaves unused blobs behind.
+func deleteImageBlobs(ctx context.Context, blobs repositories.BlobStore, img *entities.ItemImage) {
+	_ = blobs.Delete(ctx, img.BlobKey)
+	_ = blobs.Delete(ctx, img.ThumbnailKey)
+}
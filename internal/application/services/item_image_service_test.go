@@ -0,0 +1,220 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/infrastructure/storage"
+)
+
+// MockItemImageRepository is a mock implementation of ItemImageRepository
+type MockItemImageRepository struct {
+	mock.Mock
+}
+
+func (m *MockItemImageRepository) Save(ctx context.Context, image *entities.ItemImage) error {
+	args := m.Called(ctx, image)
+	return args.Error(0)
+}
+
+func (m *MockItemImageRepository) GetByItemID(ctx context.Context, itemID uuid.UUID) (*entities.ItemImage, error) {
+	args := m.Called(ctx, itemID)
+	return args.Get(0).(*entities.ItemImage), args.Error(1)
+}
+
+func (m *MockItemImageRepository) GetTrashedBefore(ctx context.Context, deletedBefore time.Time) ([]*entities.ItemImage, error) {
+	args := m.Called(ctx, deletedBefore)
+	return args.Get(0).([]*entities.ItemImage), args.Error(1)
+}
+
+func (m *MockItemImageRepository) Delete(ctx context.Context, itemID uuid.UUID) error {
+	args := m.Called(ctx, itemID)
+	return args.Error(0)
+}
+
+// encodeTestImage encodes a solid image of the given size as PNG or JPEG
+func encodeTestImage(t *testing.T, contentType string, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: 200, G: 40, B: 40, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if contentType == entities.ContentTypeJPEG {
+		require.NoError(t, jpeg.Encode(&buf, img, nil))
+	} else {
+		require.NoError(t, png.Encode(&buf, img))
+	}
+	return buf.Bytes()
+}
+
+func TestItemImageService_UploadImage(t *testing.T) {
+	itemRepo := &MockItemRepository{}
+	imageRepo := &MockItemImageRepository{}
+	blobs := storage.NewMemoryBlobStore()
+	service := NewItemImageService(imageRepo, itemRepo, &MockShoppingListRepository{}, blobs)
+	ctx := context.Background()
+
+	itemID := uuid.New()
+	itemRepo.On("GetByID", mock.Anything, itemID).Return(&entities.Item{ID: itemID}, nil)
+	imageRepo.On("GetByItemID", mock.Anything, itemID).Return((*entities.ItemImage)(nil), entities.ErrImageNotFound).Once()
+	imageRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	first, err := service.UploadImage(ctx, itemID, encodeTestImage(t, entities.ContentTypeJPEG, 1024, 512))
+	require.NoError(t, err)
+	assert.Equal(t, entities.ContentTypeJPEG, first.ContentType)
+	assert.Equal(t, entities.ContentTypeJPEG, first.ThumbnailContentType)
+	assert.Equal(t, 1024, first.Width)
+	assert.Equal(t, 512, first.Height)
+	assert.ElementsMatch(t, []string{first.BlobKey, first.ThumbnailKey}, blobs.Keys())
+
+	thumbnail, err := blobs.Get(ctx, first.ThumbnailKey)
+	require.NoError(t, err)
+	config, err := jpeg.DecodeConfig(bytes.NewReader(thumbnail))
+	require.NoError(t, err)
+	assert.Equal(t, entities.ThumbnailSize, config.Width)
+	assert.Equal(t, entities.ThumbnailSize/2, config.Height)
+
+	// Replacing the image removes the blobs of the previous one
+	imageRepo.On("GetByItemID", mock.Anything, itemID).Return(first, nil).Once()
+	second, err := service.UploadImage(ctx, itemID, encodeTestImage(t, entities.ContentTypePNG, 40, 30))
+	require.NoError(t, err)
+	assert.Equal(t, entities.ContentTypePNG, second.ContentType)
+	assert.Equal(t, entities.ContentTypePNG, second.ThumbnailContentType)
+	assert.ElementsMatch(t, []string{second.BlobKey, second.ThumbnailKey}, blobs.Keys())
+
+	thumbnail, err = blobs.Get(ctx, second.ThumbnailKey)
+	require.NoError(t, err)
+	config, err = png.DecodeConfig(bytes.NewReader(thumbnail))
+	require.NoError(t, err)
+	assert.Equal(t, 40, config.Width)
+	assert.Equal(t, 30, config.Height)
+}
+
+func TestItemImageService_UploadImage_Rejected(t *testing.T) {
+	oversized := make([]byte, entities.MaxImageSize+1)
+	copy(oversized, encodeTestImage(t, entities.ContentTypePNG, 1, 1))
+	truncated := encodeTestImage(t, entities.ContentTypePNG, 10, 10)[:40]
+	// A GIF with a 0×5 screen and an empty frame decodes, but no thumbnail can be encoded from it
+	zeroWidth := []byte("GIF89a\x00\x00\x05\x00\x80\x00\x00\x00\x00\x00\xff\xff\xff" +
+		",\x00\x00\x00\x00\x00\x00\x05\x00\x00\x02\x01,\x00;")
+	// The header of a GIF claims a 5000×5000 canvas, which takes 25 megapixels
+	huge := append([]byte("GIF89a\x88\x13\x88\x13"), zeroWidth[10:]...)
+
+	tests := []struct {
+		name          string
+		data          []byte
+		expectedError error
+	}{
+		{name: "empty", data: nil, expectedError: entities.ErrInvalidInput},
+		{name: "too large", data: oversized, expectedError: entities.ErrImageTooLarge},
+		{name: "not an image", data: []byte("%PDF-1.7 not a photo"), expectedError: entities.ErrUnsupportedImageType},
+		{name: "corrupt image", data: truncated, expectedError: entities.ErrInvalidInput},
+		{name: "zero width", data: zeroWidth, expectedError: entities.ErrInvalidInput},
+		{name: "too many pixels", data: huge, expectedError: entities.ErrImageTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := &MockItemRepository{}
+			imageRepo := &MockItemImageRepository{}
+			blobs := storage.NewMemoryBlobStore()
+			service := NewItemImageService(imageRepo, itemRepo, &MockShoppingListRepository{}, blobs)
+			itemRepo.On("GetByID", mock.Anything, mock.Anything).Return(&entities.Item{}, nil).Maybe()
+
+			result, err := service.UploadImage(context.Background(), uuid.New(), tt.data)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Nil(t, result)
+			assert.Empty(t, blobs.Keys())
+			imageRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestItemImageService_UploadImage_ItemNotFound(t *testing.T) {
+	itemRepo := &MockItemRepository{}
+	service := NewItemImageService(&MockItemImageRepository{}, itemRepo, &MockShoppingListRepository{}, storage.NewMemoryBlobStore())
+	itemRepo.On("GetByID", mock.Anything, mock.Anything).Return((*entities.Item)(nil), entities.ErrItemNotFound)
+
+	_, err := service.UploadImage(context.Background(), uuid.New(), encodeTestImage(t, entities.ContentTypePNG, 2, 2))
+
+	assert.Equal(t, entities.ErrItemNotFound, err)
+}
+
+func TestItemImageService_GetImage(t *testing.T) {
+	itemRepo := &MockItemRepository{}
+	imageRepo := &MockItemImageRepository{}
+	blobs := storage.NewMemoryBlobStore()
+	service := NewItemImageService(imageRepo, itemRepo, &MockShoppingListRepository{}, blobs)
+	ctx := context.Background()
+
+	itemID := uuid.New()
+	stored := &entities.ItemImage{ItemID: itemID, BlobKey: "items/a", ThumbnailKey: "items/a-thumbnail"}
+	require.NoError(t, blobs.Put(ctx, stored.BlobKey, []byte("original")))
+	require.NoError(t, blobs.Put(ctx, stored.ThumbnailKey, []byte("thumbnail")))
+	itemRepo.On("GetByID", mock.Anything, itemID).Return(&entities.Item{ID: itemID}, nil)
+	imageRepo.On("GetByItemID", mock.Anything, itemID).Return(stored, nil)
+
+	_, data, err := service.GetImage(ctx, itemID, false)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("original"), data)
+
+	_, data, err = service.GetImage(ctx, itemID, true)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("thumbnail"), data)
+
+	require.NoError(t, blobs.Delete(ctx, stored.BlobKey))
+	_, _, err = service.GetImage(ctx, itemID, false)
+	assert.Equal(t, entities.ErrImageNotFound, err)
+}
+
+func TestItemImageService_DeleteImage(t *testing.T) {
+	imageRepo := &MockItemImageRepository{}
+	blobs := storage.NewMemoryBlobStore()
+	service := NewItemImageService(imageRepo, &MockItemRepository{}, &MockShoppingListRepository{}, blobs)
+	ctx := context.Background()
+
+	itemID := uuid.New()
+	stored := &entities.ItemImage{ItemID: itemID, BlobKey: "items/a", ThumbnailKey: "items/a-thumbnail"}
+	require.NoError(t, blobs.Put(ctx, stored.BlobKey, []byte("original")))
+	require.NoError(t, blobs.Put(ctx, stored.ThumbnailKey, []byte("thumbnail")))
+	imageRepo.On("GetByItemID", mock.Anything, itemID).Return(stored, nil).Once()
+	imageRepo.On("Delete", mock.Anything, itemID).Return(nil)
+
+	require.NoError(t, service.DeleteImage(ctx, itemID))
+	assert.Empty(t, blobs.Keys())
+
+	imageRepo.On("GetByItemID", mock.Anything, itemID).Return((*entities.ItemImage)(nil), entities.ErrImageNotFound)
+	assert.Equal(t, entities.ErrImageNotFound, service.DeleteImage(ctx, itemID))
+}
+
+func TestThumbnailDimensions(t *testing.T) {
+	tests := []struct {
+		width, height         int
+		wantWidth, wantHeight int
+	}{
+		{width: 100, height: 50, wantWidth: 100, wantHeight: 50},
+		{width: 1024, height: 512, wantWidth: 256, wantHeight: 128},
+		{width: 300, height: 1200, wantWidth: 64, wantHeight: 256},
+		{width: 5000, height: 2, wantWidth: 256, wantHeight: 1},
+	}
+
+	for _, tt := range tests {
+		width, height := thumbnailDimensions(tt.width, tt.height)
+		assert.Equal(t, tt.wantWidth, width)
+		assert.Equal(t, tt.wantHeight, height)
+	}
+}
@@ -8,66 +8,251 @@ import (
 	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
 )
 
+// DuplicatePolicy controls what CreateItem does when the list already has an open item with the same name
+type DuplicatePolicy string
+
+// Supported duplicate policies
+const (
+	DuplicateReject DuplicatePolicy = "reject"
+	DuplicateMerge  DuplicatePolicy = "merge"
+	DuplicateAllow  DuplicatePolicy = "allow"
+)
+
+// IsValid reports whether the policy is supported; the empty policy means allow
+func (p DuplicatePolicy) IsValid() bool {
+	switch p {
+	case "", DuplicateReject, DuplicateMerge, DuplicateAllow:
+		return true
+	}
+	return false
+}
+
+// allows reports whether the policy adds duplicates as new items
+func (p DuplicatePolicy) allows() bool {
+	return p == "" || p == DuplicateAllow
+}
+
+// CreateItemInput holds the attributes of a new item.
+// UnitPrice is expressed in the minor unit of Currency, which defaults to the list currency.
+type CreateItemInput struct {
+	Name        string
+	Quantity    float64
+	Unit        entities.Unit
+	CategoryID  *uuid.UUID
+	UnitPrice   *int64
+	Currency    string
+	Notes       string
+	Brand       string
+	Size        string
+	OnDuplicate DuplicatePolicy
+}
+
+// UpdateItemInput holds the attributes that replace those of an existing item.
+// Version, when set, must match the current version of the item.
+type UpdateItemInput struct {
+	Name       string
+	Quantity   float64
+	Unit       entities.Unit
+	CategoryID *uuid.UUID
+	UnitPrice  *int64
+	Currency   string
+	Notes      string
+	Brand      string
+	Size       string
+	Completed  bool
+	Version    *int
+}
+
+// ItemPatch holds the attributes to change on an existing item; nil fields are left as they are.
+// ClearCategory and ClearUnitPrice remove the category and the price, and empty notes, brand
+// or size remove those.
+type ItemPatch struct {
+	Name           *string
+	Quantity       *float64
+	Unit           *entities.Unit
+	CategoryID     *uuid.UUID
+	ClearCategory  bool
+	UnitPrice      *int64
+	ClearUnitPrice bool
+	Currency       *string
+	Notes          *string
+	Brand          *string
+	Size           *string
+	Completed      *bool
+	Version        *int
+}
+
 // ItemService handles business logic for items
 type ItemService struct {
 	itemRepo         repositories.ItemRepository
 	shoppingListRepo repositories.ShoppingListRepository
+	categoryRepo     repositories.CategoryRepository
+	pantryRepo       repositories.PantryRepository
+	transactor       repositories.Transactor
 }
 
 // NewItemService creates a new item service
-func NewItemService(itemRepo repositories.ItemRepository, shoppingListRepo repositories.ShoppingListRepository) *ItemService {
+func NewItemService(
+	itemRepo repositories.ItemRepository,
+	shoppingListRepo repositories.ShoppingListRepository,
+	categoryRepo repositories.CategoryRepository,
+	pantryRepo repositories.PantryRepository,
+	transactor repositories.Transactor,
+) *ItemService {
 	return &ItemService{
 		itemRepo:         itemRepo,
 		shoppingListRepo: shoppingListRepo,
+		categoryRepo:     categoryRepo,
+		pantryRepo:       pantryRepo,
+		transactor:       transactor,
 	}
 }
 
-// CreateItem creates a new item in a shopping list
-func (s *ItemService) CreateItem(ctx context.Context, shoppingListID uuid.UUID, name string, quantity int) (*entities.Item, error) {
-	if name == "" {
-		return nil, entities.ErrInvalidInput
+// CreateItem creates a new item in a shopping list.
+// An empty unit defaults to pieces. When the list already has an open item with the
+// same name, the duplicate policy decides whether to reject the item with
+// ErrDuplicateItem, merge its quantity into the existing item, or add it anyway.
+// The returned flag reports whether a new item was created rather than merged.
+func (s *ItemService) CreateItem(ctx context.Context, shoppingListID uuid.UUID, input CreateItemInput) (*entities.Item, bool, error) {
+	unit, err := checkCreateInput(input)
+	if err != nil {
+		return nil, false, err
 	}
 
-	// Verify shopping list exists
-	_, err := s.shoppingListRepo.GetByID(ctx, shoppingListID)
+	// Verify shopping list exists and the caller may change it
+	list, err := getEditableList(ctx, s.shoppingListRepo, shoppingListID)
 	if err != nil {
-		return nil, entities.ErrShoppingListNotFound
+		if err == entities.ErrForbidden {
+			return nil, false, err
+		}
+		return nil, false, entities.ErrShoppingListNotFound
 	}
 
-	item := entities.NewItem(name, quantity)
+	item, err := s.buildItem(ctx, list, input, unit)
+	if err != nil {
+		return nil, false, err
+	}
 	item.ShoppingListID = shoppingListID
 
+	if !input.OnDuplicate.allows() {
+		existing, err := s.findOpenDuplicate(ctx, shoppingListID, input.Name)
+		if err != nil {
+			return nil, false, err
+		}
+		if existing != nil {
+			if input.OnDuplicate != DuplicateMerge {
+				return nil, false, entities.ErrDuplicateItem
+			}
+			merged, err := s.mergeInto(ctx, existing, input.Quantity, unit)
+			return merged, false, err
+		}
+	}
+
 	if err := s.itemRepo.Create(ctx, item); err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
-	return item, nil
+	return item, true, nil
 }
 
 // GetItem retrieves an item by ID
 func (s *ItemService) GetItem(ctx context.Context, id uuid.UUID) (*entities.Item, error) {
-	return s.itemRepo.GetByID(ctx, id)
+	return getAccessibleItem(ctx, s.itemRepo, s.shoppingListRepo, id)
 }
 
 // GetItemsByShoppingListID retrieves all items for a shopping list
 func (s *ItemService) GetItemsByShoppingListID(ctx context.Context, shoppingListID uuid.UUID) ([]*entities.Item, error) {
+	if err := checkListAccess(ctx, s.shoppingListRepo, shoppingListID, entities.ListRoleViewer); err != nil {
+		return nil, err
+	}
 	return s.itemRepo.GetByShoppingListID(ctx, shoppingListID)
 }
 
-// UpdateItem updates an existing item
-func (s *ItemService) UpdateItem(ctx context.Context, id uuid.UUID, name string, quantity int, completed bool) (*entities.Item, error) {
-	if name == "" {
+// UpdateItem updates an existing item.
+// An empty unit keeps the item's current unit.
+func (s *ItemService) UpdateItem(ctx context.Context, id uuid.UUID, input UpdateItemInput) (*entities.Item, error) {
+	if input.Name == "" {
 		return nil, entities.ErrInvalidInput
 	}
 
-	item, err := s.itemRepo.GetByID(ctx, id)
+	item, err := getEditableItem(ctx, s.itemRepo, s.shoppingListRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkVersion(input.Version, item.Version); err != nil {
+		return nil, err
+	}
+
+	return s.applyUpdate(ctx, item, input)
+}
+
+// PatchItem changes only the attributes of an item that the patch supplies
+func (s *ItemService) PatchItem(ctx context.Context, id uuid.UUID, patch ItemPatch) (*entities.Item, error) {
+	item, err := getEditableItem(ctx, s.itemRepo, s.shoppingListRepo, id)
 	if err != nil {
 		return nil, err
 	}
 
-	item.Name = name
-	item.Quantity = quantity
-	item.Completed = completed
+	if err := checkVersion(patch.Version, item.Version); err != nil {
+		return nil, err
+	}
+
+	input := UpdateItemInput{
+		Name:       item.Name,
+		Quantity:   item.Quantity,
+		Unit:       item.Unit,
+		CategoryID: item.CategoryID,
+		UnitPrice:  item.UnitPrice,
+		Currency:   item.Currency,
+		Notes:      item.Notes,
+		Brand:      item.Brand,
+		Size:       item.Size,
+		Completed:  item.Completed,
+	}
+	if patch.Name != nil {
+		input.Name = *patch.Name
+	}
+	if patch.Quantity != nil {
+		input.Quantity = *patch.Quantity
+	}
+	if patch.Unit != nil {
+		input.Unit = *patch.Unit
+	}
+	if patch.CategoryID != nil || patch.ClearCategory {
+		input.CategoryID = patch.CategoryID
+	}
+	if patch.UnitPrice != nil || patch.ClearUnitPrice {
+		input.UnitPrice = patch.UnitPrice
+	}
+	if patch.Currency != nil {
+		input.Currency = *patch.Currency
+	}
+	if patch.Notes != nil {
+		input.Notes = *patch.Notes
+	}
+	if patch.Brand != nil {
+		input.Brand = *patch.Brand
+	}
+	if patch.Size != nil {
+		input.Size = *patch.Size
+	}
+	if patch.Completed != nil {
+		input.Completed = *patch.Completed
+	}
+
+	if input.Name == "" {
+		return nil, entities.ErrInvalidInput
+	}
+
+	return s.applyUpdate(ctx, item, input)
+}
+
+// applyUpdate validates the new attributes of an item and stores them
+func (s *ItemService) applyUpdate(ctx context.Context, item *entities.Item, input UpdateItemInput) (*entities.Item, error) {
+	if err := s.prepareUpdate(ctx, item, input); err != nil {
+		return nil, err
+	}
 
 	if err := s.itemRepo.Update(ctx, item); err != nil {
 		return nil, err
@@ -76,27 +261,264 @@ func (s *ItemService) UpdateItem(ctx context.Context, id uuid.UUID, name string,
 	return item, nil
 }
 
+// prepareUpdate validates the new attributes of an item and sets them without storing them
+func (s *ItemService) prepareUpdate(ctx context.Context, item *entities.Item, input UpdateItemInput) error {
+	unit := input.Unit
+	if unit == "" {
+		unit = item.Unit
+	}
+	if unit == "" {
+		unit = entities.UnitPieces
+	}
+	if err := entities.ValidateQuantity(input.Quantity, unit); err != nil {
+		return err
+	}
+	if err := entities.ValidateItemDetails(input.Notes, input.Brand, input.Size); err != nil {
+		return err
+	}
+
+	if err := s.verifyCategory(ctx, item.ShoppingListID, input.CategoryID); err != nil {
+		return err
+	}
+
+	currency, err := s.resolveItemCurrency(ctx, item, input.UnitPrice, input.Currency)
+	if err != nil {
+		return err
+	}
+
+	item.Name = input.Name
+	item.Quantity = input.Quantity
+	item.Unit = unit
+	item.CategoryID = input.CategoryID
+	item.UnitPrice = input.UnitPrice
+	item.Currency = currency
+	item.Notes = input.Notes
+	item.Brand = input.Brand
+	item.Size = input.Size
+	item.Completed = input.Completed
+	return nil
+}
+
 // DeleteItem deletes an item
 func (s *ItemService) DeleteItem(ctx context.Context, id uuid.UUID) error {
+	if _, ok := CallerFromContext(ctx); ok {
+		if _, err := getEditableItem(ctx, s.itemRepo, s.shoppingListRepo, id); err != nil {
+			return err
+		}
+	}
 	return s.itemRepo.Delete(ctx, id)
 }
 
-// ToggleItemCompletion toggles the completion status of an item
-func (s *ItemService) ToggleItemCompletion(ctx context.Context, id uuid.UUID) (*entities.Item, error) {
-	item, err := s.itemRepo.GetByID(ctx, id)
+// ToggleItemCompletion toggles the completion status of an item.
+// A non-nil version must match the current version of the item. With addToPantry, an item
+// that becomes completed has its quantity added to the pantry in the same transaction.
+func (s *ItemService) ToggleItemCompletion(ctx context.Context, id uuid.UUID, version *int, addToPantry bool) (*entities.Item, error) {
+	item, err := getEditableItem(ctx, s.itemRepo, s.shoppingListRepo, id)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := checkVersion(version, item.Version); err != nil {
+		return nil, err
+	}
+
 	if item.Completed {
 		item.MarkIncomplete()
 	} else {
 		item.MarkCompleted()
 	}
 
+	if !addToPantry || !item.Completed {
+		if err := s.itemRepo.Update(ctx, item); err != nil {
+			return nil, err
+		}
+		return item, nil
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.itemRepo.Update(ctx, item); err != nil {
+			return err
+		}
+		_, err := stockPantry(ctx, s.pantryRepo, item)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// ReorderItems sets the order of a shopping list's items.
+// itemIDs must list every item of the shopping list exactly once.
+func (s *ItemService) ReorderItems(ctx context.Context, shoppingListID uuid.UUID, itemIDs []uuid.UUID) ([]*entities.Item, error) {
+	if _, err := getEditableList(ctx, s.shoppingListRepo, shoppingListID); err != nil {
+		return nil, err
+	}
+
+	items, err := s.itemRepo.GetByShoppingListID(ctx, shoppingListID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(itemIDs) != len(items) {
+		return nil, entities.ErrInvalidInput
+	}
+
+	byID := make(map[uuid.UUID]*entities.Item, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+
+	ordered := make([]*entities.Item, 0, len(itemIDs))
+	for position, id := range itemIDs {
+		item, ok := byID[id]
+		if !ok {
+			return nil, entities.ErrInvalidInput
+		}
+		delete(byID, id)
+		if item.Position != position {
+			// The repository bumps the version of the items that move
+			item.Position = position
+			item.Version++
+		}
+		ordered = append(ordered, item)
+	}
+
+	if err := s.itemRepo.Reorder(ctx, shoppingListID, itemIDs); err != nil {
+		return nil, err
+	}
+
+	return ordered, nil
+}
+
+// findOpenDuplicate returns the open item of a list whose name matches, if any
+func (s *ItemService) findOpenDuplicate(ctx context.Context, shoppingListID uuid.UUID, name string) (*entities.Item, error) {
+	items, err := s.itemRepo.GetByShoppingListID(ctx, shoppingListID)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if item.IsDuplicateOf(name) {
+			return item, nil
+		}
+	}
+	return nil, nil
+}
+
+// mergeInto adds a quantity to an existing item measured in the same unit
+func (s *ItemService) mergeInto(ctx context.Context, item *entities.Item, quantity float64, unit entities.Unit) (*entities.Item, error) {
+	if err := mergeQuantity(item, quantity, unit); err != nil {
+		return nil, err
+	}
+
 	if err := s.itemRepo.Update(ctx, item); err != nil {
 		return nil, err
 	}
 
 	return item, nil
 }
+
+// mergeQuantity adds a quantity to an item measured in the same unit without storing it
+func mergeQuantity(item *entities.Item, quantity float64, unit entities.Unit) error {
+	if item.Unit != unit {
+		return entities.ErrDuplicateItem
+	}
+
+	merged := item.Quantity + quantity
+	if err := entities.ValidateQuantity(merged, unit); err != nil {
+		return err
+	}
+	item.UpdateQuantity(merged)
+	return nil
+}
+
+// checkCreateInput validates the attributes of a new item that need no lookup and returns its unit.
+// An empty unit defaults to pieces.
+func checkCreateInput(input CreateItemInput) (entities.Unit, error) {
+	if input.Name == "" || !input.OnDuplicate.IsValid() {
+		return "", entities.ErrInvalidInput
+	}
+
+	unit := input.Unit
+	if unit == "" {
+		unit = entities.UnitPieces
+	}
+	if err := entities.ValidateQuantity(input.Quantity, unit); err != nil {
+		return "", err
+	}
+	if err := entities.ValidateItemDetails(input.Notes, input.Brand, input.Size); err != nil {
+		return "", err
+	}
+	return unit, nil
+}
+
+// buildItem validates the category and price of a new item of a shopping list and builds it,
+// leaving the caller to attach it to the list
+func (s *ItemService) buildItem(
+	ctx context.Context,
+	list *entities.ShoppingList,
+	input CreateItemInput,
+	unit entities.Unit,
+) (*entities.Item, error) {
+	if err := verifyCategoryOwner(ctx, s.categoryRepo, list.OwnerID, input.CategoryID); err != nil {
+		return nil, err
+	}
+
+	currency, err := validatePrice(input.UnitPrice, input.Currency, list.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	item := entities.NewItem(input.Name, input.Quantity)
+	item.Unit = unit
+	item.CategoryID = input.CategoryID
+	item.UnitPrice = input.UnitPrice
+	item.Currency = currency
+	item.Notes = input.Notes
+	item.Brand = input.Brand
+	item.Size = input.Size
+	return item, nil
+}
+
+// verifyCategory checks that an optional category belongs to the owner of a shopping list,
+// whose categories its items are grouped by
+func (s *ItemService) verifyCategory(ctx context.Context, shoppingListID uuid.UUID, categoryID *uuid.UUID) error {
+	if categoryID == nil {
+		return nil
+	}
+	list, err := s.shoppingListRepo.GetByID(ctx, shoppingListID)
+	if err != nil {
+		return err
+	}
+	return verifyCategoryOwner(ctx, s.categoryRepo, list.OwnerID, categoryID)
+}
+
+// resolveItemCurrency validates the price of an existing item, falling back to its
+// current currency and then to the currency of its shopping list
+func (s *ItemService) resolveItemCurrency(ctx context.Context, item *entities.Item, unitPrice *int64, currency string) (string, error) {
+	fallback := item.Currency
+	if unitPrice != nil && currency == "" && fallback == "" {
+		list, err := s.shoppingListRepo.GetByID(ctx, item.ShoppingListID)
+		if err != nil {
+			return "", err
+		}
+		fallback = list.Currency
+	}
+	return validatePrice(unitPrice, currency, fallback)
+}
+
+// validatePrice checks an optional unit price and returns the normalized currency.
+// Unpriced items carry no currency.
+func validatePrice(unitPrice *int64, currency, fallback string) (string, error) {
+	if unitPrice == nil {
+		return "", nil
+	}
+	if *unitPrice < 0 {
+		return "", entities.ErrInvalidInput
+	}
+	if currency == "" {
+		currency = fallback
+	}
+	return entities.NormalizeCurrency(currency)
+}
@@ -2,14 +2,26 @@ package services
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
 )
 
+// passThroughTransactor runs transactional work directly, without a transaction
+type passThroughTransactor struct{}
+
+func (passThroughTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
 // MockItemRepository is a mock implementation of ItemRepository
 type MockItemRepository struct {
 	mock.Mock
@@ -30,6 +42,40 @@ func (m *MockItemRepository) GetByShoppingListID(ctx context.Context, shoppingLi
 	return args.Get(0).([]*entities.Item), args.Error(1)
 }
 
+func (m *MockItemRepository) ApplyBatch(ctx context.Context, batch repositories.ItemBatch) error {
+	args := m.Called(ctx, batch)
+	return args.Error(0)
+}
+
+func (m *MockItemRepository) DeleteCompleted(ctx context.Context, shoppingListID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, shoppingListID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockItemRepository) SetCompleted(ctx context.Context, shoppingListID uuid.UUID, completed bool) (int64, error) {
+	args := m.Called(ctx, shoppingListID, completed)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockItemRepository) GetByShoppingListIDs(
+	ctx context.Context,
+	shoppingListIDs []uuid.UUID,
+) ([]*entities.Item, error) {
+	args := m.Called(ctx, shoppingListIDs)
+	return args.Get(0).([]*entities.Item), args.Error(1)
+}
+
+func (m *MockItemRepository) CountByShoppingListIDs(
+	ctx context.Context,
+	shoppingListIDs []uuid.UUID,
+) (map[uuid.UUID]entities.ItemCounts, error) {
+	args := m.Called(ctx, shoppingListIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]entities.ItemCounts), args.Error(1)
+}
+
 func (m *MockItemRepository) Update(ctx context.Context, item *entities.Item) error {
 	args := m.Called(ctx, item)
 	return args.Error(0)
@@ -40,6 +86,31 @@ func (m *MockItemRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return args.Error(0)
 }
 
+func (m *MockItemRepository) Reorder(ctx context.Context, shoppingListID uuid.UUID, itemIDs []uuid.UUID) error {
+	args := m.Called(ctx, shoppingListID, itemIDs)
+	return args.Error(0)
+}
+
+func (m *MockItemRepository) GetTrashed(ctx context.Context, ownerID *uuid.UUID) ([]*entities.Item, error) {
+	args := m.Called(ctx, ownerID)
+	return args.Get(0).([]*entities.Item), args.Error(1)
+}
+
+func (m *MockItemRepository) GetTrashedByID(ctx context.Context, id uuid.UUID) (*entities.Item, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*entities.Item), args.Error(1)
+}
+
+func (m *MockItemRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockItemRepository) PurgeTrashed(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	args := m.Called(ctx, deletedBefore)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 // MockShoppingListRepository is a mock implementation of ShoppingListRepository
 type MockShoppingListRepository struct {
 	mock.Mock
@@ -55,8 +126,8 @@ func (m *MockShoppingListRepository) GetByID(ctx context.Context, id uuid.UUID)
 	return args.Get(0).(*entities.ShoppingList), args.Error(1)
 }
 
-func (m *MockShoppingListRepository) GetAll(ctx context.Context) ([]*entities.ShoppingList, error) {
-	args := m.Called(ctx)
+func (m *MockShoppingListRepository) GetAll(ctx context.Context, query repositories.ShoppingListQuery) ([]*entities.ShoppingList, error) {
+	args := m.Called(ctx, query)
 	return args.Get(0).([]*entities.ShoppingList), args.Error(1)
 }
 
@@ -70,22 +141,80 @@ func (m *MockShoppingListRepository) Delete(ctx context.Context, id uuid.UUID) e
 	return args.Error(0)
 }
 
+func (m *MockShoppingListRepository) GetTrashed(ctx context.Context, ownerID *uuid.UUID) ([]*entities.ShoppingList, error) {
+	args := m.Called(ctx, ownerID)
+	return args.Get(0).([]*entities.ShoppingList), args.Error(1)
+}
+
+func (m *MockShoppingListRepository) GetTrashedByID(ctx context.Context, id uuid.UUID) (*entities.ShoppingList, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*entities.ShoppingList), args.Error(1)
+}
+
+func (m *MockShoppingListRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockShoppingListRepository) PurgeTrashed(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	args := m.Called(ctx, deletedBefore)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockCategoryRepository is a mock implementation of CategoryRepository
+type MockCategoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockCategoryRepository) Create(ctx context.Context, category *entities.Category) error {
+	args := m.Called(ctx, category)
+	return args.Error(0)
+}
+
+func (m *MockCategoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Category, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*entities.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) GetByName(ctx context.Context, ownerID *uuid.UUID, name string) (*entities.Category, error) {
+	args := m.Called(ctx, ownerID, name)
+	return args.Get(0).(*entities.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) GetAll(ctx context.Context, ownerID *uuid.UUID) ([]*entities.Category, error) {
+	args := m.Called(ctx, ownerID)
+	return args.Get(0).([]*entities.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) Update(ctx context.Context, category *entities.Category) error {
+	args := m.Called(ctx, category)
+	return args.Error(0)
+}
+
+func (m *MockCategoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
 func TestNewItemService(t *testing.T) {
 	itemRepo := &MockItemRepository{}
 	shoppingListRepo := &MockShoppingListRepository{}
+	categoryRepo := &MockCategoryRepository{}
 
-	service := NewItemService(itemRepo, shoppingListRepo)
+	service := NewItemService(itemRepo, shoppingListRepo, categoryRepo, &MockPantryRepository{}, passThroughTransactor{})
 
 	assert.NotNil(t, service)
 	assert.Equal(t, itemRepo, service.itemRepo)
 	assert.Equal(t, shoppingListRepo, service.shoppingListRepo)
+	assert.Equal(t, categoryRepo, service.categoryRepo)
 }
 
 func TestItemService_CreateItem(t *testing.T) {
 	tests := []struct {
 		name           string
 		itemName       string
-		quantity       int
+		quantity       float64
+		unit           entities.Unit
 		shoppingListID uuid.UUID
 		setupMocks     func(*MockItemRepository, *MockShoppingListRepository)
 		expectedError  error
@@ -123,17 +252,54 @@ func TestItemService_CreateItem(t *testing.T) {
 			expectedError:  entities.ErrShoppingListNotFound,
 			expectedResult: false,
 		},
+		{
+			name:           "fractional quantity with weight unit",
+			itemName:       "Flour",
+			quantity:       1.5,
+			unit:           entities.UnitKilogram,
+			shoppingListID: uuid.New(),
+			setupMocks: func(itemRepo *MockItemRepository, listRepo *MockShoppingListRepository) {
+				listRepo.On("GetByID", mock.Anything, mock.Anything).Return(&entities.ShoppingList{}, nil)
+				itemRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			},
+			expectedError:  nil,
+			expectedResult: true,
+		},
+		{
+			name:           "fractional quantity with pieces should fail",
+			itemName:       "Eggs",
+			quantity:       1.5,
+			unit:           entities.UnitPieces,
+			shoppingListID: uuid.New(),
+			setupMocks:     func(itemRepo *MockItemRepository, listRepo *MockShoppingListRepository) {},
+			expectedError:  entities.ErrInvalidInput,
+			expectedResult: false,
+		},
+		{
+			name:           "unknown unit should fail",
+			itemName:       "Sugar",
+			quantity:       2,
+			unit:           entities.Unit("cups"),
+			shoppingListID: uuid.New(),
+			setupMocks:     func(itemRepo *MockItemRepository, listRepo *MockShoppingListRepository) {},
+			expectedError:  entities.ErrInvalidInput,
+			expectedResult: false,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			itemRepo := &MockItemRepository{}
 			shoppingListRepo := &MockShoppingListRepository{}
-			service := NewItemService(itemRepo, shoppingListRepo)
+			service := NewItemService(itemRepo, shoppingListRepo, &MockCategoryRepository{}, &MockPantryRepository{}, passThroughTransactor{})
 
 			tt.setupMocks(itemRepo, shoppingListRepo)
 
-			result, err := service.CreateItem(context.Background(), tt.shoppingListID, tt.itemName, tt.quantity)
+			result, _, err := service.CreateItem(context.Background(), tt.shoppingListID, CreateItemInput{
+				Name:     tt.itemName,
+				Quantity: tt.quantity,
+				Unit:     tt.unit,
+			})
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -145,6 +311,7 @@ func TestItemService_CreateItem(t *testing.T) {
 					assert.NotNil(t, result)
 					assert.Equal(t, tt.itemName, result.Name)
 					assert.Equal(t, tt.quantity, result.Quantity)
+					assert.True(t, result.Unit.IsValid())
 					assert.Equal(t, tt.shoppingListID, result.ShoppingListID)
 				}
 			}
@@ -155,10 +322,301 @@ func TestItemService_CreateItem(t *testing.T) {
 	}
 }
 
+func TestItemService_CreateItem_WithCategory(t *testing.T) {
+	otherOwner := uuid.New()
+
+	tests := []struct {
+		name          string
+		categoryOwner *uuid.UUID
+		categoryErr   error
+		expectedError error
+	}{
+		{name: "assigns existing category"},
+		{name: "unknown category should fail", categoryErr: entities.ErrCategoryNotFound, expectedError: entities.ErrCategoryNotFound},
+		{name: "category of another owner should fail", categoryOwner: &otherOwner, expectedError: entities.ErrCategoryNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := &MockItemRepository{}
+			shoppingListRepo := &MockShoppingListRepository{}
+			categoryRepo := &MockCategoryRepository{}
+			service := NewItemService(itemRepo, shoppingListRepo, categoryRepo, &MockPantryRepository{}, passThroughTransactor{})
+
+			categoryID := uuid.New()
+			shoppingListRepo.On("GetByID", mock.Anything, mock.Anything).Return(&entities.ShoppingList{}, nil)
+			if tt.categoryErr != nil {
+				categoryRepo.On("GetByID", mock.Anything, categoryID).Return((*entities.Category)(nil), tt.categoryErr)
+			} else {
+				categoryRepo.On("GetByID", mock.Anything, categoryID).Return(&entities.Category{ID: categoryID, OwnerID: tt.categoryOwner}, nil)
+			}
+			if tt.expectedError == nil {
+				itemRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			}
+
+			result, _, err := service.CreateItem(context.Background(), uuid.New(), CreateItemInput{
+				Name:       "Apples",
+				Quantity:   6,
+				CategoryID: &categoryID,
+			})
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, &categoryID, result.CategoryID)
+			}
+
+			itemRepo.AssertExpectations(t)
+			categoryRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemService_CreateItem_WithPrice(t *testing.T) {
+	price := int64(249)
+	negative := int64(-5)
+
+	tests := []struct {
+		name          string
+		unitPrice     *int64
+		currency      string
+		listCurrency  string
+		wantCurrency  string
+		expectedError error
+	}{
+		{name: "explicit currency", unitPrice: &price, currency: "usd", wantCurrency: "USD"},
+		{name: "defaults to list currency", unitPrice: &price, listCurrency: "EUR", wantCurrency: "EUR"},
+		{name: "unpriced item has no currency", currency: "EUR", listCurrency: "EUR", wantCurrency: ""},
+		{name: "price without any currency should fail", unitPrice: &price, expectedError: entities.ErrInvalidInput},
+		{name: "negative price should fail", unitPrice: &negative, currency: "EUR", expectedError: entities.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := &MockItemRepository{}
+			shoppingListRepo := &MockShoppingListRepository{}
+			service := NewItemService(itemRepo, shoppingListRepo, &MockCategoryRepository{}, &MockPantryRepository{}, passThroughTransactor{})
+
+			shoppingListRepo.On("GetByID", mock.Anything, mock.Anything).Return(&entities.ShoppingList{Currency: tt.listCurrency}, nil)
+			if tt.expectedError == nil {
+				itemRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			}
+
+			result, _, err := service.CreateItem(context.Background(), uuid.New(), CreateItemInput{
+				Name:      "Coffee",
+				Quantity:  1,
+				UnitPrice: tt.unitPrice,
+				Currency:  tt.currency,
+			})
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.unitPrice, result.UnitPrice)
+				assert.Equal(t, tt.wantCurrency, result.Currency)
+			}
+
+			itemRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemService_CreateItem_Duplicates(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        DuplicatePolicy
+		unit          entities.Unit
+		existing      *entities.Item
+		wantCreated   bool
+		wantQuantity  float64
+		expectedError error
+	}{
+		{name: "allows open duplicate by default", existing: &entities.Item{Name: "Milk", Quantity: 1, Unit: entities.UnitPieces}, wantCreated: true, wantQuantity: 2},
+		{name: "reject policy", policy: DuplicateReject, existing: &entities.Item{Name: " milk ", Quantity: 1, Unit: entities.UnitPieces}, expectedError: entities.ErrDuplicateItem},
+		{name: "merge adds quantities", policy: DuplicateMerge, existing: &entities.Item{Name: "MILK", Quantity: 1, Unit: entities.UnitPieces}, wantQuantity: 3},
+		{name: "merge with different unit should fail", policy: DuplicateMerge, unit: entities.UnitLiter, existing: &entities.Item{Name: "Milk", Quantity: 1, Unit: entities.UnitPieces}, expectedError: entities.ErrDuplicateItem},
+		{name: "allow adds another item", policy: DuplicateAllow, wantCreated: true, wantQuantity: 2},
+		{name: "completed item is not a duplicate", policy: DuplicateReject, existing: &entities.Item{Name: "Milk", Quantity: 1, Unit: entities.UnitPieces, Completed: true}, wantCreated: true, wantQuantity: 2},
+		{name: "unknown policy should fail", policy: DuplicatePolicy("skip"), expectedError: entities.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := &MockItemRepository{}
+			shoppingListRepo := &MockShoppingListRepository{}
+			service := NewItemService(itemRepo, shoppingListRepo, &MockCategoryRepository{}, &MockPantryRepository{}, passThroughTransactor{})
+
+			items := []*entities.Item{}
+			if tt.existing != nil {
+				items = append(items, tt.existing)
+			}
+			shoppingListRepo.On("GetByID", mock.Anything, mock.Anything).Return(&entities.ShoppingList{}, nil)
+			itemRepo.On("GetByShoppingListID", mock.Anything, mock.Anything).Return(items, nil)
+			itemRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			itemRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+			result, created, err := service.CreateItem(context.Background(), uuid.New(), CreateItemInput{
+				Name:        "Milk",
+				Quantity:    2,
+				Unit:        tt.unit,
+				OnDuplicate: tt.policy,
+			})
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Nil(t, result)
+				itemRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				itemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCreated, created)
+			assert.Equal(t, tt.wantQuantity, result.Quantity)
+			if tt.wantCreated {
+				itemRepo.AssertCalled(t, "Create", mock.Anything, mock.Anything)
+			} else {
+				assert.Same(t, tt.existing, result)
+				itemRepo.AssertCalled(t, "Update", mock.Anything, tt.existing)
+			}
+		})
+	}
+}
+
+func TestItemService_UpdateItem_PriceFallsBackToListCurrency(t *testing.T) {
+	itemRepo := &MockItemRepository{}
+	shoppingListRepo := &MockShoppingListRepository{}
+	service := NewItemService(itemRepo, shoppingListRepo, &MockCategoryRepository{}, &MockPantryRepository{}, passThroughTransactor{})
+
+	listID := uuid.New()
+	itemID := uuid.New()
+	price := int64(399)
+
+	itemRepo.On("GetByID", mock.Anything, itemID).Return(&entities.Item{ID: itemID, ShoppingListID: listID, Name: "Cheese"}, nil)
+	shoppingListRepo.On("GetByID", mock.Anything, listID).Return(&entities.ShoppingList{ID: listID, Currency: "CHF"}, nil)
+	itemRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	result, err := service.UpdateItem(context.Background(), itemID, UpdateItemInput{Name: "Cheese", Quantity: 1, UnitPrice: &price})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "CHF", result.Currency)
+	assert.Equal(t, &price, result.UnitPrice)
+	itemRepo.AssertExpectations(t)
+	shoppingListRepo.AssertExpectations(t)
+}
+
+func TestItemService_PatchItem(t *testing.T) {
+	itemRepo := &MockItemRepository{}
+	service := NewItemService(itemRepo, &MockShoppingListRepository{}, &MockCategoryRepository{}, &MockPantryRepository{}, passThroughTransactor{})
+
+	itemID := uuid.New()
+	categoryID := uuid.New()
+	price := int64(129)
+	existing := &entities.Item{
+		ID:         itemID,
+		Name:       "Milk",
+		Quantity:   1,
+		Unit:       entities.UnitLiter,
+		CategoryID: &categoryID,
+		UnitPrice:  &price,
+		Currency:   "EUR",
+		Completed:  true,
+	}
+	quantity := 2.5
+
+	itemRepo.On("GetByID", mock.Anything, itemID).Return(existing, nil)
+	itemRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	result, err := service.PatchItem(context.Background(), itemID, ItemPatch{Quantity: &quantity, ClearCategory: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Milk", result.Name)
+	assert.Equal(t, 2.5, result.Quantity)
+	assert.Equal(t, entities.UnitLiter, result.Unit)
+	assert.Nil(t, result.CategoryID)
+	assert.Equal(t, &price, result.UnitPrice)
+	assert.Equal(t, "EUR", result.Currency)
+	assert.True(t, result.Completed)
+	itemRepo.AssertExpectations(t)
+}
+
+func TestItemService_CreateItem_WithDetails(t *testing.T) {
+	itemRepo := &MockItemRepository{}
+	shoppingListRepo := &MockShoppingListRepository{}
+	service := NewItemService(itemRepo, shoppingListRepo, &MockCategoryRepository{}, &MockPantryRepository{}, passThroughTransactor{})
+
+	shoppingListRepo.On("GetByID", mock.Anything, mock.Anything).Return(&entities.ShoppingList{}, nil)
+	itemRepo.On("GetByShoppingListID", mock.Anything, mock.Anything).Return([]*entities.Item{}, nil)
+	itemRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	result, _, err := service.CreateItem(context.Background(), uuid.New(), CreateItemInput{
+		Name:     "Milk",
+		Quantity: 1,
+		Notes:    "the lactose-free one, green cap",
+		Brand:    "Arla",
+		Size:     "1 l",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "the lactose-free one, green cap", result.Notes)
+	assert.Equal(t, "Arla", result.Brand)
+	assert.Equal(t, "1 l", result.Size)
+
+	_, _, err = service.CreateItem(context.Background(), uuid.New(), CreateItemInput{
+		Name:     "Milk",
+		Quantity: 1,
+		Brand:    strings.Repeat("a", entities.MaxItemBrandLength+1),
+	})
+	assert.Equal(t, entities.ErrInvalidInput, err)
+	itemRepo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestItemService_PatchItem_Details(t *testing.T) {
+	itemRepo := &MockItemRepository{}
+	service := NewItemService(itemRepo, &MockShoppingListRepository{}, &MockCategoryRepository{}, &MockPantryRepository{}, passThroughTransactor{})
+
+	itemID := uuid.New()
+	existing := &entities.Item{ID: itemID, Name: "Milk", Quantity: 1, Notes: "green cap", Brand: "Arla", Size: "1 l"}
+	itemRepo.On("GetByID", mock.Anything, itemID).Return(existing, nil)
+	itemRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	size := "2 l"
+	result, err := service.PatchItem(context.Background(), itemID, ItemPatch{Notes: new(string), Size: &size})
+
+	require.NoError(t, err)
+	assert.Empty(t, result.Notes)
+	assert.Equal(t, "Arla", result.Brand)
+	assert.Equal(t, "2 l", result.Size)
+
+	tooLong := strings.Repeat("a", entities.MaxItemNotesLength+1)
+	_, err = service.PatchItem(context.Background(), itemID, ItemPatch{Notes: &tooLong})
+	assert.Equal(t, entities.ErrInvalidInput, err)
+	itemRepo.AssertNumberOfCalls(t, "Update", 1)
+}
+
+func TestItemService_PatchItem_EmptyName(t *testing.T) {
+	itemRepo := &MockItemRepository{}
+	service := NewItemService(itemRepo, &MockShoppingListRepository{}, &MockCategoryRepository{}, &MockPantryRepository{}, passThroughTransactor{})
+
+	itemID := uuid.New()
+	empty := ""
+	itemRepo.On("GetByID", mock.Anything, itemID).Return(&entities.Item{ID: itemID, Name: "Milk", Quantity: 1}, nil)
+
+	result, err := service.PatchItem(context.Background(), itemID, ItemPatch{Name: &empty})
+
+	assert.Equal(t, entities.ErrInvalidInput, err)
+	assert.Nil(t, result)
+	itemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
 func TestItemService_GetItem(t *testing.T) {
 	itemRepo := &MockItemRepository{}
 	shoppingListRepo := &MockShoppingListRepository{}
-	service := NewItemService(itemRepo, shoppingListRepo)
+	service := NewItemService(itemRepo, shoppingListRepo, &MockCategoryRepository{}, &MockPantryRepository{}, passThroughTransactor{})
 
 	itemID := uuid.New()
 	expectedItem := &entities.Item{ID: itemID, Name: "Test Item"}
@@ -172,10 +630,88 @@ func TestItemService_GetItem(t *testing.T) {
 	itemRepo.AssertExpectations(t)
 }
 
+func TestItemService_ScopedToCaller(t *testing.T) {
+	itemRepo := &MockItemRepository{}
+	shoppingListRepo := &MockShoppingListRepository{}
+	service := NewItemService(itemRepo, shoppingListRepo, &MockCategoryRepository{}, &MockPantryRepository{}, passThroughTransactor{})
+
+	owner := uuid.New()
+	mine := &entities.ShoppingList{ID: uuid.New(), OwnerID: &owner}
+	theirs := &entities.ShoppingList{ID: uuid.New(), OwnerID: new(uuid.UUID)}
+	*theirs.OwnerID = uuid.New()
+	myItem := &entities.Item{ID: uuid.New(), ShoppingListID: mine.ID, Name: "Milk"}
+	theirItem := &entities.Item{ID: uuid.New(), ShoppingListID: theirs.ID, Name: "Bread"}
+	shoppingListRepo.On("GetByID", mock.Anything, mine.ID).Return(mine, nil)
+	shoppingListRepo.On("GetByID", mock.Anything, theirs.ID).Return(theirs, nil)
+	itemRepo.On("GetByID", mock.Anything, myItem.ID).Return(myItem, nil)
+	itemRepo.On("GetByID", mock.Anything, theirItem.ID).Return(theirItem, nil)
+	ctx := WithCaller(context.Background(), owner)
+
+	result, err := service.GetItem(ctx, myItem.ID)
+	require.NoError(t, err)
+	assert.Equal(t, myItem, result)
+
+	_, err = service.GetItem(ctx, theirItem.ID)
+	assert.Equal(t, entities.ErrItemNotFound, err)
+
+	err = service.DeleteItem(ctx, theirItem.ID)
+	assert.Equal(t, entities.ErrItemNotFound, err)
+	itemRepo.AssertNotCalled(t, "Delete", mock.Anything, theirItem.ID)
+
+	_, err = service.GetItemsByShoppingListID(ctx, theirs.ID)
+	assert.Equal(t, entities.ErrShoppingListNotFound, err)
+
+	_, _, err = service.CreateItem(ctx, theirs.ID, CreateItemInput{Name: "Eggs", Quantity: 1})
+	assert.Equal(t, entities.ErrShoppingListNotFound, err)
+}
+
+func TestItemService_SharedList(t *testing.T) {
+	itemRepo := &MockItemRepository{}
+	shoppingListRepo := &MockShoppingListRepository{}
+	service := NewItemService(itemRepo, shoppingListRepo, &MockCategoryRepository{}, &MockPantryRepository{}, passThroughTransactor{})
+
+	owner, editor, viewer := uuid.New(), uuid.New(), uuid.New()
+	list := &entities.ShoppingList{ID: uuid.New(), OwnerID: &owner}
+	list.Members = []entities.ListMember{
+		*entities.NewListMember(list.ID, editor, entities.ListRoleEditor),
+		*entities.NewListMember(list.ID, viewer, entities.ListRoleViewer),
+	}
+	item := &entities.Item{ID: uuid.New(), ShoppingListID: list.ID, Name: "Milk", Quantity: 1, Unit: entities.UnitPieces}
+	shoppingListRepo.On("GetByID", mock.Anything, list.ID).Return(list, nil)
+	itemRepo.On("GetByID", mock.Anything, item.ID).Return(item, nil)
+
+	t.Run("viewers can read but not change items", func(t *testing.T) {
+		ctx := WithCaller(context.Background(), viewer)
+
+		result, err := service.GetItem(ctx, item.ID)
+		require.NoError(t, err)
+		assert.Equal(t, item, result)
+
+		_, err = service.UpdateItem(ctx, item.ID, UpdateItemInput{Name: "Oat milk", Quantity: 1})
+		assert.Equal(t, entities.ErrForbidden, err)
+		err = service.DeleteItem(ctx, item.ID)
+		assert.Equal(t, entities.ErrForbidden, err)
+		_, err = service.ToggleItemCompletion(ctx, item.ID, nil, false)
+		assert.Equal(t, entities.ErrForbidden, err)
+		_, _, err = service.CreateItem(ctx, list.ID, CreateItemInput{Name: "Eggs", Quantity: 1})
+		assert.Equal(t, entities.ErrForbidden, err)
+		itemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		itemRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("editors can change items", func(t *testing.T) {
+		ctx := WithCaller(context.Background(), editor)
+		itemRepo.On("Delete", mock.Anything, item.ID).Return(nil).Once()
+
+		err := service.DeleteItem(ctx, item.ID)
+		assert.NoError(t, err)
+	})
+}
+
 func TestItemService_GetItemsByShoppingListID(t *testing.T) {
 	itemRepo := &MockItemRepository{}
 	shoppingListRepo := &MockShoppingListRepository{}
-	service := NewItemService(itemRepo, shoppingListRepo)
+	service := NewItemService(itemRepo, shoppingListRepo, &MockCategoryRepository{}, &MockPantryRepository{}, passThroughTransactor{})
 
 	shoppingListID := uuid.New()
 	expectedItems := []*entities.Item{
@@ -196,7 +732,8 @@ func TestItemService_UpdateItem(t *testing.T) {
 	tests := []struct {
 		name          string
 		itemName      string
-		quantity      int
+		quantity      float64
+		unit          entities.Unit
 		completed     bool
 		setupMocks    func(*MockItemRepository, uuid.UUID)
 		expectedError error
@@ -233,18 +770,47 @@ func TestItemService_UpdateItem(t *testing.T) {
 			},
 			expectedError: entities.ErrItemNotFound,
 		},
+		{
+			name:      "changes unit to liters",
+			itemName:  "Milk",
+			quantity:  1.5,
+			unit:      entities.UnitLiter,
+			completed: false,
+			setupMocks: func(itemRepo *MockItemRepository, itemID uuid.UUID) {
+				existingItem := &entities.Item{ID: itemID, Name: "Milk", Quantity: 2, Unit: entities.UnitPieces}
+				itemRepo.On("GetByID", mock.Anything, itemID).Return(existingItem, nil)
+				itemRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:      "fractional quantity on countable unit should fail",
+			itemName:  "Bread",
+			quantity:  0.5,
+			completed: false,
+			setupMocks: func(itemRepo *MockItemRepository, itemID uuid.UUID) {
+				existingItem := &entities.Item{ID: itemID, Name: "Bread", Quantity: 1, Unit: entities.UnitPack}
+				itemRepo.On("GetByID", mock.Anything, itemID).Return(existingItem, nil)
+			},
+			expectedError: entities.ErrInvalidInput,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			itemRepo := &MockItemRepository{}
 			shoppingListRepo := &MockShoppingListRepository{}
-			service := NewItemService(itemRepo, shoppingListRepo)
+			service := NewItemService(itemRepo, shoppingListRepo, &MockCategoryRepository{}, &MockPantryRepository{}, passThroughTransactor{})
 
 			itemID := uuid.New()
 			tt.setupMocks(itemRepo, itemID)
 
-			result, err := service.UpdateItem(context.Background(), itemID, tt.itemName, tt.quantity, tt.completed)
+			result, err := service.UpdateItem(context.Background(), itemID, UpdateItemInput{
+				Name:      tt.itemName,
+				Quantity:  tt.quantity,
+				Unit:      tt.unit,
+				Completed: tt.completed,
+			})
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -266,7 +832,7 @@ func TestItemService_UpdateItem(t *testing.T) {
 func TestItemService_DeleteItem(t *testing.T) {
 	itemRepo := &MockItemRepository{}
 	shoppingListRepo := &MockShoppingListRepository{}
-	service := NewItemService(itemRepo, shoppingListRepo)
+	service := NewItemService(itemRepo, shoppingListRepo, &MockCategoryRepository{}, &MockPantryRepository{}, passThroughTransactor{})
 
 	itemID := uuid.New()
 	itemRepo.On("Delete", mock.Anything, itemID).Return(nil)
@@ -299,7 +865,7 @@ func TestItemService_ToggleItemCompletion(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			itemRepo := &MockItemRepository{}
 			shoppingListRepo := &MockShoppingListRepository{}
-			service := NewItemService(itemRepo, shoppingListRepo)
+			service := NewItemService(itemRepo, shoppingListRepo, &MockCategoryRepository{}, &MockPantryRepository{}, passThroughTransactor{})
 
 			itemID := uuid.New()
 			existingItem := &entities.Item{
@@ -311,7 +877,7 @@ func TestItemService_ToggleItemCompletion(t *testing.T) {
 			itemRepo.On("GetByID", mock.Anything, itemID).Return(existingItem, nil)
 			itemRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
 
-			result, err := service.ToggleItemCompletion(context.Background(), itemID)
+			result, err := service.ToggleItemCompletion(context.Background(), itemID, nil, false)
 
 			assert.NoError(t, err)
 			assert.NotNil(t, result)
@@ -320,3 +886,165 @@ func TestItemService_ToggleItemCompletion(t *testing.T) {
 		})
 	}
 }
+
+func TestItemService_ToggleItemCompletion_StaleVersion(t *testing.T) {
+	itemRepo := &MockItemRepository{}
+	service := NewItemService(itemRepo, &MockShoppingListRepository{}, &MockCategoryRepository{}, &MockPantryRepository{}, passThroughTransactor{})
+
+	itemID := uuid.New()
+	stale := 1
+	itemRepo.On("GetByID", mock.Anything, itemID).Return(&entities.Item{ID: itemID, Name: "Test Item", Version: 2}, nil)
+
+	result, err := service.ToggleItemCompletion(context.Background(), itemID, &stale, false)
+
+	assert.Equal(t, entities.ErrVersionConflict, err)
+	assert.Nil(t, result)
+	itemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestItemService_ReorderItems(t *testing.T) {
+	listID := uuid.New()
+	first := &entities.Item{ID: uuid.New(), Name: "Milk", Position: 0}
+	second := &entities.Item{ID: uuid.New(), Name: "Bread", Position: 1}
+	third := &entities.Item{ID: uuid.New(), Name: "Eggs", Position: 2}
+
+	tests := []struct {
+		name          string
+		itemIDs       []uuid.UUID
+		listErr       error
+		expectReorder bool
+		wantVersions  []int
+		expectedError error
+	}{
+		{
+			name:          "reorders all items",
+			itemIDs:       []uuid.UUID{third.ID, first.ID, second.ID},
+			expectReorder: true,
+			wantVersions:  []int{2, 2, 2},
+		},
+		{
+			name:          "keeps the version of items that stay in place",
+			itemIDs:       []uuid.UUID{first.ID, third.ID, second.ID},
+			expectReorder: true,
+			wantVersions:  []int{1, 2, 2},
+		},
+		{
+			name:          "missing item should fail",
+			itemIDs:       []uuid.UUID{third.ID, first.ID},
+			expectedError: entities.ErrInvalidInput,
+		},
+		{
+			name:          "duplicate item should fail",
+			itemIDs:       []uuid.UUID{third.ID, first.ID, first.ID},
+			expectedError: entities.ErrInvalidInput,
+		},
+		{
+			name:          "item from another list should fail",
+			itemIDs:       []uuid.UUID{third.ID, first.ID, uuid.New()},
+			expectedError: entities.ErrInvalidInput,
+		},
+		{
+			name:          "shopping list not found",
+			itemIDs:       []uuid.UUID{},
+			listErr:       entities.ErrShoppingListNotFound,
+			expectedError: entities.ErrShoppingListNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := &MockItemRepository{}
+			shoppingListRepo := &MockShoppingListRepository{}
+			service := NewItemService(itemRepo, shoppingListRepo, &MockCategoryRepository{}, &MockPantryRepository{}, passThroughTransactor{})
+
+			if tt.listErr != nil {
+				shoppingListRepo.On("GetByID", mock.Anything, listID).Return((*entities.ShoppingList)(nil), tt.listErr)
+			} else {
+				shoppingListRepo.On("GetByID", mock.Anything, listID).Return(&entities.ShoppingList{ID: listID}, nil)
+				items := []*entities.Item{
+					{ID: first.ID, Position: 0, Version: 1},
+					{ID: second.ID, Position: 1, Version: 1},
+					{ID: third.ID, Position: 2, Version: 1},
+				}
+				itemRepo.On("GetByShoppingListID", mock.Anything, listID).Return(items, nil)
+			}
+			if tt.expectReorder {
+				itemRepo.On("Reorder", mock.Anything, listID, tt.itemIDs).Return(nil)
+			}
+
+			result, err := service.ReorderItems(context.Background(), listID, tt.itemIDs)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result, len(tt.itemIDs))
+				for position, item := range result {
+					assert.Equal(t, tt.itemIDs[position], item.ID)
+					assert.Equal(t, position, item.Position)
+					assert.Equal(t, tt.wantVersions[position], item.Version)
+				}
+			}
+
+			itemRepo.AssertExpectations(t)
+			shoppingListRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemService_ToggleItemCompletion_AddToPantry(t *testing.T) {
+	tests := []struct {
+		name             string
+		initialCompleted bool
+		expectStocked    bool
+	}{
+		{name: "completing stocks the pantry", initialCompleted: false, expectStocked: true},
+		{name: "reopening leaves the pantry alone", initialCompleted: true, expectStocked: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := &MockItemRepository{}
+			pantryRepo := &MockPantryRepository{}
+			service := NewItemService(itemRepo, &MockShoppingListRepository{}, &MockCategoryRepository{}, pantryRepo, passThroughTransactor{})
+
+			item := entities.NewItem("Eggs", 6)
+			item.Completed = tt.initialCompleted
+			itemRepo.On("GetByID", mock.Anything, item.ID).Return(item, nil)
+			itemRepo.On("Update", mock.Anything, item).Return(nil)
+			if tt.expectStocked {
+				pantryRepo.On("GetAll", mock.Anything, repositories.PantryQuery{}).Return([]*entities.PantryItem{}, nil)
+				pantryRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *entities.PantryItem) bool {
+					return p.Name == "Eggs" && p.Quantity == 6 && p.Unit == entities.UnitPieces
+				})).Return(nil)
+			}
+
+			result, err := service.ToggleItemCompletion(context.Background(), item.ID, nil, true)
+
+			require.NoError(t, err)
+			assert.Equal(t, !tt.initialCompleted, result.Completed)
+			itemRepo.AssertExpectations(t)
+			pantryRepo.AssertExpectations(t)
+			if !tt.expectStocked {
+				pantryRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestItemService_ToggleItemCompletion_PantryFailureFailsToggle(t *testing.T) {
+	itemRepo := &MockItemRepository{}
+	pantryRepo := &MockPantryRepository{}
+	service := NewItemService(itemRepo, &MockShoppingListRepository{}, &MockCategoryRepository{}, pantryRepo, passThroughTransactor{})
+
+	item := entities.NewItem("Eggs", 6)
+	itemRepo.On("GetByID", mock.Anything, item.ID).Return(item, nil)
+	itemRepo.On("Update", mock.Anything, item).Return(nil)
+	pantryRepo.On("GetAll", mock.Anything, repositories.PantryQuery{}).Return([]*entities.PantryItem(nil), errors.New("database error"))
+
+	result, err := service.ToggleItemCompletion(context.Background(), item.ID, nil, true)
+
+	assert.EqualError(t, err, "database error")
+	assert.Nil(t, result)
+}
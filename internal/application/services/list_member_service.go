@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+)
+
+// ListMemberService handles sharing shopping lists with other users.
+// Anyone a list is shared with may see its members, but only owners may manage them.
+type ListMemberService struct {
+	shoppingListRepo repositories.ShoppingListRepository
+	memberRepo       repositories.ListMemberRepository
+	userRepo         repositories.UserRepository
+}
+
+// NewListMemberService creates a new list member service
+func NewListMemberService(
+	shoppingListRepo repositories.ShoppingListRepository,
+	memberRepo repositories.ListMemberRepository,
+	userRepo repositories.UserRepository,
+) *ListMemberService {
+	return &ListMemberService{
+		shoppingListRepo: shoppingListRepo,
+		memberRepo:       memberRepo,
+		userRepo:         userRepo,
+	}
+}
+
+// GetMembers retrieves the users a shopping list is shared with, in the order they joined
+func (s *ListMemberService) GetMembers(ctx context.Context, shoppingListID uuid.UUID) ([]*entities.ListMember, error) {
+	if _, err := getAccessibleList(ctx, s.shoppingListRepo, shoppingListID); err != nil {
+		return nil, err
+	}
+	return s.memberRepo.GetByShoppingListID(ctx, shoppingListID)
+}
+
+// AddMember shares a shopping list with a user in a role.
+// The user the list belongs to is already its owner and cannot be added.
+func (s *ListMemberService) AddMember(
+	ctx context.Context,
+	shoppingListID, userID uuid.UUID,
+	role entities.ListRole,
+) (*entities.ListMember, error) {
+	if !role.IsValid() {
+		return nil, entities.ErrInvalidInput
+	}
+
+	list, err := getListWithRole(ctx, s.shoppingListRepo, shoppingListID, entities.ListRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	if _, isMember := list.RoleOf(userID); isMember {
+		return nil, entities.ErrDuplicateMember
+	}
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	member := entities.NewListMember(shoppingListID, userID, role)
+	if err := s.memberRepo.Create(ctx, member); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// UpdateMember changes the role of a member of a shopping list
+func (s *ListMemberService) UpdateMember(
+	ctx context.Context,
+	shoppingListID, userID uuid.UUID,
+	role entities.ListRole,
+) (*entities.ListMember, error) {
+	if !role.IsValid() {
+		return nil, entities.ErrInvalidInput
+	}
+
+	if _, err := getListWithRole(ctx, s.shoppingListRepo, shoppingListID, entities.ListRoleOwner); err != nil {
+		return nil, err
+	}
+
+	member, err := s.memberRepo.Get(ctx, shoppingListID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	member.Role = role
+	if err := s.memberRepo.Update(ctx, member); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// RemoveMember stops sharing a shopping list with a user.
+// Members may also remove themselves to leave a list shared with them.
+func (s *ListMemberService) RemoveMember(ctx context.Context, shoppingListID, userID uuid.UUID) error {
+	required := entities.ListRoleOwner
+	if caller, ok := CallerFromContext(ctx); ok && caller == userID {
+		required = entities.ListRoleViewer
+	}
+
+	if _, err := getListWithRole(ctx, s.shoppingListRepo, shoppingListID, required); err != nil {
+		return err
+	}
+
+	return s.memberRepo.Delete(ctx, shoppingListID, userID)
+}
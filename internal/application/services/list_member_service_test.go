@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// MockListMemberRepository is a mock implementation of ListMemberRepository
+type MockListMemberRepository struct {
+	mock.Mock
+}
+
+func (m *MockListMemberRepository) Create(ctx context.Context, member *entities.ListMember) error {
+	args := m.Called(ctx, member)
+	return args.Error(0)
+}
+
+func (m *MockListMemberRepository) Get(ctx context.Context, shoppingListID, userID uuid.UUID) (*entities.ListMember, error) {
+	args := m.Called(ctx, shoppingListID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ListMember), args.Error(1)
+}
+
+func (m *MockListMemberRepository) GetByShoppingListID(
+	ctx context.Context,
+	shoppingListID uuid.UUID,
+) ([]*entities.ListMember, error) {
+	args := m.Called(ctx, shoppingListID)
+	return args.Get(0).([]*entities.ListMember), args.Error(1)
+}
+
+func (m *MockListMemberRepository) Update(ctx context.Context, member *entities.ListMember) error {
+	args := m.Called(ctx, member)
+	return args.Error(0)
+}
+
+func (m *MockListMemberRepository) Delete(ctx context.Context, shoppingListID, userID uuid.UUID) error {
+	args := m.Called(ctx, shoppingListID, userID)
+	return args.Error(0)
+}
+
+// sharedListFixture is a list owned by one user and shared with an editor and a viewer
+type sharedListFixture struct {
+	list                  *entities.ShoppingList
+	owner, editor, viewer uuid.UUID
+}
+
+func newSharedListFixture() sharedListFixture {
+	f := sharedListFixture{owner: uuid.New(), editor: uuid.New(), viewer: uuid.New()}
+	f.list = &entities.ShoppingList{ID: uuid.New(), OwnerID: &f.owner, Name: "Groceries"}
+	f.list.Members = []entities.ListMember{
+		*entities.NewListMember(f.list.ID, f.editor, entities.ListRoleEditor),
+		*entities.NewListMember(f.list.ID, f.viewer, entities.ListRoleViewer),
+	}
+	return f
+}
+
+func TestListMemberService_AddMember(t *testing.T) {
+	f := newSharedListFixture()
+	newcomer := uuid.New()
+
+	tests := []struct {
+		name          string
+		caller        uuid.UUID
+		userID        uuid.UUID
+		role          entities.ListRole
+		mockSetup     func(*MockListMemberRepository, *MockUserRepository)
+		expectedError error
+	}{
+		{
+			name:   "owner shares the list",
+			caller: f.owner,
+			userID: newcomer,
+			role:   entities.ListRoleEditor,
+			mockSetup: func(members *MockListMemberRepository, users *MockUserRepository) {
+				users.On("GetByID", mock.Anything, newcomer).Return(&entities.User{ID: newcomer}, nil)
+				members.On("Create", mock.Anything, mock.AnythingOfType("*entities.ListMember")).Return(nil)
+			},
+		},
+		{
+			name:          "editors cannot manage members",
+			caller:        f.editor,
+			userID:        newcomer,
+			role:          entities.ListRoleViewer,
+			expectedError: entities.ErrForbidden,
+		},
+		{
+			name:          "strangers do not see the list",
+			caller:        newcomer,
+			userID:        uuid.New(),
+			role:          entities.ListRoleViewer,
+			expectedError: entities.ErrShoppingListNotFound,
+		},
+		{
+			name:          "rejects existing members",
+			caller:        f.owner,
+			userID:        f.viewer,
+			role:          entities.ListRoleEditor,
+			expectedError: entities.ErrDuplicateMember,
+		},
+		{
+			name:          "rejects the owner",
+			caller:        f.owner,
+			userID:        f.owner,
+			role:          entities.ListRoleEditor,
+			expectedError: entities.ErrDuplicateMember,
+		},
+		{
+			name:   "rejects unknown users",
+			caller: f.owner,
+			userID: newcomer,
+			role:   entities.ListRoleViewer,
+			mockSetup: func(members *MockListMemberRepository, users *MockUserRepository) {
+				users.On("GetByID", mock.Anything, newcomer).Return((*entities.User)(nil), entities.ErrUserNotFound)
+			},
+			expectedError: entities.ErrUserNotFound,
+		},
+		{
+			name:          "rejects unknown roles",
+			caller:        f.owner,
+			userID:        newcomer,
+			role:          "admin",
+			expectedError: entities.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shoppingListRepo := &MockShoppingListRepository{}
+			memberRepo := &MockListMemberRepository{}
+			userRepo := &MockUserRepository{}
+			shoppingListRepo.On("GetByID", mock.Anything, f.list.ID).Return(f.list, nil)
+			if tt.mockSetup != nil {
+				tt.mockSetup(memberRepo, userRepo)
+			}
+			service := NewListMemberService(shoppingListRepo, memberRepo, userRepo)
+
+			member, err := service.AddMember(WithCaller(context.Background(), tt.caller), f.list.ID, tt.userID, tt.role)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				memberRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.userID, member.UserID)
+			assert.Equal(t, tt.role, member.Role)
+			memberRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListMemberService_UpdateMember(t *testing.T) {
+	f := newSharedListFixture()
+	shoppingListRepo := &MockShoppingListRepository{}
+	memberRepo := &MockListMemberRepository{}
+	service := NewListMemberService(shoppingListRepo, memberRepo, &MockUserRepository{})
+	shoppingListRepo.On("GetByID", mock.Anything, f.list.ID).Return(f.list, nil)
+	viewer := entities.NewListMember(f.list.ID, f.viewer, entities.ListRoleViewer)
+	memberRepo.On("Get", mock.Anything, f.list.ID, f.viewer).Return(viewer, nil)
+	memberRepo.On("Get", mock.Anything, f.list.ID, mock.Anything).Return(nil, entities.ErrMemberNotFound)
+	memberRepo.On("Update", mock.Anything, viewer).Return(nil)
+
+	_, err := service.UpdateMember(WithCaller(context.Background(), f.editor), f.list.ID, f.viewer, entities.ListRoleEditor)
+	assert.Equal(t, entities.ErrForbidden, err)
+
+	_, err = service.UpdateMember(WithCaller(context.Background(), f.owner), f.list.ID, uuid.New(), entities.ListRoleEditor)
+	assert.Equal(t, entities.ErrMemberNotFound, err)
+
+	member, err := service.UpdateMember(WithCaller(context.Background(), f.owner), f.list.ID, f.viewer, entities.ListRoleEditor)
+	require.NoError(t, err)
+	assert.Equal(t, entities.ListRoleEditor, member.Role)
+	memberRepo.AssertNumberOfCalls(t, "Update", 1)
+}
+
+func TestListMemberService_RemoveMember(t *testing.T) {
+	f := newSharedListFixture()
+	shoppingListRepo := &MockShoppingListRepository{}
+	memberRepo := &MockListMemberRepository{}
+	service := NewListMemberService(shoppingListRepo, memberRepo, &MockUserRepository{})
+	shoppingListRepo.On("GetByID", mock.Anything, f.list.ID).Return(f.list, nil)
+	memberRepo.On("Delete", mock.Anything, f.list.ID, mock.Anything).Return(nil)
+
+	err := service.RemoveMember(WithCaller(context.Background(), f.viewer), f.list.ID, f.editor)
+	assert.Equal(t, entities.ErrForbidden, err)
+	memberRepo.AssertNotCalled(t, "Delete", mock.Anything, f.list.ID, f.editor)
+
+	err = service.RemoveMember(WithCaller(context.Background(), f.owner), f.list.ID, f.editor)
+	assert.NoError(t, err)
+
+	// Members may leave a list on their own
+	err = service.RemoveMember(WithCaller(context.Background(), f.viewer), f.list.ID, f.viewer)
+	assert.NoError(t, err)
+}
+
+func TestListMemberService_GetMembers(t *testing.T) {
+	f := newSharedListFixture()
+	shoppingListRepo := &MockShoppingListRepository{}
+	memberRepo := &MockListMemberRepository{}
+	service := NewListMemberService(shoppingListRepo, memberRepo, &MockUserRepository{})
+	shoppingListRepo.On("GetByID", mock.Anything, f.list.ID).Return(f.list, nil)
+	members := []*entities.ListMember{&f.list.Members[0], &f.list.Members[1]}
+	memberRepo.On("GetByShoppingListID", mock.Anything, f.list.ID).Return(members, nil)
+
+	got, err := service.GetMembers(WithCaller(context.Background(), f.viewer), f.list.ID)
+	require.NoError(t, err)
+	assert.Equal(t, members, got)
+
+	_, err = service.GetMembers(WithCaller(context.Background(), uuid.New()), f.list.ID)
+	assert.Equal(t, entities.ErrShoppingListNotFound, err)
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+)
+
+// Page sizes for paginated listings
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// listCursor is the decoded form of an opaque shopping list page cursor.
+// It records the sort it was issued for, since it is meaningless under any other.
+type listCursor struct {
+	Sort string `json:"sort"`
+	repositories.ShoppingListCursor
+}
+
+// parseSort splits a sort parameter such as "-updated_at" into its field and direction.
+// An empty parameter sorts by creation time, oldest first.
+func parseSort(sort string) (repositories.ShoppingListSortField, bool, error) {
+	if sort == "" {
+		return repositories.SortByCreatedAt, false, nil
+	}
+	field := repositories.ShoppingListSortField(strings.TrimPrefix(sort, "-"))
+	if !field.IsValid() {
+		return "", false, entities.ErrInvalidInput
+	}
+	return field, strings.HasPrefix(sort, "-"), nil
+}
+
+// encodeListCursor builds the cursor resuming a listing after the given shopping list
+func encodeListCursor(sort string, field repositories.ShoppingListSortField, last *entities.ShoppingList) string {
+	cursor := listCursor{Sort: sort, ShoppingListCursor: repositories.ShoppingListCursor{ID: last.ID}}
+	switch field {
+	case repositories.SortByName:
+		cursor.Name = last.Name
+	case repositories.SortByUpdatedAt:
+		cursor.Time = last.UpdatedAt
+	default:
+		cursor.Time = last.CreatedAt
+	}
+
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeListCursor reads a cursor issued by encodeListCursor for the same sort
+func decodeListCursor(encoded, sort string) (*repositories.ShoppingListCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, entities.ErrInvalidInput
+	}
+
+	var cursor listCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Sort != sort {
+		return nil, entities.ErrInvalidInput
+	}
+	return &cursor.ShoppingListCursor, nil
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+)
+
+// PantryItemInput holds the attributes of a pantry item.
+// An empty unit defaults to pieces.
+type PantryItemInput struct {
+	Name      string
+	Quantity  float64
+	Unit      entities.Unit
+	Location  string
+	ExpiresAt *time.Time
+}
+
+// ListPantryItemsInput filters the pantry; zero fields do not filter.
+// Location matches ignoring case, and ExpiresBefore keeps dated items expiring before it.
+type ListPantryItemsInput struct {
+	Location      string
+	ExpiresBefore *time.Time
+}
+
+// PantryService handles business logic for the pantry
+type PantryService struct {
+	pantryRepo repositories.PantryRepository
+}
+
+// NewPantryService creates a new pantry service
+func NewPantryService(pantryRepo repositories.PantryRepository) *PantryService {
+	return &PantryService{pantryRepo: pantryRepo}
+}
+
+// CreatePantryItem adds an item to the pantry
+func (s *PantryService) CreatePantryItem(ctx context.Context, input PantryItemInput) (*entities.PantryItem, error) {
+	unit, err := checkPantryInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	item := entities.NewPantryItem(input.Name, input.Quantity, unit)
+	item.Location = input.Location
+	item.ExpiresAt = input.ExpiresAt
+	item.OwnerID = callerScope(ctx)
+	if err := s.pantryRepo.Create(ctx, item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// GetPantryItem retrieves a pantry item by ID
+func (s *PantryService) GetPantryItem(ctx context.Context, id uuid.UUID) (*entities.PantryItem, error) {
+	return s.getAccessiblePantryItem(ctx, id)
+}
+
+// GetPantryItems retrieves the caller's pantry items matching the input, soonest to expire first
+func (s *PantryService) GetPantryItems(ctx context.Context, input ListPantryItemsInput) ([]*entities.PantryItem, error) {
+	return s.pantryRepo.GetAll(ctx, repositories.PantryQuery{
+		Location:      input.Location,
+		ExpiresBefore: input.ExpiresBefore,
+		OwnerID:       callerScope(ctx),
+	})
+}
+
+// UpdatePantryItem replaces the attributes of a pantry item
+func (s *PantryService) UpdatePantryItem(ctx context.Context, id uuid.UUID, input PantryItemInput) (*entities.PantryItem, error) {
+	unit, err := checkPantryInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := s.getAccessiblePantryItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	item.Name = input.Name
+	item.Quantity = input.Quantity
+	item.Unit = unit
+	item.Location = input.Location
+	item.ExpiresAt = input.ExpiresAt
+	if err := s.pantryRepo.Update(ctx, item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// DeletePantryItem removes an item from the pantry
+func (s *PantryService) DeletePantryItem(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.getAccessiblePantryItem(ctx, id); err != nil {
+		return err
+	}
+	return s.pantryRepo.Delete(ctx, id)
+}
+
+// getAccessiblePantryItem retrieves a pantry item the caller owns; other users' items are reported as not found
+func (s *PantryService) getAccessiblePantryItem(ctx context.Context, id uuid.UUID) (*entities.PantryItem, error) {
+	item, err := s.pantryRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !isVisibleTo(ctx, item.OwnerID) {
+		return nil, entities.ErrPantryItemNotFound
+	}
+	return item, nil
+}
+
+// checkPantryInput validates the attributes of a pantry item and returns its unit
+func checkPantryInput(input PantryItemInput) (entities.Unit, error) {
+	if input.Name == "" || utf8.RuneCountInString(input.Location) > entities.MaxPantryLocationLength {
+		return "", entities.ErrInvalidInput
+	}
+
+	unit := input.Unit
+	if unit == "" {
+		unit = entities.UnitPieces
+	}
+	if err := entities.ValidateOnHandQuantity(input.Quantity, unit); err != nil {
+		return "", err
+	}
+	return unit, nil
+}
+
+// stockPantry adds a bought item to the pantry, topping up an undated pantry item
+// holding the same product or adding a new one
+func stockPantry(ctx context.Context, pantryRepo repositories.PantryRepository, item *entities.Item) (*entities.PantryItem, error) {
+	stock, err := pantryRepo.GetAll(ctx, repositories.PantryQuery{OwnerID: callerScope(ctx)})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pantryItem := range stock {
+		if pantryItem.CanStock(item.Name, item.Unit) {
+			pantryItem.Restock(item.Quantity)
+			if err := pantryRepo.Update(ctx, pantryItem); err != nil {
+				return nil, err
+			}
+			return pantryItem, nil
+		}
+	}
+
+	pantryItem := entities.NewPantryItem(item.Name, item.Quantity, item.Unit)
+	pantryItem.OwnerID = callerScope(ctx)
+	if err := pantryRepo.Create(ctx, pantryItem); err != nil {
+		return nil, err
+	}
+	return pantryItem, nil
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+)
+
+// MockPantryRepository is a mock implementation of PantryRepository
+type MockPantryRepository struct {
+	mock.Mock
+}
+
+func (m *MockPantryRepository) Create(ctx context.Context, item *entities.PantryItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockPantryRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.PantryItem, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*entities.PantryItem), args.Error(1)
+}
+
+func (m *MockPantryRepository) GetAll(ctx context.Context, query repositories.PantryQuery) ([]*entities.PantryItem, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).([]*entities.PantryItem), args.Error(1)
+}
+
+func (m *MockPantryRepository) Update(ctx context.Context, item *entities.PantryItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockPantryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestPantryService_CreatePantryItem(t *testing.T) {
+	expiresAt := time.Date(2026, 10, 30, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		input         PantryItemInput
+		expectedUnit  entities.Unit
+		expectedError error
+	}{
+		{
+			name:         "defaults to pieces",
+			input:        PantryItemInput{Name: "Eggs", Quantity: 6, Location: "fridge", ExpiresAt: &expiresAt},
+			expectedUnit: entities.UnitPieces,
+		},
+		{name: "records an empty stock", input: PantryItemInput{Name: "Rice", Unit: entities.UnitKilogram}, expectedUnit: entities.UnitKilogram},
+		{name: "missing name", input: PantryItemInput{Quantity: 1}, expectedError: entities.ErrInvalidInput},
+		{name: "negative quantity", input: PantryItemInput{Name: "Rice", Quantity: -1}, expectedError: entities.ErrInvalidInput},
+		{name: "fractional pieces", input: PantryItemInput{Name: "Eggs", Quantity: 1.5}, expectedError: entities.ErrInvalidInput},
+		{name: "unknown unit", input: PantryItemInput{Name: "Rice", Unit: "cups"}, expectedError: entities.ErrInvalidInput},
+		{
+			name:          "location too long",
+			input:         PantryItemInput{Name: "Rice", Quantity: 1, Location: string(make([]byte, entities.MaxPantryLocationLength+1))},
+			expectedError: entities.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockPantryRepository{}
+			service := NewPantryService(repo)
+			if tt.expectedError == nil {
+				repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			}
+
+			result, err := service.CreatePantryItem(context.Background(), tt.input)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.input.Name, result.Name)
+			assert.Equal(t, tt.input.Quantity, result.Quantity)
+			assert.Equal(t, tt.expectedUnit, result.Unit)
+			assert.Equal(t, tt.input.Location, result.Location)
+			assert.Equal(t, tt.input.ExpiresAt, result.ExpiresAt)
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPantryService_GetPantryItems(t *testing.T) {
+	repo := &MockPantryRepository{}
+	service := NewPantryService(repo)
+
+	expiresBefore := time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC)
+	items := []*entities.PantryItem{entities.NewPantryItem("Milk", 1, entities.UnitLiter)}
+	repo.On("GetAll", mock.Anything, repositories.PantryQuery{Location: "fridge", ExpiresBefore: &expiresBefore}).Return(items, nil)
+
+	result, err := service.GetPantryItems(context.Background(), ListPantryItemsInput{Location: "fridge", ExpiresBefore: &expiresBefore})
+
+	require.NoError(t, err)
+	assert.Equal(t, items, result)
+	repo.AssertExpectations(t)
+}
+
+func TestPantryService_UpdatePantryItem(t *testing.T) {
+	repo := &MockPantryRepository{}
+	service := NewPantryService(repo)
+
+	existing := entities.NewPantryItem("Milk", 2, entities.UnitLiter)
+	repo.On("GetByID", mock.Anything, existing.ID).Return(existing, nil)
+	repo.On("Update", mock.Anything, existing).Return(nil)
+
+	result, err := service.UpdatePantryItem(context.Background(), existing.ID, PantryItemInput{Name: "Milk", Quantity: 0.5, Unit: entities.UnitLiter, Location: "fridge"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, result.Quantity)
+	assert.Equal(t, "fridge", result.Location)
+
+	missing := uuid.New()
+	repo.On("GetByID", mock.Anything, missing).Return((*entities.PantryItem)(nil), entities.ErrPantryItemNotFound)
+	_, err = service.UpdatePantryItem(context.Background(), missing, PantryItemInput{Name: "Milk", Quantity: 1})
+	assert.Equal(t, entities.ErrPantryItemNotFound, err)
+	repo.AssertNumberOfCalls(t, "Update", 1)
+}
+
+func TestStockPantry(t *testing.T) {
+	expiresAt := time.Date(2026, 10, 30, 0, 0, 0, 0, time.UTC)
+	dated := entities.NewPantryItem("Milk", 1, entities.UnitLiter)
+	dated.ExpiresAt = &expiresAt
+	undated := entities.NewPantryItem("milk", 0.5, entities.UnitLiter)
+	inPacks := entities.NewPantryItem("Milk", 2, entities.UnitPack)
+
+	t.Run("tops up an undated item of the same product", func(t *testing.T) {
+		repo := &MockPantryRepository{}
+		repo.On("GetAll", mock.Anything, repositories.PantryQuery{}).Return([]*entities.PantryItem{dated, undated, inPacks}, nil)
+		repo.On("Update", mock.Anything, undated).Return(nil)
+
+		item := entities.NewItem("Milk", 2)
+		item.Unit = entities.UnitLiter
+		stocked, err := stockPantry(context.Background(), repo, item)
+
+		require.NoError(t, err)
+		assert.Equal(t, undated.ID, stocked.ID)
+		assert.Equal(t, 2.5, stocked.Quantity)
+		assert.Equal(t, 1.0, dated.Quantity)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("adds a new item otherwise", func(t *testing.T) {
+		repo := &MockPantryRepository{}
+		repo.On("GetAll", mock.Anything, repositories.PantryQuery{}).Return([]*entities.PantryItem{dated, inPacks}, nil)
+		repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		item := entities.NewItem("Milk", 2)
+		item.Unit = entities.UnitLiter
+		stocked, err := stockPantry(context.Background(), repo, item)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Milk", stocked.Name)
+		assert.Equal(t, 2.0, stocked.Quantity)
+		assert.Equal(t, entities.UnitLiter, stocked.Unit)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
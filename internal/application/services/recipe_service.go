@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+)
+
+// MaxServingsFactor is the largest factor a recipe may be scaled by
+const MaxServingsFactor = 100
+
+// ImportRecipeInput holds an ingredient block with one ingredient per line.
+// ServingsFactor scales every quantity; zero means 1.
+type ImportRecipeInput struct {
+	Text           string
+	ServingsFactor float64
+}
+
+// ImportedIngredient is the outcome of an ingredient line of an imported recipe.
+// Created reports whether a new item was added rather than merged into an existing one.
+type ImportedIngredient struct {
+	Line       string
+	Ingredient entities.Ingredient
+	Item       *entities.Item
+	Created    bool
+	Err        error
+}
+
+// RecipeImport holds the outcome of a recipe import: the ingredient lines that were
+// understood, in order, and the other non-blank lines
+type RecipeImport struct {
+	Ingredients []ImportedIngredient
+	Unparsed    []string
+}
+
+// RecipeService handles business logic for recipes
+type RecipeService struct {
+	shoppingListRepo repositories.ShoppingListRepository
+	itemService      ItemServiceInterface
+}
+
+// NewRecipeService creates a new recipe service
+func NewRecipeService(shoppingListRepo repositories.ShoppingListRepository, itemService ItemServiceInterface) *RecipeService {
+	return &RecipeService{
+		shoppingListRepo: shoppingListRepo,
+		itemService:      itemService,
+	}
+}
+
+// ImportRecipe adds the ingredients of a recipe to a shopping list, scaled by the servings factor.
+// Ingredients already on the list as open items in the same unit are merged into them; an
+// ingredient that cannot be added fails on its own without holding back the others.
+func (s *RecipeService) ImportRecipe(ctx context.Context, shoppingListID uuid.UUID, input ImportRecipeInput) (*RecipeImport, error) {
+	factor := input.ServingsFactor
+	if factor == 0 {
+		factor = 1
+	}
+	if factor < 0 || factor > MaxServingsFactor || math.IsNaN(factor) {
+		return nil, entities.ErrInvalidInput
+	}
+
+	if _, err := getEditableList(ctx, s.shoppingListRepo, shoppingListID); err != nil {
+		return nil, err
+	}
+
+	result := &RecipeImport{}
+	var operations []ItemOperation
+	for _, line := range strings.Split(input.Text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		ingredient, err := entities.ParseIngredient(line)
+		if err != nil {
+			result.Unparsed = append(result.Unparsed, line)
+			continue
+		}
+
+		ingredient = ingredient.Scale(factor)
+		result.Ingredients = append(result.Ingredients, ImportedIngredient{Line: line, Ingredient: ingredient})
+		operations = append(operations, ItemOperation{
+			Type: ItemOpCreate,
+			Create: CreateItemInput{
+				Name:        ingredient.Name,
+				Quantity:    ingredient.Quantity,
+				Unit:        ingredient.Unit,
+				OnDuplicate: DuplicateMerge,
+			},
+		})
+	}
+	if len(operations) == 0 {
+		return result, nil
+	}
+
+	batch, err := s.itemService.BatchItems(ctx, shoppingListID, BatchBestEffort, operations)
+	if err != nil {
+		return nil, err
+	}
+	for i, outcome := range batch.Results {
+		result.Ingredients[i].Item = outcome.Item
+		result.Ingredients[i].Created = outcome.Created
+		result.Ingredients[i].Err = outcome.Err
+	}
+
+	return result, nil
+}
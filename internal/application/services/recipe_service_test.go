@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+)
+
+// newTestRecipeService wires a recipe service to an item service over mock repositories
+func newTestRecipeService() (*RecipeService, *MockItemRepository, *MockShoppingListRepository) {
+	itemRepo := &MockItemRepository{}
+	shoppingListRepo := &MockShoppingListRepository{}
+	itemService := NewItemService(itemRepo, shoppingListRepo, &MockCategoryRepository{}, &MockPantryRepository{}, passThroughTransactor{})
+	return NewRecipeService(shoppingListRepo, itemService), itemRepo, shoppingListRepo
+}
+
+func TestRecipeService_ImportRecipe(t *testing.T) {
+	service, itemRepo, shoppingListRepo := newTestRecipeService()
+	list := entities.NewShoppingList("Pancakes", "")
+	flour := newListItem("Flour", 100, entities.UnitMilliliter)
+	flour.ShoppingListID = list.ID
+	sugar := newListItem("Sugar", 1, entities.UnitKilogram)
+	sugar.ShoppingListID = list.ID
+	shoppingListRepo.On("GetByID", mock.Anything, list.ID).Return(list, nil)
+	itemRepo.On("GetByShoppingListID", mock.Anything, list.ID).Return([]*entities.Item{flour, sugar}, nil)
+	var applied repositories.ItemBatch
+	itemRepo.On("ApplyBatch", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		applied = args.Get(1).(repositories.ItemBatch)
+	}).Return(nil)
+
+	text := "2 cups flour\n\n3 eggs\nsalt to taste\n100 g sugar\n1 egg"
+	result, err := service.ImportRecipe(context.Background(), list.ID, ImportRecipeInput{Text: text, ServingsFactor: 2})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"salt to taste"}, result.Unparsed)
+	require.Len(t, result.Ingredients, 4)
+
+	merged := result.Ingredients[0]
+	require.NoError(t, merged.Err)
+	assert.False(t, merged.Created)
+	assert.Equal(t, flour.ID, merged.Item.ID)
+	assert.Equal(t, 1060.0, merged.Item.Quantity)
+
+	eggs := result.Ingredients[1]
+	require.NoError(t, eggs.Err)
+	assert.True(t, eggs.Created)
+	assert.Equal(t, 6.0, eggs.Item.Quantity)
+
+	// Sugar is already listed in kilograms, so the grams cannot be merged
+	assert.Equal(t, entities.ErrDuplicateItem, result.Ingredients[2].Err)
+	assert.Nil(t, result.Ingredients[2].Item)
+
+	// "1 egg" does not match "eggs" by name and is added on its own
+	assert.True(t, result.Ingredients[3].Created)
+	assert.Equal(t, "egg", result.Ingredients[3].Item.Name)
+	assert.Equal(t, 2.0, result.Ingredients[3].Item.Quantity)
+
+	assert.Len(t, applied.Create, 2)
+	assert.Len(t, applied.Update, 1)
+}
+
+func TestRecipeService_ImportRecipe_NothingUnderstood(t *testing.T) {
+	service, itemRepo, shoppingListRepo := newTestRecipeService()
+	list := entities.NewShoppingList("Soup", "")
+	shoppingListRepo.On("GetByID", mock.Anything, list.ID).Return(list, nil)
+
+	result, err := service.ImportRecipe(context.Background(), list.ID, ImportRecipeInput{Text: "salt\npepper to taste"})
+
+	require.NoError(t, err)
+	assert.Empty(t, result.Ingredients)
+	assert.Equal(t, []string{"salt", "pepper to taste"}, result.Unparsed)
+	itemRepo.AssertNotCalled(t, "ApplyBatch", mock.Anything, mock.Anything)
+}
+
+func TestRecipeService_ImportRecipe_Errors(t *testing.T) {
+	service, _, shoppingListRepo := newTestRecipeService()
+	missing := uuid.New()
+	shoppingListRepo.On("GetByID", mock.Anything, missing).Return((*entities.ShoppingList)(nil), entities.ErrShoppingListNotFound)
+
+	_, err := service.ImportRecipe(context.Background(), missing, ImportRecipeInput{Text: "3 eggs"})
+	assert.Equal(t, entities.ErrShoppingListNotFound, err)
+
+	for _, factor := range []float64{-1, MaxServingsFactor + 1} {
+		_, err := service.ImportRecipe(context.Background(), missing, ImportRecipeInput{Text: "3 eggs", ServingsFactor: factor})
+		assert.Equal(t, entities.ErrInvalidInput, err)
+	}
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+)
+
+// StapleInput holds the attributes of a staple.
+// An empty unit defaults to pieces.
+type StapleInput struct {
+	Name           string
+	Unit           entities.Unit
+	OnHand         float64
+	MinQuantity    float64
+	ShoppingListID uuid.UUID
+}
+
+// ReplenishmentService keeps staples in stock by putting what is missing on their shopping list
+type ReplenishmentService struct {
+	stapleRepo       repositories.StapleRepository
+	shoppingListRepo repositories.ShoppingListRepository
+	itemService      ItemServiceInterface
+	transactor       repositories.Transactor
+}
+
+// NewReplenishmentService creates a new replenishment service
+func NewReplenishmentService(
+	stapleRepo repositories.StapleRepository,
+	shoppingListRepo repositories.ShoppingListRepository,
+	itemService ItemServiceInterface,
+	transactor repositories.Transactor,
+) *ReplenishmentService {
+	return &ReplenishmentService{
+		stapleRepo:       stapleRepo,
+		shoppingListRepo: shoppingListRepo,
+		itemService:      itemService,
+		transactor:       transactor,
+	}
+}
+
+// CreateStaple registers a staple, replenishing it right away when it is already low
+func (s *ReplenishmentService) CreateStaple(ctx context.Context, input StapleInput) (*entities.Staple, error) {
+	unit, err := s.checkStapleInput(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	staple := entities.NewStaple(input.Name, unit, input.OnHand, input.MinQuantity, input.ShoppingListID)
+	staple.OwnerID = callerScope(ctx)
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.stapleRepo.Create(ctx, staple); err != nil {
+			return err
+		}
+		return s.replenish(ctx, staple)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return staple, nil
+}
+
+// GetStaple retrieves a staple by ID
+func (s *ReplenishmentService) GetStaple(ctx context.Context, id uuid.UUID) (*entities.Staple, error) {
+	return s.getAccessibleStaple(ctx, id)
+}
+
+// GetAllStaples retrieves the caller's staples ordered by name
+func (s *ReplenishmentService) GetAllStaples(ctx context.Context) ([]*entities.Staple, error) {
+	return s.stapleRepo.GetAll(ctx, callerScope(ctx))
+}
+
+// GetLowStaples retrieves the caller's staples whose quantity on hand is below their minimum
+func (s *ReplenishmentService) GetLowStaples(ctx context.Context) ([]*entities.Staple, error) {
+	return s.stapleRepo.GetLow(ctx, callerScope(ctx))
+}
+
+// UpdateStaple replaces the attributes of a staple, replenishing it when it is low
+func (s *ReplenishmentService) UpdateStaple(ctx context.Context, id uuid.UUID, input StapleInput) (*entities.Staple, error) {
+	unit, err := s.checkStapleInput(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	staple, err := s.getAccessibleStaple(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	staple.Name = input.Name
+	staple.Unit = unit
+	staple.OnHand = input.OnHand
+	staple.MinQuantity = input.MinQuantity
+	staple.ShoppingListID = input.ShoppingListID
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.stapleRepo.Update(ctx, staple); err != nil {
+			return err
+		}
+		return s.replenish(ctx, staple)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return staple, nil
+}
+
+// DeleteStaple deletes a staple; items already on its shopping list stay there
+func (s *ReplenishmentService) DeleteStaple(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.getAccessibleStaple(ctx, id); err != nil {
+		return err
+	}
+	return s.stapleRepo.Delete(ctx, id)
+}
+
+// getAccessibleStaple retrieves a staple the caller owns; staples of other users are reported as not found
+func (s *ReplenishmentService) getAccessibleStaple(ctx context.Context, id uuid.UUID) (*entities.Staple, error) {
+	staple, err := s.stapleRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !isVisibleTo(ctx, staple.OwnerID) {
+		return nil, entities.ErrStapleNotFound
+	}
+	return staple, nil
+}
+
+// replenish makes sure the shopping list of a low staple asks for at least its shortfall.
+// An open item for the staple is topped up rather than duplicated, and left alone when it
+// already asks for enough or is measured in another unit.
+func (s *ReplenishmentService) replenish(ctx context.Context, staple *entities.Staple) error {
+	shortfall := staple.Shortfall()
+	if shortfall == 0 {
+		return nil
+	}
+
+	items, err := s.itemService.GetItemsByShoppingListID(ctx, staple.ShoppingListID)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if !item.IsDuplicateOf(staple.Name) {
+			continue
+		}
+		if item.Unit != staple.Unit || item.Quantity >= shortfall {
+			return nil
+		}
+		_, err := s.itemService.PatchItem(ctx, item.ID, ItemPatch{Quantity: &shortfall})
+		return err
+	}
+
+	_, _, err = s.itemService.CreateItem(ctx, staple.ShoppingListID, CreateItemInput{
+		Name:     staple.Name,
+		Quantity: shortfall,
+		Unit:     staple.Unit,
+	})
+	return err
+}
+
+// checkStapleInput validates the attributes of a staple and returns its unit
+func (s *ReplenishmentService) checkStapleInput(ctx context.Context, input StapleInput) (entities.Unit, error) {
+	if input.Name == "" {
+		return "", entities.ErrInvalidInput
+	}
+
+	unit := input.Unit
+	if unit == "" {
+		unit = entities.UnitPieces
+	}
+	if err := entities.ValidateOnHandQuantity(input.OnHand, unit); err != nil {
+		return "", err
+	}
+	if err := entities.ValidateQuantity(input.MinQuantity, unit); err != nil {
+		return "", err
+	}
+
+	if _, err := getEditableList(ctx, s.shoppingListRepo, input.ShoppingListID); err != nil {
+		return "", err
+	}
+	return unit, nil
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// MockStapleRepository is a mock implementation of StapleRepository
+type MockStapleRepository struct {
+	mock.Mock
+}
+
+func (m *MockStapleRepository) Create(ctx context.Context, staple *entities.Staple) error {
+	args := m.Called(ctx, staple)
+	return args.Error(0)
+}
+
+func (m *MockStapleRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Staple, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*entities.Staple), args.Error(1)
+}
+
+func (m *MockStapleRepository) GetAll(ctx context.Context, ownerID *uuid.UUID) ([]*entities.Staple, error) {
+	args := m.Called(ctx, ownerID)
+	return args.Get(0).([]*entities.Staple), args.Error(1)
+}
+
+func (m *MockStapleRepository) GetLow(ctx context.Context, ownerID *uuid.UUID) ([]*entities.Staple, error) {
+	args := m.Called(ctx, ownerID)
+	return args.Get(0).([]*entities.Staple), args.Error(1)
+}
+
+func (m *MockStapleRepository) Update(ctx context.Context, staple *entities.Staple) error {
+	args := m.Called(ctx, staple)
+	return args.Error(0)
+}
+
+func (m *MockStapleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// newTestReplenishmentService wires a replenishment service to an item service over mock repositories
+func newTestReplenishmentService() (*ReplenishmentService, *MockStapleRepository, *MockItemRepository, *MockShoppingListRepository) {
+	stapleRepo := &MockStapleRepository{}
+	itemRepo := &MockItemRepository{}
+	shoppingListRepo := &MockShoppingListRepository{}
+	itemService := NewItemService(itemRepo, shoppingListRepo, &MockCategoryRepository{}, &MockPantryRepository{}, passThroughTransactor{})
+	service := NewReplenishmentService(stapleRepo, shoppingListRepo, itemService, passThroughTransactor{})
+	return service, stapleRepo, itemRepo, shoppingListRepo
+}
+
+// newListItem creates an open item of a shopping list measured in a unit
+func newListItem(name string, quantity float64, unit entities.Unit) *entities.Item {
+	item := entities.NewItem(name, quantity)
+	item.Unit = unit
+	return item
+}
+
+func TestReplenishmentService_CreateStaple_Validation(t *testing.T) {
+	listID := uuid.New()
+
+	tests := []struct {
+		name  string
+		input StapleInput
+	}{
+		{name: "missing name", input: StapleInput{MinQuantity: 1, ShoppingListID: listID}},
+		{name: "negative stock", input: StapleInput{Name: "Coffee", OnHand: -1, MinQuantity: 1, ShoppingListID: listID}},
+		{name: "no minimum", input: StapleInput{Name: "Coffee", ShoppingListID: listID}},
+		{name: "fractional pieces", input: StapleInput{Name: "Eggs", MinQuantity: 2.5, ShoppingListID: listID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, stapleRepo, _, _ := newTestReplenishmentService()
+
+			result, err := service.CreateStaple(context.Background(), tt.input)
+
+			assert.Nil(t, result)
+			assert.Equal(t, entities.ErrInvalidInput, err)
+			stapleRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestReplenishmentService_CreateStaple_UnknownList(t *testing.T) {
+	service, stapleRepo, _, shoppingListRepo := newTestReplenishmentService()
+	listID := uuid.New()
+	shoppingListRepo.On("GetByID", mock.Anything, listID).Return((*entities.ShoppingList)(nil), entities.ErrShoppingListNotFound)
+
+	_, err := service.CreateStaple(context.Background(), StapleInput{Name: "Coffee", MinQuantity: 1, ShoppingListID: listID})
+
+	assert.Equal(t, entities.ErrShoppingListNotFound, err)
+	stapleRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestReplenishmentService_CreateStaple_InStock(t *testing.T) {
+	service, stapleRepo, itemRepo, shoppingListRepo := newTestReplenishmentService()
+	list := entities.NewShoppingList("Groceries", "")
+	shoppingListRepo.On("GetByID", mock.Anything, list.ID).Return(list, nil)
+	stapleRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	staple, err := service.CreateStaple(context.Background(), StapleInput{
+		Name: "Coffee", Unit: entities.UnitPack, OnHand: 2, MinQuantity: 1, ShoppingListID: list.ID,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, staple.IsLow())
+	itemRepo.AssertNotCalled(t, "GetByShoppingListID", mock.Anything, mock.Anything)
+}
+
+func TestReplenishmentService_UpdateStaple_Replenishes(t *testing.T) {
+	tests := []struct {
+		name             string
+		existing         []*entities.Item
+		expectedCreate   bool
+		expectedQuantity float64
+	}{
+		{name: "adds the shortfall", expectedCreate: true, expectedQuantity: 3},
+		{
+			name:             "tops up an open item",
+			existing:         []*entities.Item{newListItem("coffee", 1, entities.UnitPack)},
+			expectedQuantity: 3,
+		},
+		{
+			name:             "leaves an item asking for enough",
+			existing:         []*entities.Item{newListItem("Coffee", 5, entities.UnitPack)},
+			expectedQuantity: 5,
+		},
+		{
+			name:             "leaves an item in another unit",
+			existing:         []*entities.Item{newListItem("Coffee", 500, entities.UnitGram)},
+			expectedQuantity: 500,
+		},
+		{
+			name: "ignores completed items",
+			existing: []*entities.Item{func() *entities.Item {
+				item := newListItem("Coffee", 1, entities.UnitPack)
+				item.MarkCompleted()
+				return item
+			}()},
+			expectedCreate:   true,
+			expectedQuantity: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, stapleRepo, itemRepo, shoppingListRepo := newTestReplenishmentService()
+			list := entities.NewShoppingList("Groceries", "")
+			staple := entities.NewStaple("Coffee", entities.UnitPack, 4, 4, list.ID)
+			shoppingListRepo.On("GetByID", mock.Anything, list.ID).Return(list, nil)
+			stapleRepo.On("GetByID", mock.Anything, staple.ID).Return(staple, nil)
+			stapleRepo.On("Update", mock.Anything, staple).Return(nil)
+			itemRepo.On("GetByShoppingListID", mock.Anything, list.ID).Return(tt.existing, nil)
+			for _, item := range tt.existing {
+				itemRepo.On("GetByID", mock.Anything, item.ID).Return(item, nil).Maybe()
+			}
+			itemRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Maybe()
+			var created *entities.Item
+			itemRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				created = args.Get(1).(*entities.Item)
+			}).Return(nil).Maybe()
+
+			_, err := service.UpdateStaple(context.Background(), staple.ID, StapleInput{
+				Name: "Coffee", Unit: entities.UnitPack, OnHand: 1, MinQuantity: 4, ShoppingListID: list.ID,
+			})
+
+			require.NoError(t, err)
+			if tt.expectedCreate {
+				require.NotNil(t, created)
+				assert.Equal(t, "Coffee", created.Name)
+				assert.Equal(t, list.ID, created.ShoppingListID)
+				assert.Equal(t, tt.expectedQuantity, created.Quantity)
+				return
+			}
+			assert.Nil(t, created)
+			assert.Equal(t, tt.expectedQuantity, tt.existing[0].Quantity)
+		})
+	}
+}
+
+func TestReplenishmentService_UpdateStaple_NotFound(t *testing.T) {
+	service, stapleRepo, _, shoppingListRepo := newTestReplenishmentService()
+	list := entities.NewShoppingList("Groceries", "")
+	id := uuid.New()
+	shoppingListRepo.On("GetByID", mock.Anything, list.ID).Return(list, nil)
+	stapleRepo.On("GetByID", mock.Anything, id).Return((*entities.Staple)(nil), entities.ErrStapleNotFound)
+
+	_, err := service.UpdateStaple(context.Background(), id, StapleInput{Name: "Coffee", MinQuantity: 1, ShoppingListID: list.ID})
+
+	assert.Equal(t, entities.ErrStapleNotFound, err)
+}
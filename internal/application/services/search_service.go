@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+)
+
+// MaxSearchLength is the longest search text accepted, in characters
+const MaxSearchLength = 200
+
+// SearchService handles searching across shopping lists and items
+type SearchService struct {
+	searchRepo repositories.SearchRepository
+}
+
+// NewSearchService creates a new search service
+func NewSearchService(searchRepo repositories.SearchRepository) *SearchService {
+	return &SearchService{searchRepo: searchRepo}
+}
+
+// Search returns the shopping lists and items best matching a text, best first.
+// A zero limit returns DefaultPageSize hits.
+func (s *SearchService) Search(ctx context.Context, text string, limit int) ([]entities.SearchHit, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || utf8.RuneCountInString(text) > MaxSearchLength {
+		return nil, entities.ErrInvalidInput
+	}
+	if limit == 0 {
+		limit = DefaultPageSize
+	}
+	if limit < 0 || limit > MaxPageSize {
+		return nil, entities.ErrInvalidInput
+	}
+
+	return s.searchRepo.Search(ctx, repositories.SearchQuery{Text: text, Limit: limit, AccessibleTo: callerScope(ctx)})
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+)
+
+// MockSearchRepository is a mock implementation of SearchRepository
+type MockSearchRepository struct {
+	mock.Mock
+}
+
+func (m *MockSearchRepository) Search(ctx context.Context, query repositories.SearchQuery) ([]entities.SearchHit, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).([]entities.SearchHit), args.Error(1)
+}
+
+func TestSearchService_Search(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		limit         int
+		expectedQuery *repositories.SearchQuery
+		expectedError error
+	}{
+		{
+			name:          "defaults the limit",
+			text:          "  batteries ",
+			expectedQuery: &repositories.SearchQuery{Text: "batteries", Limit: DefaultPageSize},
+		},
+		{
+			name:          "keeps the limit",
+			text:          "batteries",
+			limit:         5,
+			expectedQuery: &repositories.SearchQuery{Text: "batteries", Limit: 5},
+		},
+		{name: "empty text", text: "   ", expectedError: entities.ErrInvalidInput},
+		{name: "text too long", text: strings.Repeat("a", MaxSearchLength+1), expectedError: entities.ErrInvalidInput},
+		{name: "negative limit", text: "batteries", limit: -1, expectedError: entities.ErrInvalidInput},
+		{name: "limit too large", text: "batteries", limit: MaxPageSize + 1, expectedError: entities.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockSearchRepository{}
+			service := NewSearchService(repo)
+			hits := []entities.SearchHit{{Kind: entities.SearchHitList, Rank: 1}}
+			if tt.expectedQuery != nil {
+				repo.On("Search", mock.Anything, *tt.expectedQuery).Return(hits, nil)
+			}
+
+			result, err := service.Search(context.Background(), tt.text, tt.limit)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				repo.AssertNotCalled(t, "Search", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, hits, result)
+			repo.AssertExpectations(t)
+		})
+	}
+}
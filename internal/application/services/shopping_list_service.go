@@ -3,33 +3,121 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
 	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
 )
 
+// ShoppingListInput holds the attributes of a shopping list.
+// Budget is expressed in the minor unit of Currency and requires it.
+// Version, when set on update, must match the current version of the list.
+type ShoppingListInput struct {
+	Name        string
+	Description string
+	Budget      *int64
+	Currency    string
+	Version     *int
+}
+
+// ShoppingListPatch holds the attributes to change on an existing shopping list;
+// nil fields are left as they are. ClearBudget removes the budget.
+type ShoppingListPatch struct {
+	Name        *string
+	Description *string
+	Budget      *int64
+	ClearBudget bool
+	Currency    *string
+	Version     *int
+}
+
+// ListInclude selects what a listing of shopping lists loads besides the lists themselves
+type ListInclude string
+
+// Supported list includes
+const (
+	IncludeItems  ListInclude = "items"
+	IncludeCounts ListInclude = "counts"
+	IncludeNone   ListInclude = "none"
+)
+
+// IsValid reports whether the include is supported; the empty include means items
+func (i ListInclude) IsValid() bool {
+	switch i {
+	case "", IncludeItems, IncludeCounts, IncludeNone:
+		return true
+	}
+	return false
+}
+
+// ListShoppingListsInput selects a page of shopping lists.
+// Sort names a sort field, prefixed with "-" for descending order, and Cursor
+// is the NextCursor of the previous page. A zero Limit uses DefaultPageSize.
+type ListShoppingListsInput struct {
+	Limit        int
+	Cursor       string
+	Sort         string
+	NameContains string
+	UpdatedSince *time.Time
+	Include      ListInclude
+}
+
+// ShoppingListPage is a page of shopping lists; NextCursor is empty on the last page.
+// ItemCounts is only set when the listing includes counts.
+type ShoppingListPage struct {
+	Lists      []*entities.ShoppingList
+	ItemCounts map[uuid.UUID]entities.ItemCounts
+	Limit      int
+	NextCursor string
+}
+
+// CloneShoppingListInput controls how a shopping list is copied.
+// An empty Name keeps the name of the source list.
+type CloneShoppingListInput struct {
+	Name            string
+	ResetCompletion bool
+	IncompleteOnly  bool
+}
+
 // ShoppingListService handles business logic for shopping lists
 type ShoppingListService struct {
 	shoppingListRepo repositories.ShoppingListRepository
 	itemRepo         repositories.ItemRepository
+	categoryRepo     repositories.CategoryRepository
+	transactor       repositories.Transactor
 }
 
 // NewShoppingListService creates a new shopping list service
-func NewShoppingListService(shoppingListRepo repositories.ShoppingListRepository, itemRepo repositories.ItemRepository) *ShoppingListService {
+func NewShoppingListService(
+	shoppingListRepo repositories.ShoppingListRepository,
+	itemRepo repositories.ItemRepository,
+	categoryRepo repositories.CategoryRepository,
+	transactor repositories.Transactor,
+) *ShoppingListService {
 	return &ShoppingListService{
 		shoppingListRepo: shoppingListRepo,
 		itemRepo:         itemRepo,
+		categoryRepo:     categoryRepo,
+		transactor:       transactor,
 	}
 }
 
 // CreateShoppingList creates a new shopping list
-func (s *ShoppingListService) CreateShoppingList(ctx context.Context, name, description string) (*entities.ShoppingList, error) {
-	if name == "" {
+func (s *ShoppingListService) CreateShoppingList(ctx context.Context, input ShoppingListInput) (*entities.ShoppingList, error) {
+	if input.Name == "" {
 		return nil, entities.ErrInvalidInput
 	}
 
-	list := entities.NewShoppingList(name, description)
+	currency, err := validateBudget(input.Budget, input.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	list := entities.NewShoppingList(input.Name, input.Description)
+	list.OwnerID = callerScope(ctx)
+	list.Budget = input.Budget
+	list.Currency = currency
 	if err := s.shoppingListRepo.Create(ctx, list); err != nil {
 		return nil, err
 	}
@@ -39,7 +127,7 @@ func (s *ShoppingListService) CreateShoppingList(ctx context.Context, name, desc
 
 // GetShoppingList retrieves a shopping list by ID
 func (s *ShoppingListService) GetShoppingList(ctx context.Context, id uuid.UUID) (*entities.ShoppingList, error) {
-	list, err := s.shoppingListRepo.GetByID(ctx, id)
+	list, err := getAccessibleList(ctx, s.shoppingListRepo, id)
 	if err != nil {
 		return nil, err
 	}
@@ -59,42 +147,188 @@ func (s *ShoppingListService) GetShoppingList(ctx context.Context, id uuid.UUID)
 	return list, nil
 }
 
-// GetAllShoppingLists retrieves all shopping lists
-func (s *ShoppingListService) GetAllShoppingLists(ctx context.Context) ([]*entities.ShoppingList, error) {
-	lists, err := s.shoppingListRepo.GetAll(ctx)
+// GetShoppingListGroupedByCategory retrieves a shopping list with its items grouped by category
+func (s *ShoppingListService) GetShoppingListGroupedByCategory(
+	ctx context.Context,
+	id uuid.UUID,
+) (*entities.ShoppingList, []entities.ItemGroup, error) {
+	list, err := s.GetShoppingList(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	categories, err := s.categoryRepo.GetAll(ctx, list.OwnerID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return list, entities.GroupItemsByCategory(list.Items, categories), nil
+}
+
+// GetAllShoppingLists retrieves a page of the shopping lists matching the input,
+// loading their items, their item counts or nothing else as the input includes
+func (s *ShoppingListService) GetAllShoppingLists(ctx context.Context, input ListShoppingListsInput) (*ShoppingListPage, error) {
+	limit := input.Limit
+	if limit == 0 {
+		limit = DefaultPageSize
+	}
+	if limit < 0 || limit > MaxPageSize || !input.Include.IsValid() {
+		return nil, entities.ErrInvalidInput
+	}
+
+	sortBy, descending, err := parseSort(input.Sort)
 	if err != nil {
 		return nil, err
 	}
 
-	// Load items for each shopping list
-	for _, list := range lists {
-		items, err := s.itemRepo.GetByShoppingListID(ctx, list.ID)
+	query := repositories.ShoppingListQuery{
+		AccessibleTo: callerScope(ctx),
+		NameContains: input.NameContains,
+		UpdatedSince: input.UpdatedSince,
+		SortBy:       sortBy,
+		Descending:   descending,
+		Limit:        limit + 1,
+	}
+	if input.Cursor != "" {
+		query.After, err = decodeListCursor(input.Cursor, input.Sort)
 		if err != nil {
 			return nil, err
 		}
+	}
 
-		list.Items = make([]entities.Item, len(items))
-		for i, item := range items {
-			list.Items[i] = *item
+	// One extra list tells whether another page follows
+	lists, err := s.shoppingListRepo.GetAll(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &ShoppingListPage{Lists: lists, Limit: limit}
+	if len(lists) > limit {
+		page.Lists = lists[:limit]
+		page.NextCursor = encodeListCursor(input.Sort, sortBy, page.Lists[limit-1])
+	}
+
+	switch input.Include {
+	case "", IncludeItems:
+		err = s.loadItems(ctx, page.Lists)
+	case IncludeCounts:
+		page.ItemCounts, err = s.itemRepo.CountByShoppingListIDs(ctx, listIDs(page.Lists))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// loadItems fills in the items of several shopping lists with a single query
+func (s *ShoppingListService) loadItems(ctx context.Context, lists []*entities.ShoppingList) error {
+	items, err := s.itemRepo.GetByShoppingListIDs(ctx, listIDs(lists))
+	if err != nil {
+		return err
+	}
+
+	byList := make(map[uuid.UUID][]entities.Item, len(lists))
+	for _, item := range items {
+		byList[item.ShoppingListID] = append(byList[item.ShoppingListID], *item)
+	}
+	for _, list := range lists {
+		list.Items = byList[list.ID]
+		if list.Items == nil {
+			list.Items = []entities.Item{}
 		}
 	}
+	return nil
+}
 
-	return lists, nil
+// listIDs returns the IDs of the given shopping lists
+func listIDs(lists []*entities.ShoppingList) []uuid.UUID {
+	ids := make([]uuid.UUID, len(lists))
+	for i, list := range lists {
+		ids[i] = list.ID
+	}
+	return ids
 }
 
 // UpdateShoppingList updates an existing shopping list
-func (s *ShoppingListService) UpdateShoppingList(ctx context.Context, id uuid.UUID, name, description string) (*entities.ShoppingList, error) {
-	if name == "" {
+func (s *ShoppingListService) UpdateShoppingList(ctx context.Context, id uuid.UUID, input ShoppingListInput) (*entities.ShoppingList, error) {
+	if input.Name == "" {
 		return nil, entities.ErrInvalidInput
 	}
 
-	list, err := s.shoppingListRepo.GetByID(ctx, id)
+	currency, err := validateBudget(input.Budget, input.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := getEditableList(ctx, s.shoppingListRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkVersion(input.Version, list.Version); err != nil {
+		return nil, err
+	}
+
+	input.Currency = currency
+	return s.applyUpdate(ctx, list, input)
+}
+
+// PatchShoppingList changes only the attributes of a shopping list that the patch supplies
+func (s *ShoppingListService) PatchShoppingList(
+	ctx context.Context,
+	id uuid.UUID,
+	patch ShoppingListPatch,
+) (*entities.ShoppingList, error) {
+	list, err := getEditableList(ctx, s.shoppingListRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkVersion(patch.Version, list.Version); err != nil {
+		return nil, err
+	}
+
+	input := ShoppingListInput{
+		Name:        list.Name,
+		Description: list.Description,
+		Budget:      list.Budget,
+		Currency:    list.Currency,
+	}
+	if patch.Name != nil {
+		input.Name = *patch.Name
+	}
+	if patch.Description != nil {
+		input.Description = *patch.Description
+	}
+	if patch.Budget != nil || patch.ClearBudget {
+		input.Budget = patch.Budget
+	}
+	if patch.Currency != nil {
+		input.Currency = *patch.Currency
+	}
+
+	if input.Name == "" {
+		return nil, entities.ErrInvalidInput
+	}
+	input.Currency, err = validateBudget(input.Budget, input.Currency)
 	if err != nil {
 		return nil, err
 	}
 
-	list.Name = name
-	list.Description = description
+	return s.applyUpdate(ctx, list, input)
+}
+
+// applyUpdate stores the validated attributes of a shopping list
+func (s *ShoppingListService) applyUpdate(
+	ctx context.Context,
+	list *entities.ShoppingList,
+	input ShoppingListInput,
+) (*entities.ShoppingList, error) {
+	list.Name = input.Name
+	list.Description = input.Description
+	list.Budget = input.Budget
+	list.Currency = input.Currency
 
 	if err := s.shoppingListRepo.Update(ctx, list); err != nil {
 		return nil, err
@@ -105,5 +339,126 @@ func (s *ShoppingListService) UpdateShoppingList(ctx context.Context, id uuid.UU
 
 // DeleteShoppingList deletes a shopping list
 func (s *ShoppingListService) DeleteShoppingList(ctx context.Context, id uuid.UUID) error {
+	if err := checkListAccess(ctx, s.shoppingListRepo, id, entities.ListRoleOwner); err != nil {
+		return err
+	}
 	return s.shoppingListRepo.Delete(ctx, id)
 }
+
+// CloneShoppingList copies a shopping list and its items into a new list with fresh IDs.
+// The copy belongs to the caller, so that members can copy lists shared with them.
+// The list and its items are created in a single transaction.
+func (s *ShoppingListService) CloneShoppingList(
+	ctx context.Context,
+	id uuid.UUID,
+	input CloneShoppingListInput,
+) (*entities.ShoppingList, error) {
+	source, err := getAccessibleList(ctx, s.shoppingListRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.itemRepo.GetByShoppingListID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	name := input.Name
+	if name == "" {
+		name = source.Name
+	}
+	clone := entities.NewShoppingList(name, source.Description)
+	clone.OwnerID = source.OwnerID
+	if caller := callerScope(ctx); caller != nil {
+		clone.OwnerID = caller
+	}
+	clone.Budget = copyInt64(source.Budget)
+	clone.Currency = source.Currency
+
+	copies := make([]*entities.Item, 0, len(items))
+	for _, item := range items {
+		if input.IncompleteOnly && item.Completed {
+			continue
+		}
+		copied := item.CopyTo(clone.ID)
+		if !sameOwner(clone.OwnerID, source.OwnerID) {
+			// The categories of the source belong to its owner
+			copied.CategoryID = nil
+		}
+		if input.ResetCompletion {
+			copied.MarkIncomplete()
+		}
+		copies = append(copies, copied)
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.shoppingListRepo.Create(ctx, clone); err != nil {
+			return err
+		}
+		return s.itemRepo.ApplyBatch(ctx, repositories.ItemBatch{Create: copies})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, copied := range copies {
+		clone.Items = append(clone.Items, *copied)
+	}
+	return clone, nil
+}
+
+// ClearCompleted moves the completed items of a shopping list to the trash and returns how many it moved
+func (s *ShoppingListService) ClearCompleted(ctx context.Context, id uuid.UUID) (int64, error) {
+	if _, err := getEditableList(ctx, s.shoppingListRepo, id); err != nil {
+		return 0, err
+	}
+	return s.itemRepo.DeleteCompleted(ctx, id)
+}
+
+// CompleteAll marks every item of a shopping list as completed and returns how many it changed
+func (s *ShoppingListService) CompleteAll(ctx context.Context, id uuid.UUID) (int64, error) {
+	if _, err := getEditableList(ctx, s.shoppingListRepo, id); err != nil {
+		return 0, err
+	}
+	return s.itemRepo.SetCompleted(ctx, id, true)
+}
+
+// ResetShoppingList marks every item of a shopping list as not completed and returns how many it changed
+func (s *ShoppingListService) ResetShoppingList(ctx context.Context, id uuid.UUID) (int64, error) {
+	if _, err := getEditableList(ctx, s.shoppingListRepo, id); err != nil {
+		return 0, err
+	}
+	return s.itemRepo.SetCompleted(ctx, id, false)
+}
+
+// copyInt64 returns a copy of an optional value
+func copyInt64(value *int64) *int64 {
+	if value == nil {
+		return nil
+	}
+	copied := *value
+	return &copied
+}
+
+// checkVersion fails with ErrVersionConflict when an expected version is given
+// and differs from the current one
+func checkVersion(expected *int, current int) error {
+	if expected != nil && *expected != current {
+		return entities.ErrVersionConflict
+	}
+	return nil
+}
+
+// validateBudget checks an optional budget and returns the normalized currency
+func validateBudget(budget *int64, currency string) (string, error) {
+	if currency == "" {
+		if budget != nil {
+			return "", entities.ErrInvalidInput
+		}
+		return "", nil
+	}
+	if budget != nil && *budget < 0 {
+		return "", entities.ErrInvalidInput
+	}
+	return entities.NormalizeCurrency(currency)
+}
@@ -2,23 +2,29 @@ package services
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
 )
 
 func TestNewShoppingListService(t *testing.T) {
 	itemRepo := &MockItemRepository{}
 	shoppingListRepo := &MockShoppingListRepository{}
+	categoryRepo := &MockCategoryRepository{}
 
-	service := NewShoppingListService(shoppingListRepo, itemRepo)
+	service := NewShoppingListService(shoppingListRepo, itemRepo, categoryRepo, passThroughTransactor{})
 
 	assert.NotNil(t, service)
 	assert.Equal(t, shoppingListRepo, service.shoppingListRepo)
 	assert.Equal(t, itemRepo, service.itemRepo)
+	assert.Equal(t, categoryRepo, service.categoryRepo)
+	assert.Equal(t, passThroughTransactor{}, service.transactor)
 }
 
 func TestShoppingListService_CreateShoppingList(t *testing.T) {
@@ -64,11 +70,11 @@ func TestShoppingListService_CreateShoppingList(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			itemRepo := &MockItemRepository{}
 			shoppingListRepo := &MockShoppingListRepository{}
-			service := NewShoppingListService(shoppingListRepo, itemRepo)
+			service := NewShoppingListService(shoppingListRepo, itemRepo, &MockCategoryRepository{}, passThroughTransactor{})
 
 			tt.setupMocks(shoppingListRepo)
 
-			result, err := service.CreateShoppingList(context.Background(), tt.listName, tt.description)
+			result, err := service.CreateShoppingList(context.Background(), ShoppingListInput{Name: tt.listName, Description: tt.description})
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -88,6 +94,52 @@ func TestShoppingListService_CreateShoppingList(t *testing.T) {
 	}
 }
 
+func TestShoppingListService_CreateShoppingList_Budget(t *testing.T) {
+	budget := int64(5000)
+	negative := int64(-1)
+
+	tests := []struct {
+		name          string
+		budget        *int64
+		currency      string
+		wantCurrency  string
+		expectedError error
+	}{
+		{name: "budget with currency", budget: &budget, currency: "eur", wantCurrency: "EUR"},
+		{name: "currency without budget", currency: "USD", wantCurrency: "USD"},
+		{name: "budget without currency should fail", budget: &budget, expectedError: entities.ErrInvalidInput},
+		{name: "negative budget should fail", budget: &negative, currency: "EUR", expectedError: entities.ErrInvalidInput},
+		{name: "invalid currency should fail", budget: &budget, currency: "EURO", expectedError: entities.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shoppingListRepo := &MockShoppingListRepository{}
+			service := NewShoppingListService(shoppingListRepo, &MockItemRepository{}, &MockCategoryRepository{}, passThroughTransactor{})
+			if tt.expectedError == nil {
+				shoppingListRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			}
+
+			result, err := service.CreateShoppingList(context.Background(), ShoppingListInput{
+				Name:     "Groceries",
+				Budget:   tt.budget,
+				Currency: tt.currency,
+			})
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.budget, result.Budget)
+				assert.Equal(t, tt.wantCurrency, result.Currency)
+			}
+
+			shoppingListRepo.AssertExpectations(t)
+		})
+	}
+}
+
 func TestShoppingListService_GetShoppingList(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -133,7 +185,7 @@ func TestShoppingListService_GetShoppingList(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			itemRepo := &MockItemRepository{}
 			shoppingListRepo := &MockShoppingListRepository{}
-			service := NewShoppingListService(shoppingListRepo, itemRepo)
+			service := NewShoppingListService(shoppingListRepo, itemRepo, &MockCategoryRepository{}, passThroughTransactor{})
 
 			listID := uuid.New()
 			tt.setupMocks(shoppingListRepo, itemRepo, listID)
@@ -159,7 +211,7 @@ func TestShoppingListService_GetShoppingList(t *testing.T) {
 func TestShoppingListService_GetShoppingList_NotFound(t *testing.T) {
 	itemRepo := &MockItemRepository{}
 	shoppingListRepo := &MockShoppingListRepository{}
-	service := NewShoppingListService(shoppingListRepo, itemRepo)
+	service := NewShoppingListService(shoppingListRepo, itemRepo, &MockCategoryRepository{}, passThroughTransactor{})
 
 	listID := uuid.New()
 	shoppingListRepo.On("GetByID", mock.Anything, listID).Return((*entities.ShoppingList)(nil), entities.ErrShoppingListNotFound)
@@ -172,11 +224,117 @@ func TestShoppingListService_GetShoppingList_NotFound(t *testing.T) {
 	shoppingListRepo.AssertExpectations(t)
 }
 
+func TestShoppingListService_ScopedToCaller(t *testing.T) {
+	owner := uuid.New()
+	ctx := WithCaller(context.Background(), owner)
+
+	t.Run("creates lists owned by the caller", func(t *testing.T) {
+		shoppingListRepo := &MockShoppingListRepository{}
+		service := NewShoppingListService(shoppingListRepo, &MockItemRepository{}, &MockCategoryRepository{}, passThroughTransactor{})
+		shoppingListRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.ShoppingList")).Return(nil)
+
+		list, err := service.CreateShoppingList(ctx, ShoppingListInput{Name: "Groceries"})
+
+		require.NoError(t, err)
+		assert.Equal(t, &owner, list.OwnerID)
+	})
+
+	t.Run("lists only the lists accessible to the caller", func(t *testing.T) {
+		shoppingListRepo := &MockShoppingListRepository{}
+		itemRepo := &MockItemRepository{}
+		service := NewShoppingListService(shoppingListRepo, itemRepo, &MockCategoryRepository{}, passThroughTransactor{})
+		accessible := mock.MatchedBy(func(query repositories.ShoppingListQuery) bool {
+			return query.AccessibleTo != nil && *query.AccessibleTo == owner
+		})
+		shoppingListRepo.On("GetAll", mock.Anything, accessible).Return([]*entities.ShoppingList{}, nil)
+		itemRepo.On("GetByShoppingListIDs", mock.Anything, []uuid.UUID{}).Return([]*entities.Item{}, nil)
+
+		_, err := service.GetAllShoppingLists(ctx, ListShoppingListsInput{})
+
+		require.NoError(t, err)
+		shoppingListRepo.AssertExpectations(t)
+	})
+
+	t.Run("hides the lists of other users", func(t *testing.T) {
+		shoppingListRepo := &MockShoppingListRepository{}
+		service := NewShoppingListService(shoppingListRepo, &MockItemRepository{}, &MockCategoryRepository{}, passThroughTransactor{})
+		other := uuid.New()
+		listID := uuid.New()
+		shoppingListRepo.On("GetByID", mock.Anything, listID).Return(&entities.ShoppingList{ID: listID, OwnerID: &other}, nil)
+
+		_, err := service.GetShoppingList(ctx, listID)
+		assert.Equal(t, entities.ErrShoppingListNotFound, err)
+
+		err = service.DeleteShoppingList(ctx, listID)
+		assert.Equal(t, entities.ErrShoppingListNotFound, err)
+		shoppingListRepo.AssertNotCalled(t, "Delete", mock.Anything, listID)
+	})
+
+	t.Run("only lets owners delete shared lists", func(t *testing.T) {
+		shoppingListRepo := &MockShoppingListRepository{}
+		service := NewShoppingListService(shoppingListRepo, &MockItemRepository{}, &MockCategoryRepository{}, passThroughTransactor{})
+		other, coOwner := uuid.New(), uuid.New()
+		list := &entities.ShoppingList{ID: uuid.New(), OwnerID: &other, Name: "Groceries"}
+		list.Members = []entities.ListMember{
+			*entities.NewListMember(list.ID, owner, entities.ListRoleEditor),
+			*entities.NewListMember(list.ID, coOwner, entities.ListRoleOwner),
+		}
+		shoppingListRepo.On("GetByID", mock.Anything, list.ID).Return(list, nil)
+		shoppingListRepo.On("Update", mock.Anything, list).Return(nil)
+		shoppingListRepo.On("Delete", mock.Anything, list.ID).Return(nil)
+
+		_, err := service.UpdateShoppingList(ctx, list.ID, ShoppingListInput{Name: "Weekly groceries"})
+		assert.NoError(t, err)
+
+		err = service.DeleteShoppingList(ctx, list.ID)
+		assert.Equal(t, entities.ErrForbidden, err)
+		shoppingListRepo.AssertNotCalled(t, "Delete", mock.Anything, list.ID)
+
+		err = service.DeleteShoppingList(WithCaller(context.Background(), coOwner), list.ID)
+		assert.NoError(t, err)
+	})
+}
+
+func TestShoppingListService_GetShoppingListGroupedByCategory(t *testing.T) {
+	itemRepo := &MockItemRepository{}
+	shoppingListRepo := &MockShoppingListRepository{}
+	categoryRepo := &MockCategoryRepository{}
+	service := NewShoppingListService(shoppingListRepo, itemRepo, categoryRepo, passThroughTransactor{})
+
+	listID := uuid.New()
+	dairy := &entities.Category{ID: uuid.New(), Name: "Dairy", Position: 2}
+	produce := &entities.Category{ID: uuid.New(), Name: "Produce", Position: 1}
+	items := []*entities.Item{
+		{ID: uuid.New(), Name: "Milk", ShoppingListID: listID, CategoryID: &dairy.ID},
+		{ID: uuid.New(), Name: "Batteries", ShoppingListID: listID},
+		{ID: uuid.New(), Name: "Apples", ShoppingListID: listID, CategoryID: &produce.ID},
+	}
+
+	shoppingListRepo.On("GetByID", mock.Anything, listID).Return(&entities.ShoppingList{ID: listID}, nil)
+	itemRepo.On("GetByShoppingListID", mock.Anything, listID).Return(items, nil)
+	categoryRepo.On("GetAll", mock.Anything, mock.Anything).Return([]*entities.Category{dairy, produce}, nil)
+
+	list, groups, err := service.GetShoppingListGroupedByCategory(context.Background(), listID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, listID, list.ID)
+	assert.Len(t, groups, 3)
+	assert.Equal(t, produce, groups[0].Category)
+	assert.Equal(t, dairy, groups[1].Category)
+	assert.Nil(t, groups[2].Category)
+	assert.Equal(t, "Batteries", groups[2].Items[0].Name)
+
+	shoppingListRepo.AssertExpectations(t)
+	itemRepo.AssertExpectations(t)
+	categoryRepo.AssertExpectations(t)
+}
+
 func TestShoppingListService_GetAllShoppingLists(t *testing.T) {
 	tests := []struct {
 		name       string
 		setupMocks func(*MockShoppingListRepository, *MockItemRepository)
 		listCount  int
+		itemCounts []int
 	}{
 		{
 			name: "get multiple lists with items",
@@ -189,25 +347,34 @@ func TestShoppingListService_GetAllShoppingLists(t *testing.T) {
 					{ID: list2ID, Name: "List 2"},
 				}
 
-				items1 := []*entities.Item{
+				items := []*entities.Item{
 					{ID: uuid.New(), Name: "Item 1", ShoppingListID: list1ID},
-				}
-				items2 := []*entities.Item{
 					{ID: uuid.New(), Name: "Item 2", ShoppingListID: list2ID},
 					{ID: uuid.New(), Name: "Item 3", ShoppingListID: list2ID},
 				}
 
-				listRepo.On("GetAll", mock.Anything).Return(expectedLists, nil)
-				itemRepo.On("GetByShoppingListID", mock.Anything, list1ID).Return(items1, nil)
-				itemRepo.On("GetByShoppingListID", mock.Anything, list2ID).Return(items2, nil)
+				listRepo.On("GetAll", mock.Anything, mock.Anything).Return(expectedLists, nil)
+				itemRepo.On("GetByShoppingListIDs", mock.Anything, []uuid.UUID{list1ID, list2ID}).Return(items, nil).Once()
 			},
-			listCount: 2,
+			listCount:  2,
+			itemCounts: []int{1, 2},
+		},
+		{
+			name: "get lists without items",
+			setupMocks: func(listRepo *MockShoppingListRepository, itemRepo *MockItemRepository) {
+				expectedLists := []*entities.ShoppingList{{ID: uuid.New(), Name: "List 1"}}
+				listRepo.On("GetAll", mock.Anything, mock.Anything).Return(expectedLists, nil)
+				itemRepo.On("GetByShoppingListIDs", mock.Anything, mock.Anything).Return([]*entities.Item{}, nil)
+			},
+			listCount:  1,
+			itemCounts: []int{0},
 		},
 		{
 			name: "get empty list",
 			setupMocks: func(listRepo *MockShoppingListRepository, itemRepo *MockItemRepository) {
 				expectedLists := []*entities.ShoppingList{}
-				listRepo.On("GetAll", mock.Anything).Return(expectedLists, nil)
+				listRepo.On("GetAll", mock.Anything, mock.Anything).Return(expectedLists, nil)
+				itemRepo.On("GetByShoppingListIDs", mock.Anything, []uuid.UUID{}).Return([]*entities.Item{}, nil)
 			},
 			listCount: 0,
 		},
@@ -217,14 +384,20 @@ func TestShoppingListService_GetAllShoppingLists(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			itemRepo := &MockItemRepository{}
 			shoppingListRepo := &MockShoppingListRepository{}
-			service := NewShoppingListService(shoppingListRepo, itemRepo)
+			service := NewShoppingListService(shoppingListRepo, itemRepo, &MockCategoryRepository{}, passThroughTransactor{})
 
 			tt.setupMocks(shoppingListRepo, itemRepo)
 
-			result, err := service.GetAllShoppingLists(context.Background())
+			result, err := service.GetAllShoppingLists(context.Background(), ListShoppingListsInput{})
 
 			assert.NoError(t, err)
-			assert.Len(t, result, tt.listCount)
+			assert.Len(t, result.Lists, tt.listCount)
+			for i, count := range tt.itemCounts {
+				assert.NotNil(t, result.Lists[i].Items)
+				assert.Len(t, result.Lists[i].Items, count)
+			}
+			assert.Equal(t, DefaultPageSize, result.Limit)
+			assert.Empty(t, result.NextCursor)
 
 			shoppingListRepo.AssertExpectations(t)
 			itemRepo.AssertExpectations(t)
@@ -232,6 +405,93 @@ func TestShoppingListService_GetAllShoppingLists(t *testing.T) {
 	}
 }
 
+func TestShoppingListService_GetAllShoppingLists_Paging(t *testing.T) {
+	listRepo := &MockShoppingListRepository{}
+	itemRepo := &MockItemRepository{}
+	service := NewShoppingListService(listRepo, itemRepo, &MockCategoryRepository{}, passThroughTransactor{})
+
+	lists := []*entities.ShoppingList{
+		{ID: uuid.New(), Name: "Bakery"},
+		{ID: uuid.New(), Name: "Hardware"},
+		{ID: uuid.New(), Name: "Pharmacy"},
+	}
+	firstPage := repositories.ShoppingListQuery{SortBy: repositories.SortByName, Descending: true, Limit: 3}
+	listRepo.On("GetAll", mock.Anything, firstPage).Return(lists, nil)
+	itemRepo.On("GetByShoppingListIDs", mock.Anything, mock.Anything).Return([]*entities.Item{}, nil)
+
+	page, err := service.GetAllShoppingLists(context.Background(), ListShoppingListsInput{Limit: 2, Sort: "-name"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, lists[:2], page.Lists)
+	assert.NotEmpty(t, page.NextCursor)
+
+	secondPage := firstPage
+	secondPage.After = &repositories.ShoppingListCursor{Name: "Hardware", ID: lists[1].ID}
+	listRepo.On("GetAll", mock.Anything, secondPage).Return(lists[2:], nil)
+
+	page, err = service.GetAllShoppingLists(context.Background(), ListShoppingListsInput{Limit: 2, Sort: "-name", Cursor: page.NextCursor})
+
+	assert.NoError(t, err)
+	assert.Equal(t, lists[2:], page.Lists)
+	assert.Empty(t, page.NextCursor)
+	listRepo.AssertExpectations(t)
+}
+
+func TestShoppingListService_GetAllShoppingLists_Include(t *testing.T) {
+	lists := []*entities.ShoppingList{{ID: uuid.New(), Name: "Bakery"}, {ID: uuid.New(), Name: "Hardware"}}
+
+	t.Run("counts", func(t *testing.T) {
+		listRepo := &MockShoppingListRepository{}
+		itemRepo := &MockItemRepository{}
+		service := NewShoppingListService(listRepo, itemRepo, &MockCategoryRepository{}, passThroughTransactor{})
+
+		counts := map[uuid.UUID]entities.ItemCounts{lists[0].ID: {Total: 3, Completed: 1}}
+		listRepo.On("GetAll", mock.Anything, mock.Anything).Return(lists, nil)
+		itemRepo.On("CountByShoppingListIDs", mock.Anything, []uuid.UUID{lists[0].ID, lists[1].ID}).Return(counts, nil)
+
+		page, err := service.GetAllShoppingLists(context.Background(), ListShoppingListsInput{Include: IncludeCounts})
+
+		assert.NoError(t, err)
+		assert.Equal(t, counts, page.ItemCounts)
+		itemRepo.AssertExpectations(t)
+		itemRepo.AssertNotCalled(t, "GetByShoppingListIDs", mock.Anything, mock.Anything)
+	})
+
+	t.Run("none", func(t *testing.T) {
+		listRepo := &MockShoppingListRepository{}
+		itemRepo := &MockItemRepository{}
+		service := NewShoppingListService(listRepo, itemRepo, &MockCategoryRepository{}, passThroughTransactor{})
+
+		listRepo.On("GetAll", mock.Anything, mock.Anything).Return(lists, nil)
+
+		page, err := service.GetAllShoppingLists(context.Background(), ListShoppingListsInput{Include: IncludeNone})
+
+		assert.NoError(t, err)
+		assert.Equal(t, lists, page.Lists)
+		assert.Nil(t, page.ItemCounts)
+		itemRepo.AssertExpectations(t)
+	})
+}
+
+func TestShoppingListService_GetAllShoppingLists_InvalidInput(t *testing.T) {
+	service := NewShoppingListService(&MockShoppingListRepository{}, &MockItemRepository{}, &MockCategoryRepository{}, passThroughTransactor{})
+	cursor := encodeListCursor("name", repositories.SortByName, &entities.ShoppingList{ID: uuid.New(), Name: "Bakery"})
+
+	for name, input := range map[string]ListShoppingListsInput{
+		"negative limit":       {Limit: -1},
+		"limit above maximum":  {Limit: MaxPageSize + 1},
+		"unknown sort field":   {Sort: "budget"},
+		"malformed cursor":     {Cursor: "not-a-cursor"},
+		"cursor of other sort": {Cursor: cursor, Sort: "-name"},
+		"unknown include":      {Include: "categories"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := service.GetAllShoppingLists(context.Background(), input)
+			assert.Equal(t, entities.ErrInvalidInput, err)
+		})
+	}
+}
+
 func TestShoppingListService_UpdateShoppingList(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -279,12 +539,12 @@ func TestShoppingListService_UpdateShoppingList(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			itemRepo := &MockItemRepository{}
 			shoppingListRepo := &MockShoppingListRepository{}
-			service := NewShoppingListService(shoppingListRepo, itemRepo)
+			service := NewShoppingListService(shoppingListRepo, itemRepo, &MockCategoryRepository{}, passThroughTransactor{})
 
 			listID := uuid.New()
 			tt.setupMocks(shoppingListRepo, listID)
 
-			result, err := service.UpdateShoppingList(context.Background(), listID, tt.listName, tt.description)
+			result, err := service.UpdateShoppingList(context.Background(), listID, ShoppingListInput{Name: tt.listName, Description: tt.description})
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -302,10 +562,68 @@ func TestShoppingListService_UpdateShoppingList(t *testing.T) {
 	}
 }
 
+func TestShoppingListService_UpdateShoppingList_StaleVersion(t *testing.T) {
+	shoppingListRepo := &MockShoppingListRepository{}
+	service := NewShoppingListService(shoppingListRepo, &MockItemRepository{}, &MockCategoryRepository{}, passThroughTransactor{})
+
+	listID := uuid.New()
+	stale := 1
+	shoppingListRepo.On("GetByID", mock.Anything, listID).Return(&entities.ShoppingList{ID: listID, Name: "Old List", Version: 2}, nil)
+
+	result, err := service.UpdateShoppingList(context.Background(), listID, ShoppingListInput{Name: "New List", Version: &stale})
+
+	assert.Equal(t, entities.ErrVersionConflict, err)
+	assert.Nil(t, result)
+	shoppingListRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestShoppingListService_PatchShoppingList(t *testing.T) {
+	budget := int64(8000)
+	description := "For the weekend"
+
+	tests := []struct {
+		name          string
+		patch         ShoppingListPatch
+		wantBudget    *int64
+		wantCurrency  string
+		expectedError error
+	}{
+		{name: "keeps fields that are not supplied", patch: ShoppingListPatch{Description: &description}, wantBudget: &budget, wantCurrency: "EUR"},
+		{name: "removes the budget", patch: ShoppingListPatch{ClearBudget: true}, wantCurrency: "EUR"},
+		{name: "budget without currency should fail", patch: ShoppingListPatch{Currency: new(string)}, expectedError: entities.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shoppingListRepo := &MockShoppingListRepository{}
+			service := NewShoppingListService(shoppingListRepo, &MockItemRepository{}, &MockCategoryRepository{}, passThroughTransactor{})
+
+			listID := uuid.New()
+			existing := &entities.ShoppingList{ID: listID, Name: "Groceries", Budget: &budget, Currency: "EUR"}
+			shoppingListRepo.On("GetByID", mock.Anything, listID).Return(existing, nil)
+			shoppingListRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+			result, err := service.PatchShoppingList(context.Background(), listID, tt.patch)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Nil(t, result)
+				shoppingListRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "Groceries", result.Name)
+			assert.Equal(t, tt.wantBudget, result.Budget)
+			assert.Equal(t, tt.wantCurrency, result.Currency)
+		})
+	}
+}
+
 func TestShoppingListService_DeleteShoppingList(t *testing.T) {
 	itemRepo := &MockItemRepository{}
 	shoppingListRepo := &MockShoppingListRepository{}
-	service := NewShoppingListService(shoppingListRepo, itemRepo)
+	service := NewShoppingListService(shoppingListRepo, itemRepo, &MockCategoryRepository{}, passThroughTransactor{})
 
 	listID := uuid.New()
 	shoppingListRepo.On("Delete", mock.Anything, listID).Return(nil)
@@ -319,7 +637,7 @@ func TestShoppingListService_DeleteShoppingList(t *testing.T) {
 func TestShoppingListService_DeleteShoppingList_NotFound(t *testing.T) {
 	itemRepo := &MockItemRepository{}
 	shoppingListRepo := &MockShoppingListRepository{}
-	service := NewShoppingListService(shoppingListRepo, itemRepo)
+	service := NewShoppingListService(shoppingListRepo, itemRepo, &MockCategoryRepository{}, passThroughTransactor{})
 
 	listID := uuid.New()
 	shoppingListRepo.On("Delete", mock.Anything, listID).Return(entities.ErrShoppingListNotFound)
@@ -330,3 +648,172 @@ func TestShoppingListService_DeleteShoppingList_NotFound(t *testing.T) {
 	assert.Equal(t, entities.ErrShoppingListNotFound, err)
 	shoppingListRepo.AssertExpectations(t)
 }
+
+func TestShoppingListService_ListActions(t *testing.T) {
+	tests := []struct {
+		name       string
+		run        func(*ShoppingListService, context.Context, uuid.UUID) (int64, error)
+		expectRepo func(*MockItemRepository, uuid.UUID)
+	}{
+		{
+			name: "clear completed",
+			run:  (*ShoppingListService).ClearCompleted,
+			expectRepo: func(m *MockItemRepository, listID uuid.UUID) {
+				m.On("DeleteCompleted", mock.Anything, listID).Return(int64(2), nil)
+			},
+		},
+		{
+			name: "complete all",
+			run:  (*ShoppingListService).CompleteAll,
+			expectRepo: func(m *MockItemRepository, listID uuid.UUID) {
+				m.On("SetCompleted", mock.Anything, listID, true).Return(int64(2), nil)
+			},
+		},
+		{
+			name: "reset",
+			run:  (*ShoppingListService).ResetShoppingList,
+			expectRepo: func(m *MockItemRepository, listID uuid.UUID) {
+				m.On("SetCompleted", mock.Anything, listID, false).Return(int64(2), nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := &MockItemRepository{}
+			shoppingListRepo := &MockShoppingListRepository{}
+			service := NewShoppingListService(shoppingListRepo, itemRepo, &MockCategoryRepository{}, passThroughTransactor{})
+
+			listID := uuid.New()
+			shoppingListRepo.On("GetByID", mock.Anything, listID).Return(&entities.ShoppingList{ID: listID}, nil)
+			tt.expectRepo(itemRepo, listID)
+
+			affected, err := tt.run(service, context.Background(), listID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, int64(2), affected)
+			itemRepo.AssertExpectations(t)
+		})
+
+		t.Run(tt.name+" of missing list", func(t *testing.T) {
+			itemRepo := &MockItemRepository{}
+			shoppingListRepo := &MockShoppingListRepository{}
+			service := NewShoppingListService(shoppingListRepo, itemRepo, &MockCategoryRepository{}, passThroughTransactor{})
+
+			shoppingListRepo.On("GetByID", mock.Anything, mock.Anything).
+				Return((*entities.ShoppingList)(nil), entities.ErrShoppingListNotFound)
+
+			_, err := tt.run(service, context.Background(), uuid.New())
+
+			assert.Equal(t, entities.ErrShoppingListNotFound, err)
+			itemRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestShoppingListService_CloneShoppingList(t *testing.T) {
+	budget := int64(5000)
+	source := &entities.ShoppingList{
+		ID:          uuid.New(),
+		Name:        "Weekly Groceries",
+		Description: "Saturday market",
+		Budget:      &budget,
+		Currency:    "EUR",
+		Version:     4,
+	}
+	items := []*entities.Item{
+		{ID: uuid.New(), ShoppingListID: source.ID, Name: "Milk", Quantity: 2, Completed: true, Version: 3},
+		{ID: uuid.New(), ShoppingListID: source.ID, Name: "Bread", Quantity: 1, Version: 1},
+	}
+
+	tests := []struct {
+		name          string
+		input         CloneShoppingListInput
+		expectedName  string
+		expectedItems []string
+		expectedDone  []bool
+	}{
+		{
+			name:          "copies everything",
+			expectedName:  "Weekly Groceries",
+			expectedItems: []string{"Milk", "Bread"},
+			expectedDone:  []bool{true, false},
+		},
+		{
+			name:          "resets completion",
+			input:         CloneShoppingListInput{ResetCompletion: true},
+			expectedName:  "Weekly Groceries",
+			expectedItems: []string{"Milk", "Bread"},
+			expectedDone:  []bool{false, false},
+		},
+		{
+			name:          "copies incomplete items under a new name",
+			input:         CloneShoppingListInput{Name: "Next Week", IncompleteOnly: true},
+			expectedName:  "Next Week",
+			expectedItems: []string{"Bread"},
+			expectedDone:  []bool{false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := &MockItemRepository{}
+			listRepo := &MockShoppingListRepository{}
+			service := NewShoppingListService(listRepo, itemRepo, &MockCategoryRepository{}, passThroughTransactor{})
+
+			listRepo.On("GetByID", mock.Anything, source.ID).Return(source, nil)
+			itemRepo.On("GetByShoppingListID", mock.Anything, source.ID).Return(items, nil)
+			listRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.ShoppingList")).Return(nil)
+			itemRepo.On("ApplyBatch", mock.Anything, mock.AnythingOfType("repositories.ItemBatch")).Return(nil)
+
+			clone, err := service.CloneShoppingList(context.Background(), source.ID, tt.input)
+
+			require.NoError(t, err)
+			assert.NotEqual(t, source.ID, clone.ID)
+			assert.Equal(t, tt.expectedName, clone.Name)
+			assert.Equal(t, source.Description, clone.Description)
+			assert.Equal(t, budget, *clone.Budget)
+			assert.NotSame(t, source.Budget, clone.Budget)
+			assert.Equal(t, "EUR", clone.Currency)
+			assert.Equal(t, 1, clone.Version)
+
+			require.Len(t, clone.Items, len(tt.expectedItems))
+			for i, item := range clone.Items {
+				assert.Equal(t, tt.expectedItems[i], item.Name)
+				assert.Equal(t, tt.expectedDone[i], item.Completed)
+				assert.Equal(t, clone.ID, item.ShoppingListID)
+				assert.Equal(t, 1, item.Version)
+			}
+			listRepo.AssertExpectations(t)
+			itemRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestShoppingListService_CloneShoppingList_Failures(t *testing.T) {
+	t.Run("source not found", func(t *testing.T) {
+		listRepo := &MockShoppingListRepository{}
+		service := NewShoppingListService(listRepo, &MockItemRepository{}, &MockCategoryRepository{}, passThroughTransactor{})
+		listRepo.On("GetByID", mock.Anything, mock.Anything).
+			Return((*entities.ShoppingList)(nil), entities.ErrShoppingListNotFound)
+
+		_, err := service.CloneShoppingList(context.Background(), uuid.New(), CloneShoppingListInput{})
+		assert.Equal(t, entities.ErrShoppingListNotFound, err)
+	})
+
+	t.Run("item copy fails", func(t *testing.T) {
+		itemRepo := &MockItemRepository{}
+		listRepo := &MockShoppingListRepository{}
+		service := NewShoppingListService(listRepo, itemRepo, &MockCategoryRepository{}, passThroughTransactor{})
+
+		source := &entities.ShoppingList{ID: uuid.New(), Name: "Weekly Groceries"}
+		listRepo.On("GetByID", mock.Anything, source.ID).Return(source, nil)
+		itemRepo.On("GetByShoppingListID", mock.Anything, source.ID).Return([]*entities.Item{}, nil)
+		listRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		itemRepo.On("ApplyBatch", mock.Anything, mock.Anything).Return(errors.New("database error"))
+
+		clone, err := service.CloneShoppingList(context.Background(), source.ID, CloneShoppingListInput{})
+		assert.Error(t, err)
+		assert.Nil(t, clone)
+	})
+}
@@ -0,0 +1,353 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+)
+
+// TemplateInput holds the fields of a template and its items.
+// Updating a template replaces all of its items. Recurrence is an RRULE subset such as
+// "FREQ=WEEKLY;BYDAY=SA"; StartsAt, which defaults to now, sets the time of day of the
+// generated lists and the earliest one. TimeZone is the IANA zone the rule is evaluated in,
+// UTC by default, so runs keep their local time of day across daylight saving changes.
+// Updating a template without changing its rule, start or time zone keeps its schedule.
+type TemplateInput struct {
+	Name        string
+	Description string
+	Budget      *int64
+	Currency    string
+	Recurrence  string
+	TimeZone    string
+	StartsAt    *time.Time
+	Items       []TemplateItemInput
+}
+
+// TemplateItemInput holds the fields of a template item.
+// An empty Unit defaults to pieces; a price without a currency uses the template currency.
+type TemplateItemInput struct {
+	Name       string
+	Quantity   float64
+	Unit       entities.Unit
+	CategoryID *uuid.UUID
+	UnitPrice  *int64
+	Currency   string
+}
+
+// TemplateService handles business logic for shopping list templates
+type TemplateService struct {
+	templateRepo     repositories.TemplateRepository
+	shoppingListRepo repositories.ShoppingListRepository
+	itemRepo         repositories.ItemRepository
+	categoryRepo     repositories.CategoryRepository
+	transactor       repositories.Transactor
+	clock            Clock
+}
+
+// NewTemplateService creates a new template service
+func NewTemplateService(
+	templateRepo repositories.TemplateRepository,
+	shoppingListRepo repositories.ShoppingListRepository,
+	itemRepo repositories.ItemRepository,
+	categoryRepo repositories.CategoryRepository,
+	transactor repositories.Transactor,
+	clock Clock,
+) *TemplateService {
+	return &TemplateService{
+		templateRepo:     templateRepo,
+		shoppingListRepo: shoppingListRepo,
+		itemRepo:         itemRepo,
+		categoryRepo:     categoryRepo,
+		transactor:       transactor,
+		clock:            clock,
+	}
+}
+
+// CreateTemplate creates a new template with its items
+func (s *TemplateService) CreateTemplate(ctx context.Context, input TemplateInput) (*entities.Template, error) {
+	template := entities.NewTemplate("", "")
+	template.OwnerID = callerScope(ctx)
+	if err := s.applyInput(ctx, template, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.Create(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// GetTemplate retrieves a template and its items by ID
+func (s *TemplateService) GetTemplate(ctx context.Context, id uuid.UUID) (*entities.Template, error) {
+	return s.getAccessibleTemplate(ctx, id)
+}
+
+// GetAllTemplates retrieves the caller's templates ordered by name
+func (s *TemplateService) GetAllTemplates(ctx context.Context) ([]*entities.Template, error) {
+	return s.templateRepo.GetAll(ctx, callerScope(ctx))
+}
+
+// UpdateTemplate replaces the fields and items of an existing template
+func (s *TemplateService) UpdateTemplate(ctx context.Context, id uuid.UUID, input TemplateInput) (*entities.Template, error) {
+	template, err := s.getAccessibleTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyInput(ctx, template, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.Update(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// DeleteTemplate deletes a template; lists created from it are kept
+func (s *TemplateService) DeleteTemplate(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.getAccessibleTemplate(ctx, id); err != nil {
+		return err
+	}
+	return s.templateRepo.Delete(ctx, id)
+}
+
+// getAccessibleTemplate retrieves a template the caller owns; templates of other users are reported as not found
+func (s *TemplateService) getAccessibleTemplate(ctx context.Context, id uuid.UUID) (*entities.Template, error) {
+	template, err := s.templateRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !isVisibleTo(ctx, template.OwnerID) {
+		return nil, entities.ErrTemplateNotFound
+	}
+	return template, nil
+}
+
+// InstantiateTemplate creates a new shopping list with the items of a template.
+// An empty name keeps the name of the template. The list and its items are
+// created in a single transaction.
+func (s *TemplateService) InstantiateTemplate(ctx context.Context, id uuid.UUID, name string) (*entities.ShoppingList, error) {
+	template, err := s.getAccessibleTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var list *entities.ShoppingList
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		list, err = s.createList(ctx, template, strings.TrimSpace(name))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// errRunClaimed reports that another scheduler already materialized a due run
+var errRunClaimed = errors.New("template run already materialized")
+
+// MaterializeDueTemplates creates the shopping lists of the recurring templates that are due
+// and returns how many it created. Each list is created in the same transaction that moves its
+// template to the next run, so a run is materialized once even across restarts or when several
+// schedulers race. Runs missed while nothing was running collapse into a single list.
+func (s *TemplateService) MaterializeDueTemplates(ctx context.Context) (int, error) {
+	now := s.clock().UTC()
+	templates, err := s.templateRepo.GetDue(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	var errs []error
+	for _, template := range templates {
+		err := s.materialize(ctx, template, now)
+		switch {
+		case err == nil:
+			created++
+		case err != errRunClaimed:
+			errs = append(errs, err)
+		}
+	}
+	return created, errors.Join(errs...)
+}
+
+// materialize creates the list of a due template run and schedules its next run after now
+func (s *TemplateService) materialize(ctx context.Context, template *entities.Template, now time.Time) error {
+	rule, err := entities.ParseRecurrence(template.Recurrence)
+	if err != nil {
+		return err
+	}
+	location, err := template.Location()
+	if err != nil {
+		return err
+	}
+
+	due := template.NextRunAt.In(location)
+	next := rule.Next(due)
+	for !next.IsZero() && !next.After(now) {
+		next = rule.Next(next)
+	}
+	var nextRun *time.Time
+	if !next.IsZero() {
+		next = next.UTC()
+		nextRun = &next
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		claimed, err := s.templateRepo.AdvanceSchedule(ctx, template.ID, *template.NextRunAt, nextRun)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			return errRunClaimed
+		}
+		_, err = s.createList(ctx, template, template.Name+" ("+due.Format("2006-01-02")+")")
+		return err
+	})
+}
+
+// createList stores a new shopping list and its items built from a template
+func (s *TemplateService) createList(ctx context.Context, template *entities.Template, name string) (*entities.ShoppingList, error) {
+	list, items := template.Instantiate(name)
+	list.OwnerID = template.OwnerID
+	if err := s.shoppingListRepo.Create(ctx, list); err != nil {
+		return nil, err
+	}
+	if err := s.itemRepo.ApplyBatch(ctx, repositories.ItemBatch{Create: items}); err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		list.Items = append(list.Items, *item)
+	}
+	return list, nil
+}
+
+// applyInput validates a template input and copies it onto the template
+func (s *TemplateService) applyInput(ctx context.Context, template *entities.Template, input TemplateInput) error {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return entities.ErrInvalidInput
+	}
+
+	currency, err := validateBudget(input.Budget, input.Currency)
+	if err != nil {
+		return err
+	}
+
+	items := make([]entities.TemplateItem, len(input.Items))
+	for i, itemInput := range input.Items {
+		item, err := s.buildTemplateItem(ctx, template.OwnerID, itemInput, currency)
+		if err != nil {
+			return err
+		}
+		items[i] = item
+	}
+
+	if err := s.applySchedule(template, input); err != nil {
+		return err
+	}
+
+	template.Name = name
+	template.Description = input.Description
+	template.Budget = input.Budget
+	template.Currency = currency
+	template.SetItems(items)
+	return nil
+}
+
+// applySchedule validates the recurrence of a template input and schedules the first run,
+// keeping the current schedule when neither the rule, the start nor the time zone changes
+func (s *TemplateService) applySchedule(template *entities.Template, input TemplateInput) error {
+	timeZone := strings.TrimSpace(input.TimeZone)
+	if strings.TrimSpace(input.Recurrence) == "" {
+		if input.StartsAt != nil || timeZone != "" {
+			return entities.ErrInvalidInput
+		}
+		template.Recurrence = ""
+		template.TimeZone = ""
+		template.NextRunAt = nil
+		return nil
+	}
+
+	rule, err := entities.ParseRecurrence(input.Recurrence)
+	if err != nil {
+		return err
+	}
+	location, err := entities.LoadTimeZone(timeZone)
+	if err != nil {
+		return err
+	}
+	sameRule := rule.String() == template.Recurrence
+	if sameRule && timeZone == template.TimeZone && input.StartsAt == nil {
+		return nil
+	}
+
+	start := s.clock().In(location)
+	switch {
+	case input.StartsAt != nil:
+		start = input.StartsAt.In(location)
+	case sameRule && template.NextRunAt != nil:
+		// Only the time zone changes: runs keep their local time of day in the new zone
+		previous, err := template.Location()
+		if err != nil {
+			return err
+		}
+		local := template.NextRunAt.In(previous)
+		start = time.Date(local.Year(), local.Month(), local.Day(),
+			local.Hour(), local.Minute(), local.Second(), local.Nanosecond(), location)
+	}
+	first := rule.First(start).UTC()
+	template.Recurrence = rule.String()
+	template.TimeZone = timeZone
+	template.NextRunAt = &first
+	return nil
+}
+
+// buildTemplateItem validates a template item, pricing it in the template currency unless it names its own.
+// Its category must belong to the owner of the template.
+func (s *TemplateService) buildTemplateItem(
+	ctx context.Context,
+	ownerID *uuid.UUID,
+	input TemplateItemInput,
+	currency string,
+) (entities.TemplateItem, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return entities.TemplateItem{}, entities.ErrInvalidInput
+	}
+
+	unit := input.Unit
+	if unit == "" {
+		unit = entities.UnitPieces
+	}
+	if err := entities.ValidateQuantity(input.Quantity, unit); err != nil {
+		return entities.TemplateItem{}, err
+	}
+
+	if err := verifyCategoryOwner(ctx, s.categoryRepo, ownerID, input.CategoryID); err != nil {
+		return entities.TemplateItem{}, err
+	}
+
+	itemCurrency, err := validatePrice(input.UnitPrice, input.Currency, currency)
+	if err != nil {
+		return entities.TemplateItem{}, err
+	}
+
+	return entities.TemplateItem{
+		Name:       name,
+		Quantity:   input.Quantity,
+		Unit:       unit,
+		CategoryID: input.CategoryID,
+		UnitPrice:  input.UnitPrice,
+		Currency:   itemCurrency,
+	}, nil
+}
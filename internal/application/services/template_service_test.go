@@ -0,0 +1,451 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+)
+
+// MockTemplateRepository is a mock implementation of TemplateRepository
+type MockTemplateRepository struct {
+	mock.Mock
+}
+
+func (m *MockTemplateRepository) Create(ctx context.Context, template *entities.Template) error {
+	args := m.Called(ctx, template)
+	return args.Error(0)
+}
+
+func (m *MockTemplateRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Template, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*entities.Template), args.Error(1)
+}
+
+func (m *MockTemplateRepository) GetAll(ctx context.Context, ownerID *uuid.UUID) ([]*entities.Template, error) {
+	args := m.Called(ctx, ownerID)
+	return args.Get(0).([]*entities.Template), args.Error(1)
+}
+
+func (m *MockTemplateRepository) Update(ctx context.Context, template *entities.Template) error {
+	args := m.Called(ctx, template)
+	return args.Error(0)
+}
+
+func (m *MockTemplateRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTemplateRepository) GetDue(ctx context.Context, now time.Time) ([]*entities.Template, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).([]*entities.Template), args.Error(1)
+}
+
+func (m *MockTemplateRepository) AdvanceSchedule(ctx context.Context, id uuid.UUID, due time.Time, next *time.Time) (bool, error) {
+	args := m.Called(ctx, id, due, next)
+	return args.Bool(0), args.Error(1)
+}
+
+// templateTestNow is the fixed time the template service sees in tests, a Wednesday
+var templateTestNow = time.Date(2026, 10, 14, 9, 30, 0, 0, time.UTC)
+
+func setupTemplateTest() (*TemplateService, *MockTemplateRepository, *MockShoppingListRepository, *MockItemRepository, *MockCategoryRepository) {
+	templateRepo := &MockTemplateRepository{}
+	listRepo := &MockShoppingListRepository{}
+	itemRepo := &MockItemRepository{}
+	categoryRepo := &MockCategoryRepository{}
+	service := NewTemplateService(templateRepo, listRepo, itemRepo, categoryRepo, passThroughTransactor{},
+		func() time.Time { return templateTestNow })
+	return service, templateRepo, listRepo, itemRepo, categoryRepo
+}
+
+func TestTemplateService_CreateTemplate(t *testing.T) {
+	categoryID := uuid.New()
+	price := int64(129)
+
+	tests := []struct {
+		name          string
+		input         TemplateInput
+		setupMocks    func(*MockTemplateRepository, *MockCategoryRepository)
+		expectedError error
+	}{
+		{
+			name: "successful creation",
+			input: TemplateInput{
+				Name:     "  Weekly basics ",
+				Currency: "eur",
+				Items: []TemplateItemInput{
+					{Name: "Milk", Quantity: 2, CategoryID: &categoryID, UnitPrice: &price},
+					{Name: "Flour", Quantity: 1.5, Unit: entities.UnitKilogram},
+				},
+			},
+			setupMocks: func(repo *MockTemplateRepository, categoryRepo *MockCategoryRepository) {
+				categoryRepo.On("GetByID", mock.Anything, categoryID).Return(&entities.Category{ID: categoryID}, nil)
+				repo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Template")).Return(nil)
+			},
+		},
+		{
+			name:          "empty name should fail",
+			input:         TemplateInput{Name: "  "},
+			setupMocks:    func(repo *MockTemplateRepository, categoryRepo *MockCategoryRepository) {},
+			expectedError: entities.ErrInvalidInput,
+		},
+		{
+			name:          "unnamed item should fail",
+			input:         TemplateInput{Name: "Weekly basics", Items: []TemplateItemInput{{Quantity: 1}}},
+			setupMocks:    func(repo *MockTemplateRepository, categoryRepo *MockCategoryRepository) {},
+			expectedError: entities.ErrInvalidInput,
+		},
+		{
+			name:          "fractional pieces should fail",
+			input:         TemplateInput{Name: "Weekly basics", Items: []TemplateItemInput{{Name: "Eggs", Quantity: 1.5}}},
+			setupMocks:    func(repo *MockTemplateRepository, categoryRepo *MockCategoryRepository) {},
+			expectedError: entities.ErrInvalidInput,
+		},
+		{
+			name: "unknown category should fail",
+			input: TemplateInput{
+				Name:  "Weekly basics",
+				Items: []TemplateItemInput{{Name: "Milk", Quantity: 1, CategoryID: &categoryID}},
+			},
+			setupMocks: func(repo *MockTemplateRepository, categoryRepo *MockCategoryRepository) {
+				categoryRepo.On("GetByID", mock.Anything, categoryID).Return((*entities.Category)(nil), entities.ErrCategoryNotFound)
+			},
+			expectedError: entities.ErrCategoryNotFound,
+		},
+		{
+			name: "price without any currency should fail",
+			input: TemplateInput{
+				Name:  "Weekly basics",
+				Items: []TemplateItemInput{{Name: "Milk", Quantity: 1, UnitPrice: &price}},
+			},
+			setupMocks:    func(repo *MockTemplateRepository, categoryRepo *MockCategoryRepository) {},
+			expectedError: entities.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, templateRepo, _, _, categoryRepo := setupTemplateTest()
+			tt.setupMocks(templateRepo, categoryRepo)
+
+			result, err := service.CreateTemplate(context.Background(), tt.input)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "Weekly basics", result.Name)
+				assert.Equal(t, "EUR", result.Currency)
+				require.Len(t, result.Items, 2)
+				assert.Equal(t, result.ID, result.Items[0].TemplateID)
+				assert.Equal(t, entities.UnitPieces, result.Items[0].Unit)
+				assert.Equal(t, "EUR", result.Items[0].Currency)
+				assert.Equal(t, 1, result.Items[1].Position)
+				assert.Equal(t, entities.UnitKilogram, result.Items[1].Unit)
+			}
+
+			templateRepo.AssertExpectations(t)
+			categoryRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTemplateService_UpdateTemplate(t *testing.T) {
+	t.Run("replaces the items", func(t *testing.T) {
+		service, templateRepo, _, _, _ := setupTemplateTest()
+		template := entities.NewTemplate("BBQ", "")
+		template.SetItems([]entities.TemplateItem{{Name: "Charcoal", Quantity: 1, Unit: entities.UnitPack}})
+		templateRepo.On("GetByID", mock.Anything, template.ID).Return(template, nil)
+		templateRepo.On("Update", mock.Anything, template).Return(nil)
+
+		result, err := service.UpdateTemplate(context.Background(), template.ID, TemplateInput{
+			Name:        "BBQ party",
+			Description: "Summer",
+			Items:       []TemplateItemInput{{Name: "Sausages", Quantity: 12}},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "BBQ party", result.Name)
+		assert.Equal(t, "Summer", result.Description)
+		require.Len(t, result.Items, 1)
+		assert.Equal(t, "Sausages", result.Items[0].Name)
+		templateRepo.AssertExpectations(t)
+	})
+
+	t.Run("template not found", func(t *testing.T) {
+		service, templateRepo, _, _, _ := setupTemplateTest()
+		templateRepo.On("GetByID", mock.Anything, mock.Anything).Return((*entities.Template)(nil), entities.ErrTemplateNotFound)
+
+		_, err := service.UpdateTemplate(context.Background(), uuid.New(), TemplateInput{Name: "BBQ party"})
+		assert.Equal(t, entities.ErrTemplateNotFound, err)
+		templateRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTemplateService_InstantiateTemplate(t *testing.T) {
+	budget := int64(4000)
+	template := entities.NewTemplate("Weekly basics", "Every Saturday")
+	template.Budget = &budget
+	template.Currency = "EUR"
+	template.SetItems([]entities.TemplateItem{
+		{Name: "Milk", Quantity: 2, Unit: entities.UnitPieces},
+		{Name: "Flour", Quantity: 1, Unit: entities.UnitKilogram},
+	})
+
+	tests := []struct {
+		name         string
+		listName     string
+		expectedName string
+	}{
+		{name: "keeps the template name", expectedName: "Weekly basics"},
+		{name: "uses the given name", listName: " Week 42 ", expectedName: "Week 42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, templateRepo, listRepo, itemRepo, _ := setupTemplateTest()
+			templateRepo.On("GetByID", mock.Anything, template.ID).Return(template, nil)
+			listRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.ShoppingList")).Return(nil)
+			var batch repositories.ItemBatch
+			itemRepo.On("ApplyBatch", mock.Anything, mock.AnythingOfType("repositories.ItemBatch")).
+				Run(func(args mock.Arguments) { batch = args.Get(1).(repositories.ItemBatch) }).
+				Return(nil)
+
+			list, err := service.InstantiateTemplate(context.Background(), template.ID, tt.listName)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedName, list.Name)
+			assert.Equal(t, "Every Saturday", list.Description)
+			assert.Equal(t, budget, *list.Budget)
+			assert.Equal(t, "EUR", list.Currency)
+			require.Len(t, list.Items, 2)
+			assert.Equal(t, "Milk", list.Items[0].Name)
+			assert.Equal(t, entities.UnitKilogram, list.Items[1].Unit)
+			require.Len(t, batch.Create, 2)
+			assert.Equal(t, list.ID, batch.Create[0].ShoppingListID)
+			listRepo.AssertExpectations(t)
+			itemRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTemplateService_InstantiateTemplate_Failures(t *testing.T) {
+	t.Run("template not found", func(t *testing.T) {
+		service, templateRepo, listRepo, _, _ := setupTemplateTest()
+		templateRepo.On("GetByID", mock.Anything, mock.Anything).Return((*entities.Template)(nil), entities.ErrTemplateNotFound)
+
+		_, err := service.InstantiateTemplate(context.Background(), uuid.New(), "")
+		assert.Equal(t, entities.ErrTemplateNotFound, err)
+		listRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("item creation fails", func(t *testing.T) {
+		service, templateRepo, listRepo, itemRepo, _ := setupTemplateTest()
+		template := entities.NewTemplate("Weekly basics", "")
+		templateRepo.On("GetByID", mock.Anything, template.ID).Return(template, nil)
+		listRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		itemRepo.On("ApplyBatch", mock.Anything, mock.Anything).Return(fmt.Errorf("database error"))
+
+		list, err := service.InstantiateTemplate(context.Background(), template.ID, "")
+		assert.EqualError(t, err, "database error")
+		assert.Nil(t, list)
+	})
+}
+
+func TestTemplateService_Schedule(t *testing.T) {
+	saturday := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+	startsAt := time.Date(2026, 11, 1, 7, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		input         TemplateInput
+		expectedRule  string
+		expectedNext  *time.Time
+		expectedError error
+	}{
+		{name: "without recurrence", input: TemplateInput{Name: "BBQ party"}},
+		{
+			name:         "first run defaults to now",
+			input:        TemplateInput{Name: "Weekly basics", Recurrence: "freq=weekly;byday=sa"},
+			expectedRule: "FREQ=WEEKLY;BYDAY=SA",
+			expectedNext: &saturday,
+		},
+		{
+			name:         "first run at the start",
+			input:        TemplateInput{Name: "Monthly", Recurrence: "FREQ=MONTHLY;BYMONTHDAY=1", StartsAt: &startsAt},
+			expectedRule: "FREQ=MONTHLY;BYMONTHDAY=1",
+			expectedNext: &startsAt,
+		},
+		{
+			name:          "invalid rule",
+			input:         TemplateInput{Name: "Weekly basics", Recurrence: "FREQ=HOURLY"},
+			expectedError: entities.ErrInvalidInput,
+		},
+		{
+			name:          "start without rule",
+			input:         TemplateInput{Name: "Weekly basics", StartsAt: &startsAt},
+			expectedError: entities.ErrInvalidInput,
+		},
+		{
+			name:          "time zone without rule",
+			input:         TemplateInput{Name: "Weekly basics", TimeZone: "Europe/Madrid"},
+			expectedError: entities.ErrInvalidInput,
+		},
+		{
+			name:          "unknown time zone",
+			input:         TemplateInput{Name: "Weekly basics", Recurrence: "FREQ=DAILY", TimeZone: "Mars/Olympus"},
+			expectedError: entities.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, templateRepo, _, _, _ := setupTemplateTest()
+			templateRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			result, err := service.CreateTemplate(context.Background(), tt.input)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedRule, result.Recurrence)
+			assert.Equal(t, tt.expectedNext, result.NextRunAt)
+		})
+	}
+
+	t.Run("update keeps the schedule", func(t *testing.T) {
+		service, templateRepo, _, _, _ := setupTemplateTest()
+		next := time.Date(2026, 10, 24, 9, 30, 0, 0, time.UTC)
+		template := entities.NewTemplate("Weekly basics", "")
+		template.Recurrence = "FREQ=WEEKLY;BYDAY=SA"
+		template.NextRunAt = &next
+		templateRepo.On("GetByID", mock.Anything, template.ID).Return(template, nil)
+		templateRepo.On("Update", mock.Anything, template).Return(nil)
+
+		result, err := service.UpdateTemplate(context.Background(), template.ID, TemplateInput{
+			Name:       "Weekly basics",
+			Recurrence: "RRULE:FREQ=WEEKLY;BYDAY=SA",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, next, *result.NextRunAt)
+	})
+
+	t.Run("time zone change keeps the local time of day", func(t *testing.T) {
+		service, templateRepo, _, _, _ := setupTemplateTest()
+		next := time.Date(2026, 10, 24, 9, 30, 0, 0, time.UTC)
+		template := entities.NewTemplate("Weekly basics", "")
+		template.Recurrence = "FREQ=WEEKLY;BYDAY=SA"
+		template.NextRunAt = &next
+		templateRepo.On("GetByID", mock.Anything, template.ID).Return(template, nil)
+		templateRepo.On("Update", mock.Anything, template).Return(nil)
+
+		result, err := service.UpdateTemplate(context.Background(), template.ID, TemplateInput{
+			Name:       "Weekly basics",
+			Recurrence: "FREQ=WEEKLY;BYDAY=SA",
+			TimeZone:   "Europe/Madrid",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Europe/Madrid", result.TimeZone)
+		// 09:30 in Madrid, two hours ahead of UTC in October
+		assert.Equal(t, time.Date(2026, 10, 24, 7, 30, 0, 0, time.UTC), *result.NextRunAt)
+	})
+}
+
+func TestTemplateService_MaterializeDueTemplates(t *testing.T) {
+	due := time.Date(2026, 10, 3, 8, 0, 0, 0, time.UTC)
+	weekly := entities.NewTemplate("Weekly basics", "")
+	weekly.Recurrence = "FREQ=WEEKLY;BYDAY=SA"
+	weekly.NextRunAt = &due
+	weekly.SetItems([]entities.TemplateItem{{Name: "Milk", Quantity: 1, Unit: entities.UnitPieces}})
+
+	claimedDue := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	daily := entities.NewTemplate("Daily bread", "")
+	daily.Recurrence = "FREQ=DAILY"
+	daily.NextRunAt = &claimedDue
+
+	service, templateRepo, listRepo, itemRepo, _ := setupTemplateTest()
+	templateRepo.On("GetDue", mock.Anything, templateTestNow).Return([]*entities.Template{weekly, daily}, nil)
+	// Two Saturdays were missed; the next run is the coming one
+	nextSaturday := time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC)
+	templateRepo.On("AdvanceSchedule", mock.Anything, weekly.ID, due, &nextSaturday).Return(true, nil)
+	tomorrow := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+	templateRepo.On("AdvanceSchedule", mock.Anything, daily.ID, claimedDue, &tomorrow).Return(false, nil)
+
+	var created *entities.ShoppingList
+	listRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.ShoppingList")).
+		Run(func(args mock.Arguments) { created = args.Get(1).(*entities.ShoppingList) }).
+		Return(nil).Once()
+	itemRepo.On("ApplyBatch", mock.Anything, mock.AnythingOfType("repositories.ItemBatch")).Return(nil).Once()
+
+	count, err := service.MaterializeDueTemplates(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.NotNil(t, created)
+	assert.Equal(t, "Weekly basics (2026-10-03)", created.Name)
+	templateRepo.AssertExpectations(t)
+	listRepo.AssertExpectations(t)
+	itemRepo.AssertExpectations(t)
+}
+
+func TestTemplateService_MaterializeDueTemplates_TimeZone(t *testing.T) {
+	// 00:30 on Saturday 24 October in Madrid, the day before daylight saving time ends
+	due := time.Date(2026, 10, 23, 22, 30, 0, 0, time.UTC)
+	daily := entities.NewTemplate("Daily bread", "")
+	daily.Recurrence = "FREQ=DAILY"
+	daily.TimeZone = "Europe/Madrid"
+	daily.NextRunAt = &due
+
+	service, templateRepo, listRepo, itemRepo, _ := setupTemplateTest()
+	now := time.Date(2026, 10, 26, 12, 0, 0, 0, time.UTC)
+	service.clock = func() time.Time { return now }
+	templateRepo.On("GetDue", mock.Anything, now).Return([]*entities.Template{daily}, nil)
+	// 00:30 on Tuesday in Madrid, which is an hour ahead of UTC again
+	next := time.Date(2026, 10, 26, 23, 30, 0, 0, time.UTC)
+	templateRepo.On("AdvanceSchedule", mock.Anything, daily.ID, due, &next).Return(true, nil)
+
+	var created *entities.ShoppingList
+	listRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.ShoppingList")).
+		Run(func(args mock.Arguments) { created = args.Get(1).(*entities.ShoppingList) }).
+		Return(nil)
+	itemRepo.On("ApplyBatch", mock.Anything, mock.AnythingOfType("repositories.ItemBatch")).Return(nil)
+
+	count, err := service.MaterializeDueTemplates(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.NotNil(t, created)
+	assert.Equal(t, "Daily bread (2026-10-24)", created.Name)
+	templateRepo.AssertExpectations(t)
+}
+
+func TestTemplateService_MaterializeDueTemplates_Failures(t *testing.T) {
+	due := time.Date(2026, 10, 10, 8, 0, 0, 0, time.UTC)
+	broken := entities.NewTemplate("Broken", "")
+	broken.Recurrence = "FREQ=WEEKLY"
+	broken.NextRunAt = &due
+
+	service, templateRepo, listRepo, _, _ := setupTemplateTest()
+	templateRepo.On("GetDue", mock.Anything, templateTestNow).Return([]*entities.Template{broken}, nil)
+	templateRepo.On("AdvanceSchedule", mock.Anything, broken.ID, due, mock.Anything).Return(true, nil)
+	listRepo.On("Create", mock.Anything, mock.Anything).Return(fmt.Errorf("database error"))
+
+	count, err := service.MaterializeDueTemplates(context.Background())
+
+	assert.Zero(t, count)
+	assert.EqualError(t, err, "database error")
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+
+	// Registers the GIF decoder with image.Decode
+	_ "image/gif"
+
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// thumbnailQuality is the JPEG quality of thumbnails of JPEG images
+const thumbnailQuality = 80
+
+// makeThumbnail scales an image down to fit in a square of entities.ThumbnailSize pixels,
+// averaging the pixels each thumbnail pixel covers, and encodes it.
+// JPEG images get JPEG thumbnails; other images get PNG thumbnails, which keep transparency.
+func makeThumbnail(src image.Image, contentType string) ([]byte, string, error) {
+	bounds := src.Bounds()
+	width, height := thumbnailDimensions(bounds.Dx(), bounds.Dy())
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := bounds.Min.Y + (y+1)*bounds.Dy()/height
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := bounds.Min.X + (x+1)*bounds.Dx()/width
+			dst.SetRGBA(x, y, averageColor(src, x0, y0, x1, y1))
+		}
+	}
+
+	var buf bytes.Buffer
+	if contentType == entities.ContentTypeJPEG {
+		if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), entities.ContentTypeJPEG, nil
+	}
+	if err := png.Encode(&buf, dst); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), entities.ContentTypePNG, nil
+}
+
+// thumbnailDimensions fits a width and height in the thumbnail square, keeping the aspect ratio.
+// Images that already fit keep their size.
+func thumbnailDimensions(width, height int) (int, int) {
+	longest := max(width, height)
+	if longest <= entities.ThumbnailSize {
+		return width, height
+	}
+	return max(1, width*entities.ThumbnailSize/longest), max(1, height*entities.ThumbnailSize/longest)
+}
+
+// averageColor averages the premultiplied colors of the pixels in [x0, x1) × [y0, y1)
+func averageColor(src image.Image, x0, y0, x1, y1 int) color.RGBA {
+	var r, g, b, a, n uint64
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			pr, pg, pb, pa := src.At(x, y).RGBA()
+			r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+			n++
+		}
+	}
+	return color.RGBA{R: uint8(r / n >> 8), G: uint8(g / n >> 8), B: uint8(b / n >> 8), A: uint8(a / n >> 8)}
+}
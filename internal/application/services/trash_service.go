@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+)
+
+// TrashService handles the deleted shopping lists and items that can still be restored
+type TrashService struct {
+	shoppingListRepo repositories.ShoppingListRepository
+	itemRepo         repositories.ItemRepository
+	imageRepo        repositories.ItemImageRepository
+	blobs            repositories.BlobStore
+	transactor       repositories.Transactor
+}
+
+// NewTrashService creates a new trash service
+func NewTrashService(
+	shoppingListRepo repositories.ShoppingListRepository,
+	itemRepo repositories.ItemRepository,
+	imageRepo repositories.ItemImageRepository,
+	blobs repositories.BlobStore,
+	transactor repositories.Transactor,
+) *TrashService {
+	return &TrashService{
+		shoppingListRepo: shoppingListRepo,
+		itemRepo:         itemRepo,
+		imageRepo:        imageRepo,
+		blobs:            blobs,
+		transactor:       transactor,
+	}
+}
+
+// GetTrash retrieves the shopping lists the caller owns that are in the trash,
+// and the items trashed from the lists they own or are a member of
+func (s *TrashService) GetTrash(ctx context.Context) (*entities.Trash, error) {
+	lists, err := s.shoppingListRepo.GetTrashed(ctx, callerScope(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.itemRepo.GetTrashed(ctx, callerScope(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.Trash{Lists: lists, Items: items}, nil
+}
+
+// RestoreShoppingList takes a shopping list out of the trash, along with its items.
+// Trashed lists cannot be read, so the restore is rolled back when the caller is not an owner of it.
+func (s *TrashService) RestoreShoppingList(ctx context.Context, id uuid.UUID) (*entities.ShoppingList, error) {
+	var list *entities.ShoppingList
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.shoppingListRepo.Restore(ctx, id); err != nil {
+			return err
+		}
+		var err error
+		list, err = getListWithRole(ctx, s.shoppingListRepo, id, entities.ListRoleOwner)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// RestoreItem takes an item out of the trash, appending it to its shopping list.
+// The caller must be able to change the list, which may not be in the trash itself.
+func (s *TrashService) RestoreItem(ctx context.Context, id uuid.UUID) (*entities.Item, error) {
+	if err := s.checkTrashedItemAccess(ctx, id); err != nil {
+		return nil, err
+	}
+
+	var item *entities.Item
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.itemRepo.Restore(ctx, id); err != nil {
+			return err
+		}
+		var err error
+		item, err = getEditableItem(ctx, s.itemRepo, s.shoppingListRepo, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// checkTrashedItemAccess verifies that the caller may change the shopping list of an item in the trash,
+// whether or not the list is in the trash too. Items of lists the caller holds no role in are reported
+// as not found, so that a trashed list does not give their existence away.
+func (s *TrashService) checkTrashedItemAccess(ctx context.Context, id uuid.UUID) error {
+	if _, ok := CallerFromContext(ctx); !ok {
+		return nil
+	}
+
+	item, err := s.itemRepo.GetTrashedByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	list, err := s.shoppingListRepo.GetByID(ctx, item.ShoppingListID)
+	if err == entities.ErrShoppingListNotFound {
+		list, err = s.shoppingListRepo.GetTrashedByID(ctx, item.ShoppingListID)
+	}
+	if err == nil {
+		err = checkRole(ctx, list, entities.ListRoleEditor)
+	}
+	if err == entities.ErrShoppingListNotFound {
+		return entities.ErrItemNotFound
+	}
+	return err
+}
+
+// PurgeTrash permanently deletes the shopping lists and items that have been in the trash
+// for longer than the retention period, along with the images of the purged items,
+// returning how many lists and items were purged
+func (s *TrashService) PurgeTrash(ctx context.Context, retention time.Duration) (lists, items int64, err error) {
+	if retention < 0 {
+		return 0, 0, entities.ErrInvalidInput
+	}
+	deletedBefore := time.Now().Add(-retention)
+
+	var images []*entities.ItemImage
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		images, err = s.imageRepo.GetTrashedBefore(ctx, deletedBefore)
+		if err != nil {
+			return err
+		}
+
+		items, err = s.itemRepo.PurgeTrashed(ctx, deletedBefore)
+		if err != nil {
+			return err
+		}
+
+		lists, err = s.shoppingListRepo.PurgeTrashed(ctx, deletedBefore)
+		return err
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, img := range images {
+		deleteImageBlobs(ctx, s.blobs, img)
+	}
+
+	return lists, items, nil
+}
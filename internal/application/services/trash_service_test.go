@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/infrastructure/storage"
+)
+
+func TestTrashService_GetTrash(t *testing.T) {
+	listRepo := &MockShoppingListRepository{}
+	itemRepo := &MockItemRepository{}
+	service := NewTrashService(listRepo, itemRepo, &MockItemImageRepository{}, storage.NewMemoryBlobStore(), passThroughTransactor{})
+
+	lists := []*entities.ShoppingList{{ID: uuid.New(), Name: "Groceries"}}
+	items := []*entities.Item{{ID: uuid.New(), Name: "Milk"}}
+	listRepo.On("GetTrashed", mock.Anything, (*uuid.UUID)(nil)).Return(lists, nil)
+	itemRepo.On("GetTrashed", mock.Anything, (*uuid.UUID)(nil)).Return(items, nil)
+
+	trash, err := service.GetTrash(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, lists, trash.Lists)
+	assert.Equal(t, items, trash.Items)
+}
+
+func TestTrashService_RestoreShoppingList(t *testing.T) {
+	tests := []struct {
+		name          string
+		restoreErr    error
+		expectedError error
+	}{
+		{name: "restores the list"},
+		{name: "list not in trash", restoreErr: entities.ErrShoppingListNotFound, expectedError: entities.ErrShoppingListNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listRepo := &MockShoppingListRepository{}
+			service := NewTrashService(listRepo, &MockItemRepository{}, &MockItemImageRepository{}, storage.NewMemoryBlobStore(), passThroughTransactor{})
+
+			listID := uuid.New()
+			listRepo.On("Restore", mock.Anything, listID).Return(tt.restoreErr)
+			if tt.restoreErr == nil {
+				listRepo.On("GetByID", mock.Anything, listID).Return(&entities.ShoppingList{ID: listID}, nil)
+			}
+
+			result, err := service.RestoreShoppingList(context.Background(), listID)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, listID, result.ID)
+			}
+			listRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTrashService_RestoreItem(t *testing.T) {
+	itemRepo := &MockItemRepository{}
+	service := NewTrashService(&MockShoppingListRepository{}, itemRepo, &MockItemImageRepository{}, storage.NewMemoryBlobStore(), passThroughTransactor{})
+
+	itemID := uuid.New()
+	itemRepo.On("Restore", mock.Anything, itemID).Return(nil)
+	itemRepo.On("GetByID", mock.Anything, itemID).Return(&entities.Item{ID: itemID}, nil)
+
+	result, err := service.RestoreItem(context.Background(), itemID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, itemID, result.ID)
+	itemRepo.AssertExpectations(t)
+}
+
+func TestTrashService_RestoreItem_Access(t *testing.T) {
+	owner, editor, viewer := uuid.New(), uuid.New(), uuid.New()
+	listID := uuid.New()
+	itemID := uuid.New()
+	list := &entities.ShoppingList{
+		ID:      listID,
+		OwnerID: &owner,
+		Members: []entities.ListMember{
+			{ShoppingListID: listID, UserID: editor, Role: entities.ListRoleEditor},
+			{ShoppingListID: listID, UserID: viewer, Role: entities.ListRoleViewer},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		caller        uuid.UUID
+		listTrashed   bool
+		expectedError error
+	}{
+		{name: "editor restores an item of a live list", caller: editor},
+		{name: "viewer may not restore items", caller: viewer, expectedError: entities.ErrForbidden},
+		{name: "stranger does not learn the item exists", caller: uuid.New(), expectedError: entities.ErrItemNotFound},
+		{name: "editor is told to restore the list first", caller: editor, listTrashed: true, expectedError: entities.ErrShoppingListNotFound},
+		{name: "stranger does not learn the item of a trashed list exists", caller: uuid.New(), listTrashed: true, expectedError: entities.ErrItemNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listRepo := &MockShoppingListRepository{}
+			itemRepo := &MockItemRepository{}
+			service := NewTrashService(listRepo, itemRepo, &MockItemImageRepository{}, storage.NewMemoryBlobStore(), passThroughTransactor{})
+
+			itemRepo.On("GetTrashedByID", mock.Anything, itemID).Return(&entities.Item{ID: itemID, ShoppingListID: listID}, nil)
+			if tt.listTrashed {
+				listRepo.On("GetByID", mock.Anything, listID).Return((*entities.ShoppingList)(nil), entities.ErrShoppingListNotFound)
+				listRepo.On("GetTrashedByID", mock.Anything, listID).Return(list, nil)
+				itemRepo.On("Restore", mock.Anything, itemID).Return(entities.ErrShoppingListNotFound)
+			} else {
+				listRepo.On("GetByID", mock.Anything, listID).Return(list, nil)
+				itemRepo.On("Restore", mock.Anything, itemID).Return(nil)
+				itemRepo.On("GetByID", mock.Anything, itemID).Return(&entities.Item{ID: itemID, ShoppingListID: listID}, nil)
+			}
+
+			result, err := service.RestoreItem(WithCaller(context.Background(), tt.caller), itemID)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Nil(t, result)
+				if tt.expectedError != entities.ErrShoppingListNotFound {
+					itemRepo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, itemID, result.ID)
+		})
+	}
+}
+
+func TestTrashService_RestoreShoppingList_OtherOwner(t *testing.T) {
+	listRepo := &MockShoppingListRepository{}
+	service := NewTrashService(listRepo, &MockItemRepository{}, &MockItemImageRepository{}, storage.NewMemoryBlobStore(), passThroughTransactor{})
+
+	listID := uuid.New()
+	owner := uuid.New()
+	listRepo.On("Restore", mock.Anything, listID).Return(nil)
+	listRepo.On("GetByID", mock.Anything, listID).Return(&entities.ShoppingList{ID: listID, OwnerID: &owner}, nil)
+
+	result, err := service.RestoreShoppingList(WithCaller(context.Background(), uuid.New()), listID)
+
+	assert.Equal(t, entities.ErrShoppingListNotFound, err)
+	assert.Nil(t, result)
+}
+
+func TestTrashService_PurgeTrash(t *testing.T) {
+	listRepo := &MockShoppingListRepository{}
+	itemRepo := &MockItemRepository{}
+	imageRepo := &MockItemImageRepository{}
+	blobs := storage.NewMemoryBlobStore()
+	service := NewTrashService(listRepo, itemRepo, imageRepo, blobs, passThroughTransactor{})
+
+	ctx := context.Background()
+	purged := &entities.ItemImage{ItemID: uuid.New(), BlobKey: "items/a", ThumbnailKey: "items/a-thumb"}
+	require.NoError(t, blobs.Put(ctx, purged.BlobKey, []byte("image")))
+	require.NoError(t, blobs.Put(ctx, purged.ThumbnailKey, []byte("thumbnail")))
+	require.NoError(t, blobs.Put(ctx, "items/kept", []byte("image")))
+
+	retention := 24 * time.Hour
+	cutoff := mock.MatchedBy(func(deletedBefore time.Time) bool {
+		return time.Since(deletedBefore) >= retention && time.Since(deletedBefore) < retention+time.Minute
+	})
+	imageRepo.On("GetTrashedBefore", mock.Anything, cutoff).Return([]*entities.ItemImage{purged}, nil)
+	itemRepo.On("PurgeTrashed", mock.Anything, cutoff).Return(int64(3), nil)
+	listRepo.On("PurgeTrashed", mock.Anything, cutoff).Return(int64(1), nil)
+
+	lists, items, err := service.PurgeTrash(ctx, retention)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), lists)
+	assert.Equal(t, int64(3), items)
+	_, err = blobs.Get(ctx, purged.BlobKey)
+	assert.Equal(t, entities.ErrBlobNotFound, err)
+	_, err = blobs.Get(ctx, purged.ThumbnailKey)
+	assert.Equal(t, entities.ErrBlobNotFound, err)
+	_, err = blobs.Get(ctx, "items/kept")
+	assert.NoError(t, err)
+	itemRepo.AssertExpectations(t)
+	listRepo.AssertExpectations(t)
+	imageRepo.AssertExpectations(t)
+}
+
+func TestTrashService_PurgeTrash_Errors(t *testing.T) {
+	service := NewTrashService(&MockShoppingListRepository{}, &MockItemRepository{}, &MockItemImageRepository{}, storage.NewMemoryBlobStore(), passThroughTransactor{})
+	_, _, err := service.PurgeTrash(context.Background(), -time.Hour)
+	assert.Equal(t, entities.ErrInvalidInput, err)
+
+	itemRepo := &MockItemRepository{}
+	imageRepo := &MockItemImageRepository{}
+	blobs := storage.NewMemoryBlobStore()
+	service = NewTrashService(&MockShoppingListRepository{}, itemRepo, imageRepo, blobs, passThroughTransactor{})
+	image := &entities.ItemImage{ItemID: uuid.New(), BlobKey: "items/a", ThumbnailKey: "items/a-thumb"}
+	require.NoError(t, blobs.Put(context.Background(), image.BlobKey, []byte("image")))
+	imageRepo.On("GetTrashedBefore", mock.Anything, mock.Anything).Return([]*entities.ItemImage{image}, nil)
+	itemRepo.On("PurgeTrashed", mock.Anything, mock.Anything).Return(int64(0), fmt.Errorf("database error"))
+	_, _, err = service.PurgeTrash(context.Background(), time.Hour)
+	assert.Error(t, err)
+
+	// Images of items that were not purged are kept
+	_, err = blobs.Get(context.Background(), image.BlobKey)
+	assert.NoError(t, err)
+}
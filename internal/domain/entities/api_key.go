@@ -0,0 +1,86 @@
+package entities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyPrefix starts every API key, so that leaked keys are easy to recognize
+const APIKeyPrefix = "slk_"
+
+// MaxAPIKeyNameLength is the length limit of an API key name, in characters
+const MaxAPIKeyNameLength = 100
+
+// apiKeyDisplayLength is how many leading characters of a key are kept to tell keys apart
+const apiKeyDisplayLength = 12
+
+// APIKeyScope tells what an API key may do
+type APIKeyScope string
+
+// API key scopes
+const (
+	APIKeyScopeReadOnly  APIKeyScope = "read_only"
+	APIKeyScopeReadWrite APIKeyScope = "read_write"
+)
+
+// IsValid reports whether the scope is known
+func (s APIKeyScope) IsValid() bool {
+	return s == APIKeyScopeReadOnly || s == APIKeyScopeReadWrite
+}
+
+// APIKey lets scripts act on behalf of a user without logging in.
+// Only a SHA-256 hash of the key is stored; Prefix holds its first characters for display.
+type APIKey struct {
+	ID         uuid.UUID   `json:"id" gorm:"type:uuid;primary_key"`
+	UserID     uuid.UUID   `json:"-" gorm:"type:uuid;not null;index"`
+	Name       string      `json:"name" gorm:"type:varchar(100);not null"`
+	Prefix     string      `json:"prefix" gorm:"type:varchar(16);not null"`
+	KeyHash    string      `json:"-" gorm:"type:char(64);not null;uniqueIndex"`
+	Scope      APIKeyScope `json:"scope" gorm:"type:varchar(16);not null"`
+	ExpiresAt  *time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time  `json:"last_used_at"`
+	CreatedAt  time.Time   `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// NewAPIKey creates an API key for a user and returns it along with the key itself,
+// which is not stored and cannot be recovered later
+func NewAPIKey(userID uuid.UUID, name string, scope APIKeyScope, expiresAt *time.Time) (*APIKey, string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", err
+	}
+	key := APIKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	return &APIKey{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      name,
+		Prefix:    key[:apiKeyDisplayLength],
+		KeyHash:   HashAPIKey(key),
+		Scope:     scope,
+		ExpiresAt: expiresAt,
+	}, key, nil
+}
+
+// HashAPIKey returns the hex-encoded SHA-256 hash an API key is looked up by.
+// Keys are random, so a fast hash is enough.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(key)))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsExpired reports whether the key can no longer be used at a given time
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// AllowsWrites reports whether the key may change data
+func (k *APIKey) AllowsWrites() bool {
+	return k.Scope == APIKeyScopeReadWrite
+}
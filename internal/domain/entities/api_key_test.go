@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	userID := uuid.New()
+	apiKey, key, err := NewAPIKey(userID, "Home Assistant", APIKeyScopeReadOnly, nil)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(key, APIKeyPrefix))
+	assert.Equal(t, key[:len(apiKey.Prefix)], apiKey.Prefix)
+	assert.Equal(t, HashAPIKey(key), apiKey.KeyHash)
+	assert.NotContains(t, apiKey.KeyHash, key)
+	assert.Equal(t, userID, apiKey.UserID)
+	assert.False(t, apiKey.AllowsWrites())
+
+	_, other, err := NewAPIKey(userID, "Home Assistant", APIKeyScopeReadOnly, nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, key, other)
+}
+
+func TestAPIKey_IsExpired(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Hour)
+
+	assert.False(t, (&APIKey{}).IsExpired(now))
+	assert.False(t, (&APIKey{ExpiresAt: &later}).IsExpired(now))
+	assert.True(t, (&APIKey{ExpiresAt: &later}).IsExpired(later))
+}
+
+func TestAPIKeyScope_IsValid(t *testing.T) {
+	assert.True(t, APIKeyScopeReadOnly.IsValid())
+	assert.True(t, APIKeyScopeReadWrite.IsValid())
+	assert.False(t, APIKeyScope("admin").IsValid())
+}
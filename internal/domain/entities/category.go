@@ -0,0 +1,77 @@
+package entities
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Category represents an aisle or section that items can be grouped by.
+// Each user has their own categories, and the items of a list are grouped by those of its owner.
+type Category struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	OwnerID   *uuid.UUID `json:"owner_id" gorm:"type:uuid;uniqueIndex:idx_categories_owner_id_name"`
+	Name      string     `json:"name" gorm:"not null;uniqueIndex:idx_categories_owner_id_name"`
+	Position  int        `json:"position" gorm:"not null;default:0"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// NewCategory creates a new category
+func NewCategory(name string, position int) *Category {
+	return &Category{
+		ID:       uuid.New(),
+		Name:     name,
+		Position: position,
+	}
+}
+
+// ItemGroup holds the items of a list that share a category.
+// A nil Category groups the uncategorized items.
+type ItemGroup struct {
+	Category *Category `json:"category"`
+	Items    []Item    `json:"items"`
+}
+
+// GroupItemsByCategory groups items by category.
+// Groups follow the category order (position, then name) with uncategorized
+// items last, and items within a group keep their relative order.
+// Items referencing an unknown category are treated as uncategorized.
+func GroupItemsByCategory(items []Item, categories []*Category) []ItemGroup {
+	ordered := make([]*Category, len(categories))
+	copy(ordered, categories)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Position != ordered[j].Position {
+			return ordered[i].Position < ordered[j].Position
+		}
+		return ordered[i].Name < ordered[j].Name
+	})
+
+	byCategory := make(map[uuid.UUID][]Item, len(ordered))
+	known := make(map[uuid.UUID]bool, len(ordered))
+	for _, category := range ordered {
+		known[category.ID] = true
+	}
+
+	var uncategorized []Item
+	for _, item := range items {
+		if item.CategoryID != nil && known[*item.CategoryID] {
+			byCategory[*item.CategoryID] = append(byCategory[*item.CategoryID], item)
+			continue
+		}
+		uncategorized = append(uncategorized, item)
+	}
+
+	groups := make([]ItemGroup, 0, len(ordered)+1)
+	for _, category := range ordered {
+		if grouped, ok := byCategory[category.ID]; ok {
+			groups = append(groups, ItemGroup{Category: category, Items: grouped})
+		}
+	}
+	if len(uncategorized) > 0 {
+		groups = append(groups, ItemGroup{Items: uncategorized})
+	}
+
+	return groups
+}
@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCategory(t *testing.T) {
+	category := NewCategory("Produce", 2)
+
+	assert.NotEqual(t, uuid.Nil, category.ID)
+	assert.Equal(t, "Produce", category.Name)
+	assert.Equal(t, 2, category.Position)
+}
+
+func TestGroupItemsByCategory(t *testing.T) {
+	produce := NewCategory("Produce", 1)
+	dairy := NewCategory("Dairy", 2)
+	bakery := NewCategory("Bakery", 2)
+	unused := NewCategory("Frozen", 0)
+	unknownID := uuid.New()
+
+	apples := NewItem("Apples", 6)
+	apples.CategoryID = &produce.ID
+	milk := NewItem("Milk", 1)
+	milk.CategoryID = &dairy.ID
+	bread := NewItem("Bread", 1)
+	bread.CategoryID = &bakery.ID
+	pears := NewItem("Pears", 3)
+	pears.CategoryID = &produce.ID
+	soap := NewItem("Soap", 1)
+	orphan := NewItem("Orphan", 1)
+	orphan.CategoryID = &unknownID
+
+	items := []Item{*milk, *soap, *apples, *bread, *orphan, *pears}
+	groups := GroupItemsByCategory(items, []*Category{dairy, unused, bakery, produce})
+
+	require.Len(t, groups, 4)
+
+	// Categories follow position, ties broken by name
+	assert.Equal(t, produce, groups[0].Category)
+	assert.Equal(t, bakery, groups[1].Category)
+	assert.Equal(t, dairy, groups[2].Category)
+
+	// Items keep their relative order within a group
+	require.Len(t, groups[0].Items, 2)
+	assert.Equal(t, "Apples", groups[0].Items[0].Name)
+	assert.Equal(t, "Pears", groups[0].Items[1].Name)
+
+	// Uncategorized and unknown-category items come last
+	assert.Nil(t, groups[3].Category)
+	require.Len(t, groups[3].Items, 2)
+	assert.Equal(t, "Soap", groups[3].Items[0].Name)
+	assert.Equal(t, "Orphan", groups[3].Items[1].Name)
+}
+
+func TestGroupItemsByCategory_Empty(t *testing.T) {
+	groups := GroupItemsByCategory(nil, []*Category{NewCategory("Produce", 1)})
+
+	assert.NotNil(t, groups)
+	assert.Empty(t, groups)
+}
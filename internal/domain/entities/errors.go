@@ -7,4 +7,26 @@ var (
 	ErrItemNotFound         = errors.New("item not found")
 	ErrInvalidInput         = errors.New("invalid input")
 	ErrDuplicateItem        = errors.New("item already exists")
+	ErrCategoryNotFound     = errors.New("category not found")
+	ErrDuplicateCategory    = errors.New("category already exists")
+	ErrVersionConflict      = errors.New("version conflict")
+	ErrOperationAborted     = errors.New("operation aborted")
+	ErrTemplateNotFound     = errors.New("template not found")
+	ErrImageNotFound        = errors.New("image not found")
+	ErrImageTooLarge        = errors.New("image too large")
+	ErrUnsupportedImageType = errors.New("unsupported image type")
+	ErrBlobNotFound         = errors.New("blob not found")
+	ErrPantryItemNotFound   = errors.New("pantry item not found")
+	ErrStapleNotFound       = errors.New("staple not found")
+	ErrUserNotFound         = errors.New("user not found")
+	ErrDuplicateEmail       = errors.New("email already registered")
+	ErrInvalidCredentials   = errors.New("invalid credentials")
+	ErrInvalidToken         = errors.New("invalid token")
+	ErrUnauthenticated      = errors.New("authentication required")
+	ErrAPIKeyNotFound       = errors.New("api key not found")
+	ErrForbidden            = errors.New("insufficient role")
+	ErrMemberNotFound       = errors.New("list member not found")
+	ErrDuplicateMember      = errors.New("user is already a member")
+	ErrInvitationNotFound   = errors.New("invitation not found")
+	ErrInvitationExpired    = errors.New("invitation expired or used up")
 )
@@ -0,0 +1,172 @@
+package entities
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ingredientUnit is a unit of measure written in recipes and its equivalent in a supported unit
+type ingredientUnit struct {
+	unit   Unit
+	factor float64
+}
+
+// ingredientUnits maps the units written in recipes to supported units.
+// Cups and spoons are converted to milliliters using US measures.
+var ingredientUnits = map[string]ingredientUnit{
+	"g": {UnitGram, 1}, "gr": {UnitGram, 1}, "gram": {UnitGram, 1}, "grams": {UnitGram, 1},
+	"kg": {UnitKilogram, 1}, "kilo": {UnitKilogram, 1}, "kilos": {UnitKilogram, 1},
+	"kilogram": {UnitKilogram, 1}, "kilograms": {UnitKilogram, 1},
+	"ml": {UnitMilliliter, 1}, "milliliter": {UnitMilliliter, 1}, "milliliters": {UnitMilliliter, 1},
+	"millilitre": {UnitMilliliter, 1}, "millilitres": {UnitMilliliter, 1},
+	"cl": {UnitMilliliter, 10}, "dl": {UnitMilliliter, 100},
+	"l": {UnitLiter, 1}, "liter": {UnitLiter, 1}, "liters": {UnitLiter, 1}, "litre": {UnitLiter, 1}, "litres": {UnitLiter, 1},
+	"cup": {UnitMilliliter, 240}, "cups": {UnitMilliliter, 240},
+	"tbsp": {UnitMilliliter, 15}, "tbs": {UnitMilliliter, 15}, "tablespoon": {UnitMilliliter, 15}, "tablespoons": {UnitMilliliter, 15},
+	"tsp": {UnitMilliliter, 5}, "teaspoon": {UnitMilliliter, 5}, "teaspoons": {UnitMilliliter, 5},
+	"oz": {UnitOunce, 1}, "ounce": {UnitOunce, 1}, "ounces": {UnitOunce, 1},
+	"lb": {UnitPound, 1}, "lbs": {UnitPound, 1}, "pound": {UnitPound, 1}, "pounds": {UnitPound, 1},
+	"pack": {UnitPack, 1}, "packs": {UnitPack, 1}, "package": {UnitPack, 1}, "packages": {UnitPack, 1},
+	"packet": {UnitPack, 1}, "packets": {UnitPack, 1}, "can": {UnitPack, 1}, "cans": {UnitPack, 1},
+	"jar": {UnitPack, 1}, "jars": {UnitPack, 1}, "bottle": {UnitPack, 1}, "bottles": {UnitPack, 1},
+	"piece": {UnitPieces, 1}, "pieces": {UnitPieces, 1}, "pc": {UnitPieces, 1}, "pcs": {UnitPieces, 1},
+}
+
+// vulgarFractions maps the fraction characters found in recipes to their value
+var vulgarFractions = map[rune]float64{
+	'½': 1.0 / 2, '⅓': 1.0 / 3, '⅔': 2.0 / 3, '¼': 1.0 / 4, '¾': 3.0 / 4,
+	'⅕': 1.0 / 5, '⅖': 2.0 / 5, '⅗': 3.0 / 5, '⅘': 4.0 / 5, '⅙': 1.0 / 6, '⅚': 5.0 / 6,
+	'⅛': 1.0 / 8, '⅜': 3.0 / 8, '⅝': 5.0 / 8, '⅞': 7.0 / 8,
+}
+
+// Ingredient is a product a recipe needs, with its quantity in a supported unit
+type Ingredient struct {
+	Name     string
+	Quantity float64
+	Unit     Unit
+}
+
+// ParseIngredient parses an ingredient line such as "2 cups flour", "1 1/2 tbsp olive oil",
+// "200g sugar" or "3 eggs, beaten". The quantity comes first and may be a whole, decimal,
+// fraction or mixed number; a known unit may follow, and is assumed to be pieces otherwise.
+// Comments after a comma or in parentheses are dropped from the name.
+func ParseIngredient(line string) (Ingredient, error) {
+	fields := strings.Fields(strings.TrimLeft(strings.TrimSpace(line), "-*•"))
+	if len(fields) == 0 {
+		return Ingredient{}, ErrInvalidInput
+	}
+
+	quantity, suffix, ok := parseAmount(fields[0])
+	if !ok {
+		return Ingredient{}, ErrInvalidInput
+	}
+	rest := fields[1:]
+	if suffix != "" {
+		// A unit written against the quantity, as in "200g"
+		rest = append([]string{suffix}, rest...)
+	} else if len(rest) > 0 && quantity == math.Trunc(quantity) {
+		if fraction, fractionSuffix, ok := parseAmount(rest[0]); ok && fractionSuffix == "" && fraction < 1 {
+			quantity += fraction
+			rest = rest[1:]
+		}
+	}
+
+	ingredient := Ingredient{Quantity: quantity, Unit: UnitPieces}
+	if len(rest) > 0 {
+		if unit, ok := ingredientUnits[strings.TrimSuffix(strings.ToLower(rest[0]), ".")]; ok {
+			ingredient.Unit = unit.unit
+			ingredient.Quantity *= unit.factor
+			rest = rest[1:]
+		} else if suffix != "" {
+			return Ingredient{}, ErrInvalidInput
+		}
+	}
+	if len(rest) > 0 && strings.EqualFold(rest[0], "of") {
+		rest = rest[1:]
+	}
+
+	ingredient.Name = ingredientName(strings.Join(rest, " "))
+	if ingredient.Name == "" || ingredient.Quantity <= 0 {
+		return Ingredient{}, ErrInvalidInput
+	}
+	return ingredient, nil
+}
+
+// Scale multiplies the quantity of an ingredient by a factor, rounding it to what can be bought:
+// whole numbers for countable units and thousandths otherwise
+func (i Ingredient) Scale(factor float64) Ingredient {
+	quantity := roundQuantity(i.Quantity * factor)
+	if i.Unit.IsCountable() {
+		quantity = math.Ceil(quantity)
+	}
+	i.Quantity = math.Max(quantity, 0.001)
+	return i
+}
+
+// parseAmount parses the number at the start of a token, such as "2", "1.5", "1/2", "½" or "1½",
+// and returns what follows it
+func parseAmount(token string) (float64, string, bool) {
+	end := strings.IndexFunc(token, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.' && r != '/'
+	})
+	if end < 0 {
+		end = len(token)
+	}
+
+	var amount float64
+	number := token[:end]
+	if number != "" {
+		var ok bool
+		if amount, ok = parseNumber(number); !ok {
+			return 0, "", false
+		}
+	}
+
+	rest := token[end:]
+	if r, size := utf8.DecodeRuneInString(rest); vulgarFractions[r] > 0 && !strings.Contains(number, "/") {
+		amount += vulgarFractions[r]
+		rest = rest[size:]
+	} else if number == "" {
+		return 0, "", false
+	}
+	return amount, rest, true
+}
+
+// parseNumber parses a decimal number or a fraction of whole numbers
+func parseNumber(number string) (float64, bool) {
+	numerator, denominator, isFraction := strings.Cut(number, "/")
+	if !isFraction {
+		value, err := strconv.ParseFloat(number, 64)
+		return value, err == nil
+	}
+
+	n, err := strconv.Atoi(numerator)
+	if err != nil {
+		return 0, false
+	}
+	d, err := strconv.Atoi(denominator)
+	if err != nil || d == 0 {
+		return 0, false
+	}
+	return float64(n) / float64(d), true
+}
+
+// ingredientName strips the comments from the name of an ingredient
+func ingredientName(text string) string {
+	text, _, _ = strings.Cut(text, ",")
+	var name strings.Builder
+	depth := 0
+	for _, r := range text {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case depth == 0:
+			name.WriteRune(r)
+		}
+	}
+	return strings.Join(strings.Fields(name.String()), " ")
+}
@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIngredient(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected Ingredient
+	}{
+		{line: "2 cups flour", expected: Ingredient{Name: "flour", Quantity: 480, Unit: UnitMilliliter}},
+		{line: "1 1/2 tbsp olive oil", expected: Ingredient{Name: "olive oil", Quantity: 22.5, Unit: UnitMilliliter}},
+		{line: "3 eggs", expected: Ingredient{Name: "eggs", Quantity: 3, Unit: UnitPieces}},
+		{line: "- 200g sugar", expected: Ingredient{Name: "sugar", Quantity: 200, Unit: UnitGram}},
+		{line: "½ tsp. salt", expected: Ingredient{Name: "salt", Quantity: 2.5, Unit: UnitMilliliter}},
+		{line: "1½ kg potatoes (waxy), peeled", expected: Ingredient{Name: "potatoes", Quantity: 1.5, Unit: UnitKilogram}},
+		{line: "0.5 l of Whole  Milk", expected: Ingredient{Name: "Whole Milk", Quantity: 0.5, Unit: UnitLiter}},
+		{line: "2 cans chickpeas", expected: Ingredient{Name: "chickpeas", Quantity: 2, Unit: UnitPack}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			ingredient, err := ParseIngredient(tt.line)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected.Name, ingredient.Name)
+			assert.InDelta(t, tt.expected.Quantity, ingredient.Quantity, 1e-9)
+			assert.Equal(t, tt.expected.Unit, ingredient.Unit)
+		})
+	}
+}
+
+func TestParseIngredient_NotUnderstood(t *testing.T) {
+	for _, line := range []string{"", "salt to taste", "2-3 apples", "3", "2 cups", "1/0 cup sugar", "0 eggs", "200zz sugar"} {
+		_, err := ParseIngredient(line)
+		assert.Equal(t, ErrInvalidInput, err, line)
+	}
+}
+
+func TestIngredient_Scale(t *testing.T) {
+	eggs := Ingredient{Name: "eggs", Quantity: 3, Unit: UnitPieces}
+	assert.Equal(t, 2.0, eggs.Scale(0.5).Quantity)
+	assert.Equal(t, 1.0, Ingredient{Name: "onion", Quantity: 1.0 / 3, Unit: UnitPieces}.Scale(3).Quantity)
+
+	flour := Ingredient{Name: "flour", Quantity: 480, Unit: UnitMilliliter}
+	assert.Equal(t, 720.0, flour.Scale(1.5).Quantity)
+	assert.Equal(t, 0.333, Ingredient{Name: "salt", Quantity: 1, Unit: UnitGram}.Scale(1.0/3).Quantity)
+}
@@ -1,29 +1,81 @@
 package entities
 
 import (
+	"math"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
 
-// Item represents an item in a shopping list
+// Unit represents the unit of measure of an item quantity
+type Unit string
+
+// Supported units of measure
+const (
+	UnitPieces     Unit = "pieces"
+	UnitGram       Unit = "g"
+	UnitKilogram   Unit = "kg"
+	UnitMilliliter Unit = "ml"
+	UnitLiter      Unit = "l"
+	UnitOunce      Unit = "oz"
+	UnitPound      Unit = "lb"
+	UnitPack       Unit = "pack"
+)
+
+// IsValid reports whether the unit is one of the supported units
+func (u Unit) IsValid() bool {
+	switch u {
+	case UnitPieces, UnitGram, UnitKilogram, UnitMilliliter, UnitLiter, UnitOunce, UnitPound, UnitPack:
+		return true
+	}
+	return false
+}
+
+// IsCountable reports whether the unit only allows whole quantities
+func (u Unit) IsCountable() bool {
+	return u == UnitPieces || u == UnitPack
+}
+
+// Length limits of the free-text details of an item, in characters
+const (
+	MaxItemNotesLength = 1000
+	MaxItemBrandLength = 100
+	MaxItemSizeLength  = 50
+)
+
+// Item represents an item in a shopping list.
+// Notes, Brand and Size tell apart variants of the same product, e.g. "lactose-free, green cap".
 type Item struct {
-	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
-	ShoppingListID uuid.UUID `json:"shopping_list_id" gorm:"type:uuid;not null"`
-	Name           string    `json:"name" gorm:"not null"`
-	Quantity       int       `json:"quantity" gorm:"default:1"`
-	Completed      bool      `json:"completed" gorm:"default:false"`
-	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt      time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	ShoppingListID uuid.UUID  `json:"shopping_list_id" gorm:"type:uuid;not null"`
+	Name           string     `json:"name" gorm:"not null"`
+	Quantity       float64    `json:"quantity" gorm:"type:numeric(12,3);default:1"`
+	Unit           Unit       `json:"unit" gorm:"type:varchar(16);not null;default:pieces"`
+	CategoryID     *uuid.UUID `json:"category_id" gorm:"type:uuid;index"`
+	Position       int        `json:"position" gorm:"not null;default:0"`
+	UnitPrice      *int64     `json:"unit_price"`
+	Currency       string     `json:"currency" gorm:"type:varchar(3)"`
+	Notes          string     `json:"notes" gorm:"type:text"`
+	Brand          string     `json:"brand" gorm:"type:varchar(100)"`
+	Size           string     `json:"size" gorm:"type:varchar(50)"`
+	Completed      bool       `json:"completed" gorm:"default:false"`
+	Version        int        `json:"version" gorm:"not null;default:1"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty" gorm:"index"`
 }
 
-// NewItem creates a new item
-func NewItem(name string, quantity int) *Item {
+// NewItem creates a new item measured in pieces
+func NewItem(name string, quantity float64) *Item {
 	return &Item{
 		ID:        uuid.New(),
 		Name:      name,
 		Quantity:  quantity,
+		Unit:      UnitPieces,
 		Completed: false,
+		Version:   1,
 	}
 }
 
@@ -38,6 +90,69 @@ func (i *Item) MarkIncomplete() {
 }
 
 // UpdateQuantity updates the item quantity
-func (i *Item) UpdateQuantity(quantity int) {
+func (i *Item) UpdateQuantity(quantity float64) {
 	i.Quantity = quantity
 }
+
+// CopyTo returns a copy of the item with a fresh ID, belonging to another shopping list.
+// The copy starts at version 1 and has no position until it is stored.
+func (i *Item) CopyTo(shoppingListID uuid.UUID) *Item {
+	copied := *i
+	copied.ID = uuid.New()
+	copied.ShoppingListID = shoppingListID
+	copied.Position = 0
+	copied.Version = 1
+	copied.CreatedAt = time.Time{}
+	copied.UpdatedAt = time.Time{}
+	copied.DeletedAt = nil
+	if i.CategoryID != nil {
+		categoryID := *i.CategoryID
+		copied.CategoryID = &categoryID
+	}
+	if i.UnitPrice != nil {
+		unitPrice := *i.UnitPrice
+		copied.UnitPrice = &unitPrice
+	}
+	return &copied
+}
+
+// NormalizeItemName folds an item name for duplicate detection,
+// ignoring case and surrounding or repeated whitespace
+func NormalizeItemName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// IsDuplicateOf reports whether the item is an open item with the same normalized name
+func (i *Item) IsDuplicateOf(name string) bool {
+	return !i.Completed && NormalizeItemName(i.Name) == NormalizeItemName(name)
+}
+
+// ValidateQuantity checks that a quantity is positive and fits the unit
+func ValidateQuantity(quantity float64, unit Unit) error {
+	if !unit.IsValid() {
+		return ErrInvalidInput
+	}
+	if quantity <= 0 || math.IsNaN(quantity) || math.IsInf(quantity, 0) {
+		return ErrInvalidInput
+	}
+	if unit.IsCountable() && quantity != math.Trunc(quantity) {
+		return ErrInvalidInput
+	}
+	return nil
+}
+
+// roundQuantity rounds a quantity to the thousandths it is stored with,
+// dropping the floating-point noise of arithmetic such as 0.1 + 0.2
+func roundQuantity(quantity float64) float64 {
+	return math.Round(quantity*quantityScale) / quantityScale
+}
+
+// ValidateItemDetails checks that the notes, brand and size of an item fit their length limits
+func ValidateItemDetails(notes, brand, size string) error {
+	if utf8.RuneCountInString(notes) > MaxItemNotesLength ||
+		utf8.RuneCountInString(brand) > MaxItemBrandLength ||
+		utf8.RuneCountInString(size) > MaxItemSizeLength {
+		return ErrInvalidInput
+	}
+	return nil
+}
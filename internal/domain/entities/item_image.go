@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Limits of item images; larger uploads are rejected rather than downscaled.
+// MaxImagePixels bounds the memory a decoded image takes, about 64 MiB as RGBA.
+// Thumbnails fit in a square of ThumbnailSize pixels.
+const (
+	MaxImageSize   = 5 << 20
+	MaxImagePixels = 16_000_000
+	ThumbnailSize  = 256
+)
+
+// Supported image content types
+const (
+	ContentTypeJPEG = "image/jpeg"
+	ContentTypePNG  = "image/png"
+	ContentTypeGIF  = "image/gif"
+)
+
+// ItemImage describes the photo attached to an item.
+// The image and its thumbnail are kept in blob storage under their keys.
+type ItemImage struct {
+	ItemID               uuid.UUID `json:"item_id" gorm:"type:uuid;primary_key"`
+	ContentType          string    `json:"content_type" gorm:"type:varchar(32);not null"`
+	Size                 int64     `json:"size" gorm:"not null"`
+	Width                int       `json:"width" gorm:"not null"`
+	Height               int       `json:"height" gorm:"not null"`
+	BlobKey              string    `json:"-" gorm:"not null"`
+	ThumbnailKey         string    `json:"-" gorm:"not null"`
+	ThumbnailContentType string    `json:"thumbnail_content_type" gorm:"type:varchar(32);not null"`
+	CreatedAt            time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// IsSupportedImageType reports whether images of a content type can be attached to items
+func IsSupportedImageType(contentType string) bool {
+	switch contentType {
+	case ContentTypeJPEG, ContentTypePNG, ContentTypeGIF:
+		return true
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -11,9 +12,9 @@ func TestNewItem(t *testing.T) {
 	tests := []struct {
 		name         string
 		itemName     string
-		quantity     int
+		quantity     float64
 		wantName     string
-		wantQuantity int
+		wantQuantity float64
 	}{
 		{
 			name:         "creates item with positive quantity",
@@ -98,8 +99,8 @@ func TestItem_UpdateQuantity(t *testing.T) {
 
 	tests := []struct {
 		name         string
-		newQuantity  int
-		wantQuantity int
+		newQuantity  float64
+		wantQuantity float64
 	}{
 		{
 			name:         "updates to positive quantity",
@@ -156,12 +157,12 @@ func TestItem_Integration(t *testing.T) {
 
 	// Verify initial state
 	assert.Equal(t, "Organic Milk", item.Name)
-	assert.Equal(t, 2, item.Quantity)
+	assert.Equal(t, 2.0, item.Quantity)
 	assert.False(t, item.Completed)
 
 	// Update quantity
 	item.UpdateQuantity(3)
-	assert.Equal(t, 3, item.Quantity)
+	assert.Equal(t, 3.0, item.Quantity)
 
 	// Mark as completed
 	item.MarkCompleted()
@@ -169,13 +170,13 @@ func TestItem_Integration(t *testing.T) {
 
 	// Update quantity while completed
 	item.UpdateQuantity(1)
-	assert.Equal(t, 1, item.Quantity)
+	assert.Equal(t, 1.0, item.Quantity)
 	assert.True(t, item.Completed) // Should remain completed
 
 	// Mark as incomplete
 	item.MarkIncomplete()
 	assert.False(t, item.Completed)
-	assert.Equal(t, 1, item.Quantity) // Quantity should remain unchanged
+	assert.Equal(t, 1.0, item.Quantity) // Quantity should remain unchanged
 }
 
 func TestItem_UniqueIDs(t *testing.T) {
@@ -193,3 +194,120 @@ func TestItem_UniqueIDs(t *testing.T) {
 	assert.NotEqual(t, uuid.Nil, item2.ID)
 	assert.NotEqual(t, uuid.Nil, item3.ID)
 }
+
+func TestNewItem_DefaultsToPieces(t *testing.T) {
+	item := NewItem("Eggs", 12)
+	assert.Equal(t, UnitPieces, item.Unit)
+}
+
+func TestUnit_IsValid(t *testing.T) {
+	for _, unit := range []Unit{UnitPieces, UnitGram, UnitKilogram, UnitMilliliter, UnitLiter, UnitOunce, UnitPound, UnitPack} {
+		assert.True(t, unit.IsValid(), string(unit))
+	}
+	assert.False(t, Unit("").IsValid())
+	assert.False(t, Unit("cups").IsValid())
+	assert.False(t, Unit("KG").IsValid())
+}
+
+func TestValidateQuantity(t *testing.T) {
+	tests := []struct {
+		name     string
+		quantity float64
+		unit     Unit
+		wantErr  bool
+	}{
+		{name: "whole pieces", quantity: 3, unit: UnitPieces},
+		{name: "fractional kilograms", quantity: 1.5, unit: UnitKilogram},
+		{name: "milliliters", quantity: 500, unit: UnitMilliliter},
+		{name: "fractional pieces", quantity: 1.5, unit: UnitPieces, wantErr: true},
+		{name: "fractional packs", quantity: 0.5, unit: UnitPack, wantErr: true},
+		{name: "zero quantity", quantity: 0, unit: UnitGram, wantErr: true},
+		{name: "negative quantity", quantity: -1, unit: UnitLiter, wantErr: true},
+		{name: "unknown unit", quantity: 1, unit: Unit("cups"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateQuantity(tt.quantity, tt.unit)
+			if tt.wantErr {
+				assert.Equal(t, ErrInvalidInput, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateItemDetails(t *testing.T) {
+	tests := []struct {
+		name    string
+		notes   string
+		brand   string
+		size    string
+		wantErr bool
+	}{
+		{name: "no details"},
+		{name: "all details", notes: "the lactose-free one, green cap", brand: "Arla", size: "1 l"},
+		{name: "limits counted in characters", notes: strings.Repeat("é", MaxItemNotesLength), size: strings.Repeat("ü", MaxItemSizeLength)},
+		{name: "notes too long", notes: strings.Repeat("a", MaxItemNotesLength+1), wantErr: true},
+		{name: "brand too long", brand: strings.Repeat("a", MaxItemBrandLength+1), wantErr: true},
+		{name: "size too long", size: strings.Repeat("a", MaxItemSizeLength+1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateItemDetails(tt.notes, tt.brand, tt.size)
+			if tt.wantErr {
+				assert.Equal(t, ErrInvalidInput, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestItem_IsDuplicateOf(t *testing.T) {
+	item := NewItem("Whole  Milk", 1)
+
+	assert.True(t, item.IsDuplicateOf("whole milk"))
+	assert.True(t, item.IsDuplicateOf("  WHOLE MILK "))
+	assert.False(t, item.IsDuplicateOf("Milk"))
+
+	item.MarkCompleted()
+	assert.False(t, item.IsDuplicateOf("whole milk"))
+}
+
+func TestItem_CopyTo(t *testing.T) {
+	categoryID := uuid.New()
+	unitPrice := int64(250)
+	item := NewItem("Flour", 1.5)
+	item.Unit = UnitKilogram
+	item.CategoryID = &categoryID
+	item.UnitPrice = &unitPrice
+	item.Currency = "EUR"
+	item.Notes = "Type 550"
+	item.Brand = "Aurora"
+	item.Position = 4
+	item.Version = 7
+	item.MarkCompleted()
+
+	listID := uuid.New()
+	copied := item.CopyTo(listID)
+
+	assert.NotEqual(t, item.ID, copied.ID)
+	assert.Equal(t, listID, copied.ShoppingListID)
+	assert.Equal(t, "Flour", copied.Name)
+	assert.Equal(t, 1.5, copied.Quantity)
+	assert.Equal(t, UnitKilogram, copied.Unit)
+	assert.Equal(t, "EUR", copied.Currency)
+	assert.Equal(t, "Type 550", copied.Notes)
+	assert.Equal(t, "Aurora", copied.Brand)
+	assert.True(t, copied.Completed)
+	assert.Equal(t, 1, copied.Version)
+	assert.Zero(t, copied.Position)
+
+	*copied.CategoryID = uuid.New()
+	*copied.UnitPrice = 1
+	assert.Equal(t, categoryID, *item.CategoryID)
+	assert.Equal(t, int64(250), *item.UnitPrice)
+}
@@ -0,0 +1,61 @@
+package entities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ListInvitation lets whoever holds its token join a shopping list in a role.
+// Only a SHA-256 hash of the token is stored. A nil MaxUses allows any number of uses
+// until the invitation expires.
+type ListInvitation struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	ShoppingListID uuid.UUID `json:"shopping_list_id" gorm:"type:uuid;not null;index"`
+	TokenHash      string    `json:"-" gorm:"type:char(64);not null;uniqueIndex"`
+	Role           ListRole  `json:"role" gorm:"type:varchar(16);not null"`
+	MaxUses        *int      `json:"max_uses"`
+	Uses           int       `json:"uses" gorm:"not null;default:0"`
+	ExpiresAt      time.Time `json:"expires_at" gorm:"not null"`
+	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// NewListInvitation creates an invitation to a shopping list and returns it along with its
+// token, which is not stored and cannot be recovered later
+func NewListInvitation(
+	shoppingListID uuid.UUID,
+	role ListRole,
+	expiresAt time.Time,
+	maxUses *int,
+) (*ListInvitation, string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(secret)
+
+	return &ListInvitation{
+		ID:             uuid.New(),
+		ShoppingListID: shoppingListID,
+		TokenHash:      HashInvitationToken(token),
+		Role:           role,
+		MaxUses:        maxUses,
+		ExpiresAt:      expiresAt,
+	}, token, nil
+}
+
+// HashInvitationToken returns the hex-encoded SHA-256 hash an invitation is looked up by
+func HashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(token)))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsUsable reports whether the invitation can still be accepted at a given time
+func (i *ListInvitation) IsUsable(now time.Time) bool {
+	return now.Before(i.ExpiresAt) && (i.MaxUses == nil || i.Uses < *i.MaxUses)
+}
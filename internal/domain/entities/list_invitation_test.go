@@ -0,0 +1,36 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewListInvitation(t *testing.T) {
+	listID := uuid.New()
+	expiresAt := time.Now().Add(time.Hour)
+	invitation, token, err := NewListInvitation(listID, ListRoleEditor, expiresAt, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, listID, invitation.ShoppingListID)
+	assert.Equal(t, HashInvitationToken(token), invitation.TokenHash)
+	assert.NotContains(t, invitation.TokenHash, token)
+
+	_, other, err := NewListInvitation(listID, ListRoleEditor, expiresAt, nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
+
+func TestListInvitation_IsUsable(t *testing.T) {
+	now := time.Now()
+	once := 1
+
+	assert.True(t, (&ListInvitation{ExpiresAt: now.Add(time.Hour)}).IsUsable(now))
+	assert.True(t, (&ListInvitation{ExpiresAt: now.Add(time.Hour), Uses: 5}).IsUsable(now))
+	assert.False(t, (&ListInvitation{ExpiresAt: now}).IsUsable(now))
+	assert.True(t, (&ListInvitation{ExpiresAt: now.Add(time.Hour), MaxUses: &once}).IsUsable(now))
+	assert.False(t, (&ListInvitation{ExpiresAt: now.Add(time.Hour), MaxUses: &once, Uses: 1}).IsUsable(now))
+}
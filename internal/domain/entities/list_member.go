@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ListRole tells what a user may do with a shopping list.
+// Each role includes everything the roles below it allow.
+type ListRole string
+
+// Shopping list roles, from least to most privileged
+const (
+	ListRoleViewer ListRole = "viewer"
+	ListRoleEditor ListRole = "editor"
+	ListRoleOwner  ListRole = "owner"
+)
+
+// IsValid reports whether the role is known
+func (r ListRole) IsValid() bool {
+	return r.rank() > 0
+}
+
+// Allows reports whether the role grants at least what the required role does
+func (r ListRole) Allows(required ListRole) bool {
+	return r.IsValid() && r.rank() >= required.rank()
+}
+
+// rank orders the roles by privilege; unknown roles rank zero
+func (r ListRole) rank() int {
+	switch r {
+	case ListRoleViewer:
+		return 1
+	case ListRoleEditor:
+		return 2
+	case ListRoleOwner:
+		return 3
+	}
+	return 0
+}
+
+// ListMember grants a user a role in a shopping list shared with them.
+// The user a list belongs to holds the owner role without a membership.
+type ListMember struct {
+	ShoppingListID uuid.UUID `json:"shopping_list_id" gorm:"type:uuid;primaryKey"`
+	UserID         uuid.UUID `json:"user_id" gorm:"type:uuid;primaryKey;index"`
+	Role           ListRole  `json:"role" gorm:"type:varchar(16);not null"`
+	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// NewListMember creates a new membership of a user in a shopping list
+func NewListMember(shoppingListID, userID uuid.UUID, role ListRole) *ListMember {
+	return &ListMember{
+		ShoppingListID: shoppingListID,
+		UserID:         userID,
+		Role:           role,
+	}
+}
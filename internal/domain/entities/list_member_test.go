@@ -0,0 +1,24 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListRole_Allows(t *testing.T) {
+	assert.True(t, ListRoleOwner.Allows(ListRoleEditor))
+	assert.True(t, ListRoleEditor.Allows(ListRoleEditor))
+	assert.True(t, ListRoleEditor.Allows(ListRoleViewer))
+	assert.False(t, ListRoleViewer.Allows(ListRoleEditor))
+	assert.False(t, ListRoleEditor.Allows(ListRoleOwner))
+	assert.False(t, ListRole("admin").Allows(ListRoleViewer))
+}
+
+func TestListRole_IsValid(t *testing.T) {
+	assert.True(t, ListRoleViewer.IsValid())
+	assert.True(t, ListRoleEditor.IsValid())
+	assert.True(t, ListRoleOwner.IsValid())
+	assert.False(t, ListRole("").IsValid())
+	assert.False(t, ListRole("admin").IsValid())
+}
@@ -0,0 +1,97 @@
+package entities
+
+import (
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// quantityScale is the number of quantity subdivisions stored per unit (numeric(12,3))
+const quantityScale = 1000
+
+// ListTotals holds the money totals of a shopping list in a single currency.
+// Amounts are expressed in the currency's minor unit (e.g. cents).
+type ListTotals struct {
+	Currency        string `json:"currency"`
+	EstimatedTotal  int64  `json:"estimated_total"`
+	CompletedTotal  int64  `json:"completed_total"`
+	Budget          *int64 `json:"budget,omitempty"`
+	RemainingBudget *int64 `json:"remaining_budget,omitempty"`
+}
+
+// NormalizeCurrency validates a three-letter ISO 4217 currency code and returns it upper-cased
+func NormalizeCurrency(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 {
+		return "", ErrInvalidInput
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return "", ErrInvalidInput
+		}
+	}
+	return code, nil
+}
+
+// LineTotal returns the price of a quantity at a unit price given in minor units.
+// The quantity is taken at its stored precision and the result is rounded half up
+// to the nearest minor unit, using integer arithmetic throughout.
+func LineTotal(unitPrice int64, quantity float64) int64 {
+	scaledQuantity := big.NewInt(int64(math.Round(quantity * quantityScale)))
+	total := new(big.Int).Mul(big.NewInt(unitPrice), scaledQuantity)
+	total.Add(total, big.NewInt(quantityScale/2))
+	total.Quo(total, big.NewInt(quantityScale))
+	return total.Int64()
+}
+
+// Totals computes the estimated, completed and remaining budget totals of the list.
+// One entry is returned per currency in use, starting with the budget currency.
+func (sl *ShoppingList) Totals() []ListTotals {
+	byCurrency := make(map[string]*ListTotals)
+	totalsFor := func(currency string) *ListTotals {
+		totals, ok := byCurrency[currency]
+		if !ok {
+			totals = &ListTotals{Currency: currency}
+			byCurrency[currency] = totals
+		}
+		return totals
+	}
+
+	if sl.Budget != nil && sl.Currency != "" {
+		totalsFor(sl.Currency)
+	}
+
+	for i := range sl.Items {
+		item := &sl.Items[i]
+		if item.UnitPrice == nil || item.Currency == "" {
+			continue
+		}
+		lineTotal := LineTotal(*item.UnitPrice, item.Quantity)
+		totals := totalsFor(item.Currency)
+		totals.EstimatedTotal += lineTotal
+		if item.Completed {
+			totals.CompletedTotal += lineTotal
+		}
+	}
+
+	result := make([]ListTotals, 0, len(byCurrency))
+	for _, totals := range byCurrency {
+		if sl.Budget != nil && totals.Currency == sl.Currency {
+			budget := *sl.Budget
+			remaining := budget - totals.EstimatedTotal
+			totals.Budget = &budget
+			totals.RemainingBudget = &remaining
+		}
+		result = append(result, *totals)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Budget != nil || result[j].Budget != nil {
+			return result[i].Budget != nil
+		}
+		return result[i].Currency < result[j].Currency
+	})
+
+	return result
+}
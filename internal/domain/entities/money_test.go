@@ -0,0 +1,122 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestNormalizeCurrency(t *testing.T) {
+	tests := []struct {
+		code    string
+		want    string
+		wantErr bool
+	}{
+		{code: "EUR", want: "EUR"},
+		{code: " usd ", want: "USD"},
+		{code: "", wantErr: true},
+		{code: "EURO", wantErr: true},
+		{code: "E1R", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			got, err := NormalizeCurrency(tt.code)
+			if tt.wantErr {
+				assert.Equal(t, ErrInvalidInput, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLineTotal(t *testing.T) {
+	tests := []struct {
+		name      string
+		unitPrice int64
+		quantity  float64
+		want      int64
+	}{
+		{name: "whole quantity", unitPrice: 199, quantity: 3, want: 597},
+		{name: "fractional quantity", unitPrice: 1299, quantity: 1.5, want: 1949},
+		{name: "rounds half up", unitPrice: 1, quantity: 0.5, want: 1},
+		{name: "three decimal quantity", unitPrice: 1000, quantity: 0.333, want: 333},
+		{name: "binary float artifacts are ignored", unitPrice: 10, quantity: 0.1 + 0.2, want: 3},
+		{name: "free item", unitPrice: 0, quantity: 4, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, LineTotal(tt.unitPrice, tt.quantity))
+		})
+	}
+}
+
+func TestShoppingList_Totals(t *testing.T) {
+	list := NewShoppingList("Groceries", "")
+	list.Budget = int64Ptr(5000)
+	list.Currency = "EUR"
+
+	flour := NewItem("Flour", 1.5)
+	flour.Unit = UnitKilogram
+	flour.UnitPrice = int64Ptr(200)
+	flour.Currency = "EUR"
+
+	milk := NewItem("Milk", 2)
+	milk.UnitPrice = int64Ptr(129)
+	milk.Currency = "EUR"
+	milk.MarkCompleted()
+
+	souvenir := NewItem("Souvenir", 1)
+	souvenir.UnitPrice = int64Ptr(1000)
+	souvenir.Currency = "USD"
+
+	unpriced := NewItem("Salt", 1)
+
+	list.AddItem(flour)
+	list.AddItem(milk)
+	list.AddItem(souvenir)
+	list.AddItem(unpriced)
+
+	totals := list.Totals()
+	require.Len(t, totals, 2)
+
+	eur := totals[0]
+	assert.Equal(t, "EUR", eur.Currency)
+	assert.Equal(t, int64(558), eur.EstimatedTotal)
+	assert.Equal(t, int64(258), eur.CompletedTotal)
+	require.NotNil(t, eur.RemainingBudget)
+	assert.Equal(t, int64(5000), *eur.Budget)
+	assert.Equal(t, int64(4442), *eur.RemainingBudget)
+
+	usd := totals[1]
+	assert.Equal(t, "USD", usd.Currency)
+	assert.Equal(t, int64(1000), usd.EstimatedTotal)
+	assert.Nil(t, usd.RemainingBudget)
+}
+
+func TestShoppingList_Totals_BudgetWithoutItems(t *testing.T) {
+	list := NewShoppingList("Party", "")
+	list.Budget = int64Ptr(10000)
+	list.Currency = "GBP"
+
+	totals := list.Totals()
+	require.Len(t, totals, 1)
+	assert.Equal(t, "GBP", totals[0].Currency)
+	assert.Equal(t, int64(10000), *totals[0].RemainingBudget)
+}
+
+func TestShoppingList_Totals_Empty(t *testing.T) {
+	list := NewShoppingList("Empty", "")
+
+	totals := list.Totals()
+	assert.NotNil(t, totals)
+	assert.Empty(t, totals)
+}
@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxPantryLocationLength is the length limit of a pantry location, in characters
+const MaxPantryLocationLength = 100
+
+// PantryItem represents something kept at home, with the quantity on hand.
+// Location is free text such as "fridge" or "basement shelf".
+type PantryItem struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	OwnerID   *uuid.UUID `json:"owner_id" gorm:"type:uuid;index"`
+	Name      string     `json:"name" gorm:"not null"`
+	Quantity  float64    `json:"quantity" gorm:"type:numeric(12,3);not null;default:0"`
+	Unit      Unit       `json:"unit" gorm:"type:varchar(16);not null;default:pieces"`
+	Location  string     `json:"location" gorm:"type:varchar(100)"`
+	ExpiresAt *time.Time `json:"expires_at" gorm:"index"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// NewPantryItem creates a new pantry item without location or expiry date
+func NewPantryItem(name string, quantity float64, unit Unit) *PantryItem {
+	return &PantryItem{
+		ID:       uuid.New(),
+		Name:     name,
+		Quantity: quantity,
+		Unit:     unit,
+	}
+}
+
+// CanStock reports whether bought items of a product can be added to the pantry item:
+// it must hold the same product in the same unit and have no expiry date, since a new
+// purchase rarely expires along with what is already at home
+func (p *PantryItem) CanStock(name string, unit Unit) bool {
+	return p.ExpiresAt == nil && p.Unit == unit && NormalizeItemName(p.Name) == NormalizeItemName(name)
+}
+
+// Restock adds a quantity to the quantity on hand
+func (p *PantryItem) Restock(quantity float64) {
+	p.Quantity += quantity
+}
+
+// ValidateOnHandQuantity checks that a quantity on hand is zero or more and fits the unit
+func ValidateOnHandQuantity(quantity float64, unit Unit) error {
+	if quantity == 0 {
+		if !unit.IsValid() {
+			return ErrInvalidInput
+		}
+		return nil
+	}
+	return ValidateQuantity(quantity, unit)
+}
@@ -0,0 +1,36 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPantryItem_CanStock(t *testing.T) {
+	item := NewPantryItem("Whole  Milk", 1, UnitLiter)
+
+	assert.True(t, item.CanStock("whole milk", UnitLiter))
+	assert.False(t, item.CanStock("whole milk", UnitMilliliter))
+	assert.False(t, item.CanStock("Oat milk", UnitLiter))
+
+	expiresAt := time.Date(2026, 10, 30, 0, 0, 0, 0, time.UTC)
+	item.ExpiresAt = &expiresAt
+	assert.False(t, item.CanStock("whole milk", UnitLiter))
+}
+
+func TestPantryItem_Restock(t *testing.T) {
+	item := NewPantryItem("Flour", 0.5, UnitKilogram)
+
+	item.Restock(1)
+
+	assert.Equal(t, 1.5, item.Quantity)
+}
+
+func TestValidateOnHandQuantity(t *testing.T) {
+	assert.NoError(t, ValidateOnHandQuantity(0, UnitPieces))
+	assert.NoError(t, ValidateOnHandQuantity(2.5, UnitKilogram))
+	assert.Equal(t, ErrInvalidInput, ValidateOnHandQuantity(0, Unit("cups")))
+	assert.Equal(t, ErrInvalidInput, ValidateOnHandQuantity(-1, UnitPieces))
+	assert.Equal(t, ErrInvalidInput, ValidateOnHandQuantity(1.5, UnitPieces))
+}
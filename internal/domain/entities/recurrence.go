@@ -0,0 +1,247 @@
+package entities
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is how often a recurrence repeats
+type Frequency string
+
+// Supported recurrence frequencies
+const (
+	FrequencyDaily   Frequency = "DAILY"
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+)
+
+// maxRecurrenceInterval bounds INTERVAL so that occurrences stay computable
+const maxRecurrenceInterval = 1000
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Recurrence is a subset of an iCalendar RRULE: FREQ (DAILY, WEEKLY or MONTHLY), INTERVAL,
+// BYDAY for weekly rules and BYMONTHDAY (1 to 31, or -1 for the last day) for monthly rules.
+// Occurrences keep the time of day of the start of the recurrence.
+type Recurrence struct {
+	Frequency Frequency
+	Interval  int
+	Weekdays  []time.Weekday
+	MonthDay  int
+}
+
+// ParseRecurrence parses a rule such as "FREQ=WEEKLY;BYDAY=SA" or "RRULE:FREQ=MONTHLY;BYMONTHDAY=1"
+func ParseRecurrence(rule string) (Recurrence, error) {
+	r := Recurrence{Interval: 1}
+	rule = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(rule)), "RRULE:")
+	if rule == "" {
+		return Recurrence{}, ErrInvalidInput
+	}
+
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" || seen[key] {
+			return Recurrence{}, ErrInvalidInput
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			r.Frequency = Frequency(value)
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+		case "BYDAY":
+			r.Weekdays, err = parseWeekdays(value)
+		case "BYMONTHDAY":
+			r.MonthDay, err = strconv.Atoi(value)
+		default:
+			return Recurrence{}, ErrInvalidInput
+		}
+		if err != nil {
+			return Recurrence{}, ErrInvalidInput
+		}
+	}
+
+	if err := r.validate(); err != nil {
+		return Recurrence{}, err
+	}
+	return r, nil
+}
+
+// validate checks that the parts of a parsed rule fit together
+func (r Recurrence) validate() error {
+	switch r.Frequency {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly:
+	default:
+		return ErrInvalidInput
+	}
+	if r.Interval < 1 || r.Interval > maxRecurrenceInterval {
+		return ErrInvalidInput
+	}
+	if len(r.Weekdays) > 0 && r.Frequency != FrequencyWeekly {
+		return ErrInvalidInput
+	}
+	if r.MonthDay != 0 && (r.Frequency != FrequencyMonthly || r.MonthDay < -1 || r.MonthDay > 31) {
+		return ErrInvalidInput
+	}
+	return nil
+}
+
+// parseWeekdays parses a BYDAY value such as "MO,WE,FR" into weekdays ordered from Monday
+func parseWeekdays(value string) ([]time.Weekday, error) {
+	seen := make(map[time.Weekday]bool)
+	var weekdays []time.Weekday
+	for _, code := range strings.Split(value, ",") {
+		weekday, ok := weekdayCodes[code]
+		if !ok {
+			return nil, ErrInvalidInput
+		}
+		if !seen[weekday] {
+			seen[weekday] = true
+			weekdays = append(weekdays, weekday)
+		}
+	}
+	sort.Slice(weekdays, func(i, j int) bool {
+		return daysSinceMonday(weekdays[i]) < daysSinceMonday(weekdays[j])
+	})
+	return weekdays, nil
+}
+
+// String returns the rule in its canonical form
+func (r Recurrence) String() string {
+	parts := []string{"FREQ=" + string(r.Frequency)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.Weekdays) > 0 {
+		codes := make([]string, len(r.Weekdays))
+		for i, weekday := range r.Weekdays {
+			codes[i] = strings.ToUpper(weekday.String()[:2])
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if r.MonthDay != 0 {
+		parts = append(parts, "BYMONTHDAY="+strconv.Itoa(r.MonthDay))
+	}
+	return strings.Join(parts, ";")
+}
+
+// First returns the first occurrence at or after start
+func (r Recurrence) First(start time.Time) time.Time {
+	if r.matches(start) {
+		return start
+	}
+	return r.Next(start)
+}
+
+// Next returns the occurrence that follows prev, which anchors the interval and should
+// itself be an occurrence or the start of the recurrence. It returns the zero time when
+// no further occurrence can be found, such as a BYMONTHDAY=31 every 12 months from February.
+func (r Recurrence) Next(prev time.Time) time.Time {
+	switch r.Frequency {
+	case FrequencyDaily:
+		return prev.AddDate(0, 0, r.Interval)
+	case FrequencyWeekly:
+		return r.nextWeekly(prev)
+	case FrequencyMonthly:
+		return r.nextMonthly(prev)
+	}
+	return time.Time{}
+}
+
+// matches reports whether t falls on a day the rule selects
+func (r Recurrence) matches(t time.Time) bool {
+	switch r.Frequency {
+	case FrequencyWeekly:
+		return len(r.Weekdays) == 0 || containsWeekday(r.Weekdays, t.Weekday())
+	case FrequencyMonthly:
+		return r.MonthDay == 0 || monthDay(t.Year(), t.Month(), r.MonthDay) == t.Day()
+	}
+	return true
+}
+
+// nextWeekly returns the next selected weekday of the week of prev, or the first one
+// of the week Interval weeks later
+func (r Recurrence) nextWeekly(prev time.Time) time.Time {
+	if len(r.Weekdays) == 0 {
+		return prev.AddDate(0, 0, 7*r.Interval)
+	}
+
+	offset := daysSinceMonday(prev.Weekday())
+	for _, weekday := range r.Weekdays {
+		if daysSinceMonday(weekday) > offset {
+			return prev.AddDate(0, 0, daysSinceMonday(weekday)-offset)
+		}
+	}
+
+	monday := prev.AddDate(0, 0, 7*r.Interval-offset)
+	return monday.AddDate(0, 0, daysSinceMonday(r.Weekdays[0]))
+}
+
+// nextMonthly returns the selected day later in the month of prev, or in the first month
+// Interval months apart that has that day
+func (r Recurrence) nextMonthly(prev time.Time) time.Time {
+	day := r.MonthDay
+	if day == 0 {
+		day = prev.Day()
+	}
+
+	if d := monthDay(prev.Year(), prev.Month(), day); d > prev.Day() {
+		return dateOf(prev, prev.Year(), prev.Month(), d)
+	}
+
+	// Months without the day are skipped, as in RFC 5545; a few years of candidates
+	// is enough to reach every day of the month for any interval that can reach one
+	for k := 1; k <= 4*12; k++ {
+		year, month := prev.Year(), prev.Month()+time.Month(k*r.Interval)
+		first := time.Date(year, month, 1, 0, 0, 0, 0, prev.Location())
+		if d := monthDay(first.Year(), first.Month(), day); d > 0 {
+			return dateOf(prev, first.Year(), first.Month(), d)
+		}
+	}
+	return time.Time{}
+}
+
+// monthDay resolves a BYMONTHDAY value in a given month, returning 0 when the month lacks the day
+func monthDay(year int, month time.Month, day int) int {
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if day == -1 {
+		return last
+	}
+	if day > last {
+		return 0
+	}
+	return day
+}
+
+// dateOf returns the given date at the time of day of t
+func dateOf(t time.Time, year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// daysSinceMonday numbers the weekdays from Monday (0) to Sunday (6)
+func daysSinceMonday(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
+
+// containsWeekday reports whether a weekday is among the given ones
+func containsWeekday(weekdays []time.Weekday, weekday time.Weekday) bool {
+	for _, candidate := range weekdays {
+		if candidate == weekday {
+			return true
+		}
+	}
+	return false
+}
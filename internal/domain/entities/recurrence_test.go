@@ -0,0 +1,96 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		rule      string
+		canonical string
+		wantErr   bool
+	}{
+		{rule: "FREQ=DAILY", canonical: "FREQ=DAILY"},
+		{rule: "RRULE:freq=weekly;byday=su,sa,SA", canonical: "FREQ=WEEKLY;BYDAY=SA,SU"},
+		{rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO", canonical: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO"},
+		{rule: "FREQ=MONTHLY;BYMONTHDAY=1", canonical: "FREQ=MONTHLY;BYMONTHDAY=1"},
+		{rule: "FREQ=MONTHLY;BYMONTHDAY=-1;INTERVAL=1", canonical: "FREQ=MONTHLY;BYMONTHDAY=-1"},
+		{rule: "", wantErr: true},
+		{rule: "FREQ=YEARLY", wantErr: true},
+		{rule: "INTERVAL=2", wantErr: true},
+		{rule: "FREQ=DAILY;INTERVAL=0", wantErr: true},
+		{rule: "FREQ=DAILY;FREQ=WEEKLY", wantErr: true},
+		{rule: "FREQ=DAILY;BYDAY=MO", wantErr: true},
+		{rule: "FREQ=WEEKLY;BYDAY=XX", wantErr: true},
+		{rule: "FREQ=WEEKLY;BYMONTHDAY=1", wantErr: true},
+		{rule: "FREQ=MONTHLY;BYMONTHDAY=32", wantErr: true},
+		{rule: "FREQ=DAILY;COUNT=3", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			rule, err := ParseRecurrence(tt.rule)
+			if tt.wantErr {
+				assert.Equal(t, ErrInvalidInput, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.canonical, rule.String())
+		})
+	}
+}
+
+func TestRecurrence_Occurrences(t *testing.T) {
+	// Wednesday, 14 October 2026
+	start := time.Date(2026, 10, 14, 8, 0, 0, 0, time.UTC)
+	day := func(month time.Month, d int) time.Time { return time.Date(2026, month, d, 8, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		rule     string
+		start    time.Time
+		expected []time.Time
+	}{
+		{rule: "FREQ=DAILY;INTERVAL=3", start: start, expected: []time.Time{day(10, 14), day(10, 17), day(10, 20)}},
+		{rule: "FREQ=WEEKLY", start: start, expected: []time.Time{day(10, 14), day(10, 21), day(10, 28)}},
+		{rule: "FREQ=WEEKLY;BYDAY=SA", start: start, expected: []time.Time{day(10, 17), day(10, 24), day(10, 31)}},
+		{
+			rule:     "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH",
+			start:    start,
+			expected: []time.Time{day(10, 15), day(10, 26), day(10, 29), day(11, 9)},
+		},
+		{rule: "FREQ=MONTHLY;BYMONTHDAY=1", start: start, expected: []time.Time{day(11, 1), day(12, 1)}},
+		{rule: "FREQ=MONTHLY;BYMONTHDAY=-1", start: start, expected: []time.Time{day(10, 31), day(11, 30), day(12, 31)}},
+		{rule: "FREQ=MONTHLY;BYMONTHDAY=20", start: start, expected: []time.Time{day(10, 20), day(11, 20)}},
+		{
+			rule:     "FREQ=MONTHLY",
+			start:    time.Date(2026, 8, 31, 8, 0, 0, 0, time.UTC),
+			expected: []time.Time{day(8, 31), day(10, 31), day(12, 31)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			rule, err := ParseRecurrence(tt.rule)
+			require.NoError(t, err)
+
+			occurrence := rule.First(tt.start)
+			for i, expected := range tt.expected {
+				if i > 0 {
+					occurrence = rule.Next(occurrence)
+				}
+				assert.Equal(t, expected, occurrence, "occurrence %d", i)
+			}
+		})
+	}
+}
+
+func TestRecurrence_NextUnreachable(t *testing.T) {
+	rule, err := ParseRecurrence("FREQ=MONTHLY;INTERVAL=12;BYMONTHDAY=30")
+	require.NoError(t, err)
+
+	assert.True(t, rule.Next(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)).IsZero())
+}
@@ -0,0 +1,43 @@
+package entities
+
+import "sort"
+
+// SearchHitKind tells whether a search hit is a shopping list or an item
+type SearchHitKind string
+
+// Kinds of search hits
+const (
+	SearchHitList SearchHitKind = "list"
+	SearchHitItem SearchHitKind = "item"
+)
+
+// SearchHit is a shopping list or an item matching a search, with the list it belongs to.
+// Higher ranks are better matches; ranks are only comparable within one search.
+type SearchHit struct {
+	Kind SearchHitKind
+	Rank float64
+	List *ShoppingList
+	Item *Item
+}
+
+// SortSearchHits orders hits from the best match down, listing shopping lists before
+// items of the same rank and otherwise ordering by name
+func SortSearchHits(hits []SearchHit) {
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Rank != hits[j].Rank {
+			return hits[i].Rank > hits[j].Rank
+		}
+		if hits[i].Kind != hits[j].Kind {
+			return hits[i].Kind == SearchHitList
+		}
+		return hits[i].name() < hits[j].name()
+	})
+}
+
+// name returns the name of the hit itself
+func (h SearchHit) name() string {
+	if h.Item != nil {
+		return h.Item.Name
+	}
+	return h.List.Name
+}
@@ -0,0 +1,26 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortSearchHits(t *testing.T) {
+	list := NewShoppingList("Hardware", "")
+	bulbs := NewItem("Bulbs", 1)
+	batteries := NewItem("Batteries", 1)
+
+	hits := []SearchHit{
+		{Kind: SearchHitItem, Rank: 0.5, List: list, Item: bulbs},
+		{Kind: SearchHitItem, Rank: 0.5, List: list, Item: batteries},
+		{Kind: SearchHitList, Rank: 0.5, List: list},
+		{Kind: SearchHitItem, Rank: 0.9, List: list, Item: bulbs},
+	}
+	SortSearchHits(hits)
+
+	assert.Equal(t, 0.9, hits[0].Rank)
+	assert.Equal(t, SearchHitList, hits[1].Kind)
+	assert.Equal(t, "Batteries", hits[2].Item.Name)
+	assert.Equal(t, "Bulbs", hits[3].Item.Name)
+}
@@ -7,14 +7,47 @@ import (
 	"github.com/google/uuid"
 )
 
-// ShoppingList represents the main aggregate root
+// ShoppingList represents the main aggregate root.
+// Lists created before user accounts existed have no owner. Members holds the users the
+// list is shared with; it is only loaded along with a single list.
 type ShoppingList struct {
-	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
-	Name        string    `json:"name" gorm:"not null"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
-	Items       []Item    `json:"items" gorm:"foreignKey:ShoppingListID;constraint:OnDelete:CASCADE"`
+	ID          uuid.UUID    `json:"id" gorm:"type:uuid;primary_key"`
+	OwnerID     *uuid.UUID   `json:"owner_id" gorm:"type:uuid;index"`
+	Name        string       `json:"name" gorm:"not null"`
+	Description string       `json:"description"`
+	Budget      *int64       `json:"budget"`
+	Currency    string       `json:"currency" gorm:"type:varchar(3)"`
+	Version     int          `json:"version" gorm:"not null;default:1"`
+	CreatedAt   time.Time    `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time    `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt   *time.Time   `json:"deleted_at,omitempty" gorm:"index"`
+	Items       []Item       `json:"items" gorm:"foreignKey:ShoppingListID;constraint:OnDelete:CASCADE"`
+	Members     []ListMember `json:"-" gorm:"foreignKey:ShoppingListID;constraint:OnDelete:CASCADE"`
+}
+
+// IsOwnedBy reports whether a user owns the shopping list
+func (l *ShoppingList) IsOwnedBy(userID uuid.UUID) bool {
+	return l.OwnerID != nil && *l.OwnerID == userID
+}
+
+// RoleOf returns the role a user holds in the shopping list, and false when the user
+// neither owns the list nor is one of its loaded members
+func (l *ShoppingList) RoleOf(userID uuid.UUID) (ListRole, bool) {
+	if l.IsOwnedBy(userID) {
+		return ListRoleOwner, true
+	}
+	for _, member := range l.Members {
+		if member.UserID == userID {
+			return member.Role, true
+		}
+	}
+	return "", false
+}
+
+// ItemCounts summarizes the items of a shopping list
+type ItemCounts struct {
+	Total     int `json:"total"`
+	Completed int `json:"completed"`
 }
 
 // NewShoppingList creates a new shopping list
@@ -24,20 +57,25 @@ func NewShoppingList(name, description string) *ShoppingList {
 		Name:        name,
 		Description: description,
 		Items:       make([]Item, 0),
+		Version:     1,
 	}
 }
 
-// AddItem adds an item to the shopping list
+// AddItem adds an item to the end of the shopping list
 func (sl *ShoppingList) AddItem(item *Item) {
 	item.ShoppingListID = sl.ID
+	item.Position = len(sl.Items)
 	sl.Items = append(sl.Items, *item)
 }
 
-// RemoveItem removes an item from the shopping list
+// RemoveItem removes an item from the shopping list, closing the gap it leaves in the order
 func (sl *ShoppingList) RemoveItem(itemID uuid.UUID) {
 	for i, item := range sl.Items {
 		if item.ID == itemID {
 			sl.Items = append(sl.Items[:i], sl.Items[i+1:]...)
+			for j := i; j < len(sl.Items); j++ {
+				sl.Items[j].Position = j
+			}
 			break
 		}
 	}
@@ -54,7 +92,7 @@ func (sl *ShoppingList) GetItem(itemID uuid.UUID) *Item {
 }
 
 // UpdateItem updates an existing item
-func (sl *ShoppingList) UpdateItem(itemID uuid.UUID, name string, quantity int, completed bool) error {
+func (sl *ShoppingList) UpdateItem(itemID uuid.UUID, name string, quantity float64, completed bool) error {
 	for i := range sl.Items {
 		if sl.Items[i].ID == itemID {
 			sl.Items[i].Name = name
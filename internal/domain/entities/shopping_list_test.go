@@ -66,14 +66,14 @@ func TestShoppingList_AddItem(t *testing.T) {
 	assert.Len(t, list.Items, 1)
 	assert.Equal(t, list.ID, list.Items[0].ShoppingListID)
 	assert.Equal(t, "Milk", list.Items[0].Name)
-	assert.Equal(t, 2, list.Items[0].Quantity)
+	assert.Equal(t, 2.0, list.Items[0].Quantity)
 
 	// Test adding second item
 	list.AddItem(item2)
 	assert.Len(t, list.Items, 2)
 	assert.Equal(t, list.ID, list.Items[1].ShoppingListID)
 	assert.Equal(t, "Bread", list.Items[1].Name)
-	assert.Equal(t, 1, list.Items[1].Quantity)
+	assert.Equal(t, 1.0, list.Items[1].Quantity)
 }
 
 func TestShoppingList_RemoveItem(t *testing.T) {
@@ -122,7 +122,7 @@ func TestShoppingList_GetItem(t *testing.T) {
 	assert.NotNil(t, foundItem)
 	assert.Equal(t, item1.ID, foundItem.ID)
 	assert.Equal(t, "Milk", foundItem.Name)
-	assert.Equal(t, 2, foundItem.Quantity)
+	assert.Equal(t, 2.0, foundItem.Quantity)
 
 	// Test getting another existing item
 	foundItem2 := list.GetItem(item2.ID)
@@ -145,7 +145,7 @@ func TestShoppingList_UpdateItem(t *testing.T) {
 		name         string
 		itemID       uuid.UUID
 		newName      string
-		newQuantity  int
+		newQuantity  float64
 		newCompleted bool
 		wantErr      bool
 		expectedErr  error
@@ -217,7 +217,7 @@ func TestShoppingList_Integration(t *testing.T) {
 
 	updatedMilk := list.GetItem(milk.ID)
 	assert.Equal(t, "Organic Milk", updatedMilk.Name)
-	assert.Equal(t, 3, updatedMilk.Quantity)
+	assert.Equal(t, 3.0, updatedMilk.Quantity)
 	assert.True(t, updatedMilk.Completed)
 
 	// Remove an item
@@ -229,3 +229,44 @@ func TestShoppingList_Integration(t *testing.T) {
 	assert.NotNil(t, list.GetItem(milk.ID))
 	assert.NotNil(t, list.GetItem(eggs.ID))
 }
+
+func TestShoppingList_ItemPositions(t *testing.T) {
+	list := NewShoppingList("Test List", "")
+	milk := NewItem("Milk", 1)
+	bread := NewItem("Bread", 1)
+	eggs := NewItem("Eggs", 12)
+
+	list.AddItem(milk)
+	list.AddItem(bread)
+	list.AddItem(eggs)
+
+	assert.Equal(t, 0, list.Items[0].Position)
+	assert.Equal(t, 1, list.Items[1].Position)
+	assert.Equal(t, 2, list.Items[2].Position)
+
+	list.RemoveItem(milk.ID)
+
+	require.Len(t, list.Items, 2)
+	assert.Equal(t, "Bread", list.Items[0].Name)
+	assert.Equal(t, 0, list.Items[0].Position)
+	assert.Equal(t, "Eggs", list.Items[1].Name)
+	assert.Equal(t, 1, list.Items[1].Position)
+}
+
+func TestShoppingList_RoleOf(t *testing.T) {
+	owner, editor, stranger := uuid.New(), uuid.New(), uuid.New()
+	list := NewShoppingList("Groceries", "")
+	list.OwnerID = &owner
+	list.Members = []ListMember{*NewListMember(list.ID, editor, ListRoleEditor)}
+
+	role, ok := list.RoleOf(owner)
+	assert.True(t, ok)
+	assert.Equal(t, ListRoleOwner, role)
+
+	role, ok = list.RoleOf(editor)
+	assert.True(t, ok)
+	assert.Equal(t, ListRoleEditor, role)
+
+	_, ok = list.RoleOf(stranger)
+	assert.False(t, ok)
+}
@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Staple is a product that should always be at home. Whenever the quantity on hand
+// falls below MinQuantity, the shortfall is put on the shopping list ShoppingListID.
+type Staple struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	OwnerID        *uuid.UUID `json:"owner_id" gorm:"type:uuid;index"`
+	Name           string     `json:"name" gorm:"not null"`
+	Unit           Unit       `json:"unit" gorm:"type:varchar(16);not null;default:pieces"`
+	OnHand         float64    `json:"on_hand" gorm:"type:numeric(12,3);not null;default:0"`
+	MinQuantity    float64    `json:"min_quantity" gorm:"type:numeric(12,3);not null"`
+	ShoppingListID uuid.UUID  `json:"shopping_list_id" gorm:"type:uuid;not null;index"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// NewStaple creates a new staple replenished on a shopping list
+func NewStaple(name string, unit Unit, onHand, minQuantity float64, shoppingListID uuid.UUID) *Staple {
+	return &Staple{
+		ID:             uuid.New(),
+		Name:           name,
+		Unit:           unit,
+		OnHand:         onHand,
+		MinQuantity:    minQuantity,
+		ShoppingListID: shoppingListID,
+	}
+}
+
+// IsLow reports whether the quantity on hand is below the minimum
+func (s *Staple) IsLow() bool {
+	return s.OnHand < s.MinQuantity
+}
+
+// Shortfall returns the quantity to buy to get back to the minimum, rounded to thousandths,
+// or zero when there is enough
+func (s *Staple) Shortfall() float64 {
+	if !s.IsLow() {
+		return 0
+	}
+	return roundQuantity(s.MinQuantity - s.OnHand)
+}
@@ -0,0 +1,26 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaple_Shortfall(t *testing.T) {
+	staple := NewStaple("Coffee", UnitPack, 3, 2, uuid.New())
+	assert.False(t, staple.IsLow())
+	assert.Zero(t, staple.Shortfall())
+
+	staple.OnHand = 2
+	assert.False(t, staple.IsLow())
+	assert.Zero(t, staple.Shortfall())
+
+	staple.OnHand = 0
+	assert.True(t, staple.IsLow())
+	assert.Equal(t, 2.0, staple.Shortfall())
+
+	// Binary floating point makes 0.3 - 0.1 come out as 0.19999999999999998
+	flour := NewStaple("Flour", UnitKilogram, 0.1, 0.3, uuid.New())
+	assert.Equal(t, 0.2, flour.Shortfall())
+}
@@ -0,0 +1,110 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Template is a reusable blueprint for shopping lists, such as "Weekly basics".
+// Templates are stored separately from live lists and never change when a list made from them does.
+// A template with a recurrence rule creates a new list whenever NextRunAt comes due.
+// The rule is evaluated in TimeZone, an IANA name such as "Europe/Madrid", or in UTC when it is empty.
+type Template struct {
+	ID          uuid.UUID      `json:"id" gorm:"type:uuid;primary_key"`
+	OwnerID     *uuid.UUID     `json:"owner_id" gorm:"type:uuid;index"`
+	Name        string         `json:"name" gorm:"not null"`
+	Description string         `json:"description"`
+	Budget      *int64         `json:"budget"`
+	Currency    string         `json:"currency" gorm:"type:varchar(3)"`
+	Recurrence  string         `json:"recurrence" gorm:"type:varchar(255)"`
+	TimeZone    string         `json:"time_zone" gorm:"type:varchar(64)"`
+	NextRunAt   *time.Time     `json:"next_run_at" gorm:"index"`
+	CreatedAt   time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	Items       []TemplateItem `json:"items" gorm:"foreignKey:TemplateID;constraint:OnDelete:CASCADE"`
+}
+
+// TemplateItem represents an item of a template
+type TemplateItem struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	TemplateID uuid.UUID  `json:"template_id" gorm:"type:uuid;not null;index"`
+	Name       string     `json:"name" gorm:"not null"`
+	Quantity   float64    `json:"quantity" gorm:"type:numeric(12,3);default:1"`
+	Unit       Unit       `json:"unit" gorm:"type:varchar(16);not null;default:pieces"`
+	CategoryID *uuid.UUID `json:"category_id" gorm:"type:uuid"`
+	Position   int        `json:"position" gorm:"not null;default:0"`
+	UnitPrice  *int64     `json:"unit_price"`
+	Currency   string     `json:"currency" gorm:"type:varchar(3)"`
+}
+
+// NewTemplate creates a new template without items
+func NewTemplate(name, description string) *Template {
+	return &Template{
+		ID:          uuid.New(),
+		Name:        name,
+		Description: description,
+		Items:       make([]TemplateItem, 0),
+	}
+}
+
+// Location returns the time zone the recurrence of the template is evaluated in
+func (t *Template) Location() (*time.Location, error) {
+	return LoadTimeZone(t.TimeZone)
+}
+
+// LoadTimeZone loads an IANA time zone, where the empty name means UTC.
+// The server's local zone is not accepted, since it would change with the deployment.
+func LoadTimeZone(name string) (*time.Location, error) {
+	if name == "Local" {
+		return nil, ErrInvalidInput
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrInvalidInput
+	}
+	return location, nil
+}
+
+// SetItems replaces the items of the template, positioning them in the given order
+func (t *Template) SetItems(items []TemplateItem) {
+	t.Items = make([]TemplateItem, len(items))
+	for i, item := range items {
+		item.ID = uuid.New()
+		item.TemplateID = t.ID
+		item.Position = i
+		t.Items[i] = item
+	}
+}
+
+// Instantiate builds a new shopping list and its items from the template.
+// An empty name keeps the name of the template; the items are left for the caller to store.
+func (t *Template) Instantiate(name string) (*ShoppingList, []*Item) {
+	if name == "" {
+		name = t.Name
+	}
+	list := NewShoppingList(name, t.Description)
+	if t.Budget != nil {
+		budget := *t.Budget
+		list.Budget = &budget
+	}
+	list.Currency = t.Currency
+
+	items := make([]*Item, len(t.Items))
+	for i, templateItem := range t.Items {
+		item := NewItem(templateItem.Name, templateItem.Quantity)
+		item.ShoppingListID = list.ID
+		item.Unit = templateItem.Unit
+		item.Currency = templateItem.Currency
+		if templateItem.CategoryID != nil {
+			categoryID := *templateItem.CategoryID
+			item.CategoryID = &categoryID
+		}
+		if templateItem.UnitPrice != nil {
+			unitPrice := *templateItem.UnitPrice
+			item.UnitPrice = &unitPrice
+		}
+		items[i] = item
+	}
+	return list, items
+}
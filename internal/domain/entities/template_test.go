@@ -0,0 +1,88 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplate_SetItems(t *testing.T) {
+	template := NewTemplate("Weekly basics", "")
+	template.SetItems([]TemplateItem{{Name: "Milk", Position: 7}, {Name: "Bread"}})
+
+	require.Len(t, template.Items, 2)
+	for i, item := range template.Items {
+		assert.NotEqual(t, uuid.Nil, item.ID)
+		assert.Equal(t, template.ID, item.TemplateID)
+		assert.Equal(t, i, item.Position)
+	}
+	assert.NotEqual(t, template.Items[0].ID, template.Items[1].ID)
+}
+
+func TestTemplate_Instantiate(t *testing.T) {
+	budget := int64(3000)
+	price := int64(250)
+	categoryID := uuid.New()
+	template := NewTemplate("BBQ party", "Summer")
+	template.Budget = &budget
+	template.Currency = "EUR"
+	template.SetItems([]TemplateItem{
+		{Name: "Sausages", Quantity: 12, Unit: UnitPieces, CategoryID: &categoryID, UnitPrice: &price, Currency: "EUR"},
+		{Name: "Charcoal", Quantity: 1, Unit: UnitPack},
+	})
+
+	list, items := template.Instantiate("")
+
+	assert.NotEqual(t, template.ID, list.ID)
+	assert.Equal(t, "BBQ party", list.Name)
+	assert.Equal(t, "Summer", list.Description)
+	assert.Equal(t, budget, *list.Budget)
+	assert.NotSame(t, template.Budget, list.Budget)
+	assert.Equal(t, 1, list.Version)
+
+	require.Len(t, items, 2)
+	assert.Equal(t, list.ID, items[0].ShoppingListID)
+	assert.Equal(t, "Sausages", items[0].Name)
+	assert.Equal(t, float64(12), items[0].Quantity)
+	assert.Equal(t, categoryID, *items[0].CategoryID)
+	assert.NotSame(t, template.Items[0].CategoryID, items[0].CategoryID)
+	assert.Equal(t, price, *items[0].UnitPrice)
+	assert.Equal(t, UnitPack, items[1].Unit)
+	assert.False(t, items[1].Completed)
+	assert.Equal(t, 1, items[1].Version)
+
+	named, _ := template.Instantiate("Beach BBQ")
+	assert.Equal(t, "Beach BBQ", named.Name)
+}
+
+func TestTemplate_Location(t *testing.T) {
+	tests := []struct {
+		name          string
+		timeZone      string
+		expected      string
+		expectedError error
+	}{
+		{name: "defaults to UTC", expected: "UTC"},
+		{name: "IANA zone", timeZone: "Europe/Madrid", expected: "Europe/Madrid"},
+		{name: "unknown zone should fail", timeZone: "Mars/Olympus", expectedError: ErrInvalidInput},
+		{name: "server zone should fail", timeZone: "Local", expectedError: ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := NewTemplate("Weekly basics", "")
+			template.TimeZone = tt.timeZone
+
+			location, err := template.Location()
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, location.String())
+		})
+	}
+}
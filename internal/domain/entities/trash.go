@@ -0,0 +1,8 @@
+package entities
+
+// Trash holds the shopping lists and items that were deleted but can still be restored.
+// Items only appear when they were deleted on their own, not along with their list.
+type Trash struct {
+	Lists []*ShoppingList `json:"lists"`
+	Items []*Item         `json:"items"`
+}
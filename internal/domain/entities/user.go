@@ -0,0 +1,52 @@
+package entities
+
+import (
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Length limits of a password, in bytes; bcrypt ignores anything past 72 bytes
+const (
+	MinPasswordLength = 8
+	MaxPasswordLength = 72
+)
+
+// User is an account that owns shopping lists.
+// Email is stored normalized and PasswordHash holds a bcrypt hash.
+type User struct {
+	ID           uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	Email        string    `json:"email" gorm:"type:varchar(254);not null;uniqueIndex"`
+	PasswordHash string    `json:"-" gorm:"not null"`
+	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// NewUser creates a new user with an already hashed password
+func NewUser(email, passwordHash string) *User {
+	return &User{
+		ID:           uuid.New(),
+		Email:        email,
+		PasswordHash: passwordHash,
+	}
+}
+
+// NormalizeEmail validates a bare email address such as "Ana@Example.com" and lowercases it
+func NormalizeEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email || len(email) > 254 {
+		return "", ErrInvalidInput
+	}
+	return strings.ToLower(email), nil
+}
+
+// ValidatePassword checks that a password fits the length limits
+func ValidatePassword(password string) error {
+	if len(password) < MinPasswordLength || len(password) > MaxPasswordLength {
+		return ErrInvalidInput
+	}
+	return nil
+}
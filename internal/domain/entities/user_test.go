@@ -0,0 +1,36 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeEmail(t *testing.T) {
+	email, err := NormalizeEmail("  Ana@Example.com ")
+	assert.NoError(t, err)
+	assert.Equal(t, "ana@example.com", email)
+
+	for _, invalid := range []string{"", "ana", "Ana <ana@example.com>", "ana@example.com, bob@example.com"} {
+		_, err := NormalizeEmail(invalid)
+		assert.Equal(t, ErrInvalidInput, err, invalid)
+	}
+}
+
+func TestValidatePassword(t *testing.T) {
+	assert.NoError(t, ValidatePassword("correct horse"))
+	assert.Equal(t, ErrInvalidInput, ValidatePassword("short"))
+	assert.Equal(t, ErrInvalidInput, ValidatePassword(strings.Repeat("a", MaxPasswordLength+1)))
+}
+
+func TestShoppingList_IsOwnedBy(t *testing.T) {
+	owner := uuid.New()
+	list := NewShoppingList("Groceries", "")
+	assert.False(t, list.IsOwnedBy(owner))
+
+	list.OwnerID = &owner
+	assert.True(t, list.IsOwnedBy(owner))
+	assert.False(t, list.IsOwnedBy(uuid.New()))
+}
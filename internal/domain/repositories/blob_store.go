@@ -0,0 +1,11 @@
+package repositories
+
+import "context"
+
+// BlobStore keeps binary objects such as item images under slash-separated keys.
+// Get returns entities.ErrBlobNotFound for a missing key, and deleting a missing key is not an error.
+type BlobStore interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// ItemBatch holds item changes to store together.
+// Updated items must still have the version they were read at.
+type ItemBatch struct {
+	Create []*entities.Item
+	Update []*entities.Item
+	Delete []uuid.UUID
+}
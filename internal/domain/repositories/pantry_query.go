@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PantryQuery filters the pantry; zero fields do not filter.
+// Location matches exactly, ignoring case, and ExpiresBefore keeps dated items expiring before it.
+type PantryQuery struct {
+	OwnerID       *uuid.UUID
+	Location      string
+	ExpiresBefore *time.Time
+}
@@ -0,0 +1,11 @@
+package repositories
+
+import "github.com/google/uuid"
+
+// SearchQuery holds the text to search shopping lists and items for and how many hits to return.
+// A non-nil AccessibleTo keeps the lists that user owns or is a member of, and their items.
+type SearchQuery struct {
+	Text         string
+	Limit        int
+	AccessibleTo *uuid.UUID
+}
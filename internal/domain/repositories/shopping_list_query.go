@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ShoppingListSortField names the attribute shopping lists are ordered by
+type ShoppingListSortField string
+
+// Supported shopping list sort fields
+const (
+	SortByName      ShoppingListSortField = "name"
+	SortByCreatedAt ShoppingListSortField = "created_at"
+	SortByUpdatedAt ShoppingListSortField = "updated_at"
+)
+
+// IsValid reports whether the field is one of the supported sort fields
+func (f ShoppingListSortField) IsValid() bool {
+	switch f {
+	case SortByName, SortByCreatedAt, SortByUpdatedAt:
+		return true
+	}
+	return false
+}
+
+// ShoppingListCursor marks the last shopping list of a page in its sort order.
+// Only the key matching the sort field is set; ID breaks ties between equal keys.
+type ShoppingListCursor struct {
+	Name string    `json:"name,omitempty"`
+	Time time.Time `json:"time,omitempty"`
+	ID   uuid.UUID `json:"id"`
+}
+
+// ShoppingListQuery selects, orders and limits the shopping lists to retrieve.
+// Lists always tie-break on ID, so that After resumes exactly where a page ended.
+// A non-nil AccessibleTo keeps the lists that user owns or is a member of.
+type ShoppingListQuery struct {
+	AccessibleTo *uuid.UUID
+	NameContains string
+	UpdatedSince *time.Time
+	SortBy       ShoppingListSortField
+	Descending   bool
+	After        *ShoppingListCursor
+	Limit        int
+}
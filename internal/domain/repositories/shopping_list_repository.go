@@ -3,25 +3,151 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
 )
 
-// ShoppingListRepository defines the contract for shopping list persistence
+// ShoppingListRepository defines the contract for shopping list persistence.
+// GetByID and GetTrashedByID load the members of the list. GetTrashed keeps the lists of one owner,
+// or every list when the owner is nil.
 type ShoppingListRepository interface {
 	Create(ctx context.Context, list *entities.ShoppingList) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entities.ShoppingList, error)
-	GetAll(ctx context.Context) ([]*entities.ShoppingList, error)
+	GetAll(ctx context.Context, query ShoppingListQuery) ([]*entities.ShoppingList, error)
 	Update(ctx context.Context, list *entities.ShoppingList) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	GetTrashed(ctx context.Context, ownerID *uuid.UUID) ([]*entities.ShoppingList, error)
+	GetTrashedByID(ctx context.Context, id uuid.UUID) (*entities.ShoppingList, error)
+	Restore(ctx context.Context, id uuid.UUID) error
+	PurgeTrashed(ctx context.Context, deletedBefore time.Time) (int64, error)
 }
 
-// ItemRepository defines the contract for item persistence
+// ItemRepository defines the contract for item persistence.
+// GetTrashed keeps the items of the lists a user owns or is a member of, or every item when the user is nil.
 type ItemRepository interface {
 	Create(ctx context.Context, item *entities.Item) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entities.Item, error)
 	GetByShoppingListID(ctx context.Context, shoppingListID uuid.UUID) ([]*entities.Item, error)
+	GetByShoppingListIDs(ctx context.Context, shoppingListIDs []uuid.UUID) ([]*entities.Item, error)
+	CountByShoppingListIDs(ctx context.Context, shoppingListIDs []uuid.UUID) (map[uuid.UUID]entities.ItemCounts, error)
 	Update(ctx context.Context, item *entities.Item) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	Reorder(ctx context.Context, shoppingListID uuid.UUID, itemIDs []uuid.UUID) error
+	ApplyBatch(ctx context.Context, batch ItemBatch) error
+	DeleteCompleted(ctx context.Context, shoppingListID uuid.UUID) (int64, error)
+	SetCompleted(ctx context.Context, shoppingListID uuid.UUID, completed bool) (int64, error)
+	GetTrashed(ctx context.Context, userID *uuid.UUID) ([]*entities.Item, error)
+	GetTrashedByID(ctx context.Context, id uuid.UUID) (*entities.Item, error)
+	Restore(ctx context.Context, id uuid.UUID) error
+	PurgeTrashed(ctx context.Context, deletedBefore time.Time) (int64, error)
+}
+
+// CategoryRepository defines the contract for category persistence.
+// GetByName and GetAll keep the categories of one owner, or every category when the owner is nil.
+type CategoryRepository interface {
+	Create(ctx context.Context, category *entities.Category) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Category, error)
+	GetByName(ctx context.Context, ownerID *uuid.UUID, name string) (*entities.Category, error)
+	GetAll(ctx context.Context, ownerID *uuid.UUID) ([]*entities.Category, error)
+	Update(ctx context.Context, category *entities.Category) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// TemplateRepository defines the contract for template persistence.
+// Templates are always read and written together with their items. GetAll keeps the
+// templates of one owner, or every template when the owner is nil, and GetDue skips
+// templates without an owner.
+type TemplateRepository interface {
+	Create(ctx context.Context, template *entities.Template) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Template, error)
+	GetAll(ctx context.Context, ownerID *uuid.UUID) ([]*entities.Template, error)
+	Update(ctx context.Context, template *entities.Template) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetDue(ctx context.Context, now time.Time) ([]*entities.Template, error)
+	AdvanceSchedule(ctx context.Context, id uuid.UUID, due time.Time, next *time.Time) (bool, error)
+}
+
+// SearchRepository defines the contract for searching shopping lists and items.
+// Trashed lists and items, and items of trashed lists, are never returned.
+type SearchRepository interface {
+	Search(ctx context.Context, query SearchQuery) ([]entities.SearchHit, error)
+}
+
+// ItemImageRepository defines the contract for the metadata of item images.
+// Save replaces the image an item already has. GetTrashedBefore returns the images of the items
+// that purging the trash of everything deleted before a time removes.
+type ItemImageRepository interface {
+	Save(ctx context.Context, image *entities.ItemImage) error
+	GetByItemID(ctx context.Context, itemID uuid.UUID) (*entities.ItemImage, error)
+	GetTrashedBefore(ctx context.Context, deletedBefore time.Time) ([]*entities.ItemImage, error)
+	Delete(ctx context.Context, itemID uuid.UUID) error
+}
+
+// PantryRepository defines the contract for pantry persistence.
+// GetAll orders items by expiry date, soonest first and undated last, then by name.
+type PantryRepository interface {
+	Create(ctx context.Context, item *entities.PantryItem) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.PantryItem, error)
+	GetAll(ctx context.Context, query PantryQuery) ([]*entities.PantryItem, error)
+	Update(ctx context.Context, item *entities.PantryItem) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// StapleRepository defines the contract for staple persistence.
+// GetAll and GetLow order staples by name and keep those of one owner, or every staple
+// when the owner is nil.
+type StapleRepository interface {
+	Create(ctx context.Context, staple *entities.Staple) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Staple, error)
+	GetAll(ctx context.Context, ownerID *uuid.UUID) ([]*entities.Staple, error)
+	GetLow(ctx context.Context, ownerID *uuid.UUID) ([]*entities.Staple, error)
+	Update(ctx context.Context, staple *entities.Staple) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// UserRepository defines the contract for user persistence.
+// Create fails with ErrDuplicateEmail when the email is taken, and GetByEmail expects a normalized email. AdoptUnowned gives a user the lists, templates,
+// pantry items, staples and categories created before accounts existed and returns how many
+// rows it took over.
+type UserRepository interface {
+	Create(ctx context.Context, user *entities.User) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error)
+	GetByEmail(ctx context.Context, email string) (*entities.User, error)
+	AdoptUnowned(ctx context.Context, userID uuid.UUID) (int64, error)
+}
+
+// APIKeyRepository defines the contract for API key persistence
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *entities.APIKey) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.APIKey, error)
+	GetByHash(ctx context.Context, keyHash string) (*entities.APIKey, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.APIKey, error)
+	TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// ListMemberRepository defines the contract for shopping list membership persistence.
+// Create fails with ErrDuplicateMember when the user already is a member, and
+// GetByShoppingListID orders members by when they joined.
+type ListMemberRepository interface {
+	Create(ctx context.Context, member *entities.ListMember) error
+	Get(ctx context.Context, shoppingListID, userID uuid.UUID) (*entities.ListMember, error)
+	GetByShoppingListID(ctx context.Context, shoppingListID uuid.UUID) ([]*entities.ListMember, error)
+	Update(ctx context.Context, member *entities.ListMember) error
+	Delete(ctx context.Context, shoppingListID, userID uuid.UUID) error
+}
+
+// ListInvitationRepository defines the contract for shopping list invitation persistence.
+// GetOutstanding keeps the invitations of a list that are unexpired at a time and have uses
+// left, newest first. Redeem counts a use of an invitation, failing with ErrInvitationExpired
+// when it has expired or has no uses left.
+type ListInvitationRepository interface {
+	Create(ctx context.Context, invitation *entities.ListInvitation) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.ListInvitation, error)
+	GetByHash(ctx context.Context, tokenHash string) (*entities.ListInvitation, error)
+	GetOutstanding(ctx context.Context, shoppingListID uuid.UUID, now time.Time) ([]*entities.ListInvitation, error)
+	Redeem(ctx context.Context, id uuid.UUID, now time.Time) error
+	Delete(ctx context.Context, id uuid.UUID) error
 }
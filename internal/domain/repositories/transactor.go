@@ -0,0 +1,10 @@
+package repositories
+
+import "context"
+
+// Transactor runs work against several repositories in a single transaction.
+// Repository calls made with the context passed to fn take part in the transaction,
+// which is rolled back when fn returns an error.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -37,7 +37,10 @@ func NewPostgresConnection(config Config) (*gorm.DB, error) {
 func AutoMigrate(db *gorm.DB) error {
 	err := db.AutoMigrate(
 		&entities.ShoppingList{},
+		&entities.Category{},
 		&entities.Item{},
+		&entities.ListMember{},
+		&entities.ListInvitation{},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
@@ -0,0 +1,15 @@
+package persistence
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// isDuplicateKey reports whether an error is the violation of a unique index, as reported by the database of db
+func isDuplicateKey(db *gorm.DB, err error) bool {
+	if translator, ok := db.Dialector.(gorm.ErrorTranslator); ok {
+		err = translator.Translate(err)
+	}
+	return errors.Is(err, gorm.ErrDuplicatedKey)
+}
@@ -0,0 +1,116 @@
+package persistence
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+// Weights of the fields a pattern-matching search ranks, mirroring the full-text weights
+const (
+	nameWeight        = 1.0
+	descriptionWeight = 0.4
+	brandWeight       = 0.4
+	detailWeight      = 0.2
+)
+
+// LikeSearchRepository implements the SearchRepository interface with LIKE patterns,
+// for databases without full-text search such as SQLite. Every word of the query must
+// appear in a hit, and hits are ranked and limited in SQL.
+type LikeSearchRepository struct {
+	db *gorm.DB
+}
+
+// NewLikeSearchRepository creates a new pattern-matching search repository
+func NewLikeSearchRepository(db *gorm.DB) repositories.SearchRepository {
+	return &LikeSearchRepository{db: db}
+}
+
+// weightedColumn is a searched column and the weight of its matches in the rank
+type weightedColumn struct {
+	name   string
+	weight float64
+}
+
+// Search ranks the shopping lists and items containing every word of the query
+func (r *LikeSearchRepository) Search(ctx context.Context, query repositories.SearchQuery) ([]entities.SearchHit, error) {
+	terms := strings.Fields(strings.ToLower(query.Text))
+	if len(terms) == 0 {
+		return []entities.SearchHit{}, nil
+	}
+	db := conn(ctx, r.db)
+
+	listColumns := []weightedColumn{
+		{name: "shopping_lists.name", weight: nameWeight},
+		{name: "shopping_lists.description", weight: descriptionWeight},
+	}
+	listRank, listRankArgs := likeRank(terms, listColumns)
+	var lists []rankedList
+	err := likeMatch(db.Model(&entities.ShoppingList{}), terms, listColumns).
+		Scopes(notTrashed, accessibleTo(query.AccessibleTo)).
+		Select("shopping_lists.*, "+listRank+" AS rank", listRankArgs...).
+		Order("rank DESC").
+		Limit(query.Limit).
+		Scan(&lists).Error
+	if err != nil {
+		return nil, err
+	}
+
+	itemColumns := []weightedColumn{
+		{name: "items.name", weight: nameWeight},
+		{name: "items.brand", weight: brandWeight},
+		{name: "items.size", weight: detailWeight},
+		{name: "items.notes", weight: detailWeight},
+	}
+	itemRank, itemRankArgs := likeRank(terms, itemColumns)
+	var items []rankedItem
+	err = likeMatch(db.Model(&entities.Item{}), terms, itemColumns).
+		Joins("JOIN shopping_lists ON shopping_lists.id = items.shopping_list_id AND shopping_lists.deleted_at IS NULL").
+		Where("items.deleted_at IS NULL").
+		Scopes(accessibleTo(query.AccessibleTo)).
+		Select("items.*, "+itemRank+" AS rank", itemRankArgs...).
+		Order("rank DESC").
+		Limit(query.Limit).
+		Scan(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return collectSearchHits(db, lists, items, query.Limit)
+}
+
+// likeMatch restricts a query to the rows where every term appears in one of the columns
+func likeMatch(db *gorm.DB, terms []string, columns []weightedColumn) *gorm.DB {
+	for _, term := range terms {
+		pattern := "%" + escapeLike(term) + "%"
+		conditions := make([]string, len(columns))
+		args := make([]interface{}, len(columns))
+		for i, column := range columns {
+			conditions[i] = "LOWER(" + column.name + ") LIKE ? ESCAPE '\\'"
+			args[i] = pattern
+		}
+		db = db.Where("("+strings.Join(conditions, " OR ")+")", args...)
+	}
+	return db
+}
+
+// likeRank builds the SQL expression that scores how well the columns match the search terms:
+// a term that is a whole space-separated word of a column counts fully and a term inside a longer word counts half
+func likeRank(terms []string, columns []weightedColumn) (string, []interface{}) {
+	var parts []string
+	var args []interface{}
+	for _, column := range columns {
+		weight := strconv.FormatFloat(column.weight, 'f', -1, 64)
+		half := strconv.FormatFloat(column.weight/2, 'f', -1, 64)
+		for _, term := range terms {
+			parts = append(parts, "CASE WHEN ' ' || LOWER("+column.name+") || ' ' LIKE ? ESCAPE '\\' THEN "+weight+
+				" WHEN LOWER("+column.name+") LIKE ? ESCAPE '\\' THEN "+half+" ELSE 0 END")
+			args = append(args, "% "+escapeLike(term)+" %", "%"+escapeLike(term)+"%")
+		}
+	}
+	return "(" + strings.Join(parts, " + ") + ")", args
+}
@@ -0,0 +1,132 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+)
+
+func TestNewSearchRepository_FallsBackOnSQLite(t *testing.T) {
+	db := setupTestDB(t)
+
+	assert.IsType(t, &LikeSearchRepository{}, NewSearchRepository(db))
+}
+
+func TestLikeSearchRepository_Search(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewLikeSearchRepository(db)
+	ctx := context.Background()
+
+	hardware := entities.NewShoppingList("Hardware store", "Batteries and bulbs")
+	groceries := entities.NewShoppingList("Groceries", "")
+	trashedList := entities.NewShoppingList("Old batteries run", "")
+	deletedAt := time.Now()
+	trashedList.DeletedAt = &deletedAt
+	for _, list := range []*entities.ShoppingList{hardware, groceries, trashedList} {
+		require.NoError(t, db.Create(list).Error)
+	}
+
+	aa := entities.NewItem("AA batteries", 8)
+	aa.ShoppingListID = hardware.ID
+	pack := entities.NewItem("Battery pack", 1)
+	pack.ShoppingListID = groceries.ID
+	trashedItem := entities.NewItem("AAA batteries", 4)
+	trashedItem.ShoppingListID = groceries.ID
+	trashedItem.DeletedAt = &deletedAt
+	orphan := entities.NewItem("9V batteries", 1)
+	orphan.ShoppingListID = trashedList.ID
+	for _, item := range []*entities.Item{aa, pack, trashedItem, orphan} {
+		require.NoError(t, db.Create(item).Error)
+	}
+
+	hits, err := repo.Search(ctx, repositories.SearchQuery{Text: "Batteries", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, hits, 2)
+
+	assert.Equal(t, entities.SearchHitItem, hits[0].Kind)
+	assert.Equal(t, aa.ID, hits[0].Item.ID)
+	assert.Equal(t, hardware.ID, hits[0].List.ID)
+	assert.Equal(t, entities.SearchHitList, hits[1].Kind)
+	assert.Equal(t, hardware.ID, hits[1].List.ID)
+	assert.Greater(t, hits[0].Rank, hits[1].Rank)
+
+	hits, err = repo.Search(ctx, repositories.SearchQuery{Text: "batt", Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, hits, 3)
+
+	hits, err = repo.Search(ctx, repositories.SearchQuery{Text: "batt", Limit: 1})
+	require.NoError(t, err)
+	assert.Len(t, hits, 1)
+
+	hits, err = repo.Search(ctx, repositories.SearchQuery{Text: "aa pack", Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+
+	hits, err = repo.Search(ctx, repositories.SearchQuery{Text: "100%", Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+}
+
+func TestLikeSearchRepository_SearchItemDetails(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewLikeSearchRepository(db)
+	ctx := context.Background()
+
+	list := entities.NewShoppingList("Groceries", "")
+	require.NoError(t, db.Create(list).Error)
+
+	milk := entities.NewItem("Milk", 1)
+	milk.ShoppingListID = list.ID
+	milk.Brand = "Arla"
+	milk.Notes = "the lactose-free one, green cap"
+	oatMilk := entities.NewItem("Oat drink", 1)
+	oatMilk.ShoppingListID = list.ID
+	oatMilk.Notes = "Arla if they have it"
+	for _, item := range []*entities.Item{milk, oatMilk} {
+		require.NoError(t, db.Create(item).Error)
+	}
+
+	hits, err := repo.Search(ctx, repositories.SearchQuery{Text: "arla", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, hits, 2)
+	assert.Equal(t, milk.ID, hits[0].Item.ID)
+	assert.Equal(t, oatMilk.ID, hits[1].Item.ID)
+	assert.Greater(t, hits[0].Rank, hits[1].Rank)
+
+	hits, err = repo.Search(ctx, repositories.SearchQuery{Text: "milk lactose-free", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, milk.ID, hits[0].Item.ID)
+}
+
+func TestLikeSearchRepository_SearchLimitKeepsBestMatches(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewLikeSearchRepository(db)
+	ctx := context.Background()
+
+	list := entities.NewShoppingList("Groceries", "")
+	require.NoError(t, db.Create(list).Error)
+
+	// Weaker matches are stored first, so a limit applied before ranking would keep them
+	for _, notes := range []string{"not the milk", "buttermilk", "milk for the cat"} {
+		weak := entities.NewItem("Oat drink", 1)
+		weak.ShoppingListID = list.ID
+		weak.Notes = notes
+		require.NoError(t, db.Create(weak).Error)
+	}
+	milk := entities.NewItem("Milk", 1)
+	milk.ShoppingListID = list.ID
+	require.NoError(t, db.Create(milk).Error)
+
+	hits, err := repo.Search(ctx, repositories.SearchQuery{Text: "milk", Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, milk.ID, hits[0].Item.ID)
+	assert.Equal(t, list.ID, hits[0].List.ID)
+	assert.Equal(t, nameWeight, hits[0].Rank)
+}
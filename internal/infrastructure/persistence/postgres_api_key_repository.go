@@ -0,0 +1,73 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+// PostgresAPIKeyRepository implements the APIKeyRepository interface
+type PostgresAPIKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresAPIKeyRepository creates a new PostgreSQL API key repository
+func NewPostgresAPIKeyRepository(db *gorm.DB) repositories.APIKeyRepository {
+	return &PostgresAPIKeyRepository{db: db}
+}
+
+// Create creates a new API key
+func (r *PostgresAPIKeyRepository) Create(ctx context.Context, key *entities.APIKey) error {
+	return conn(ctx, r.db).Create(key).Error
+}
+
+// GetByID retrieves an API key by ID
+func (r *PostgresAPIKeyRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.APIKey, error) {
+	return r.getWhere(ctx, "id = ?", id)
+}
+
+// GetByHash retrieves the API key with a key hash
+func (r *PostgresAPIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*entities.APIKey, error) {
+	return r.getWhere(ctx, "key_hash = ?", keyHash)
+}
+
+// GetByUserID retrieves the API keys of a user, newest first
+func (r *PostgresAPIKeyRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.APIKey, error) {
+	var keys []*entities.APIKey
+	err := conn(ctx, r.db).Where("user_id = ?", userID).Order("created_at DESC, id").Find(&keys).Error
+	return keys, err
+}
+
+// TouchLastUsed records when an API key was last used
+func (r *PostgresAPIKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	return conn(ctx, r.db).Model(&entities.APIKey{}).Where("id = ?", id).Update("last_used_at", usedAt).Error
+}
+
+// Delete deletes an API key
+func (r *PostgresAPIKeyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := conn(ctx, r.db).Where("id = ?", id).Delete(&entities.APIKey{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// getWhere retrieves the API key matching a condition
+func (r *PostgresAPIKeyRepository) getWhere(ctx context.Context, condition string, value interface{}) (*entities.APIKey, error) {
+	var key entities.APIKey
+	err := conn(ctx, r.db).Where(condition, value).First(&key).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entities.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+	return &key, nil
+}
@@ -0,0 +1,60 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDBForAPIKeys(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(&entities.APIKey{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestPostgresAPIKeyRepository(t *testing.T) {
+	db := setupTestDBForAPIKeys(t)
+	repo := NewPostgresAPIKeyRepository(db)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	apiKey, key, err := entities.NewAPIKey(userID, "Home Assistant", entities.APIKeyScopeReadWrite, nil)
+	require.NoError(t, err)
+	require.NoError(t, repo.Create(ctx, apiKey))
+	other, _, err := entities.NewAPIKey(uuid.New(), "Other", entities.APIKeyScopeReadOnly, nil)
+	require.NoError(t, err)
+	require.NoError(t, repo.Create(ctx, other))
+
+	got, err := repo.GetByHash(ctx, entities.HashAPIKey(key))
+	require.NoError(t, err)
+	assert.Equal(t, apiKey.ID, got.ID)
+	assert.Nil(t, got.LastUsedAt)
+
+	usedAt := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.TouchLastUsed(ctx, apiKey.ID, usedAt))
+	got, err = repo.GetByID(ctx, apiKey.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.LastUsedAt)
+	assert.True(t, usedAt.Equal(*got.LastUsedAt))
+
+	keys, err := repo.GetByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, apiKey.ID, keys[0].ID)
+
+	require.NoError(t, repo.Delete(ctx, apiKey.ID))
+	_, err = repo.GetByHash(ctx, entities.HashAPIKey(key))
+	assert.Equal(t, entities.ErrAPIKeyNotFound, err)
+	assert.Equal(t, entities.ErrAPIKeyNotFound, repo.Delete(ctx, apiKey.ID))
+}
@@ -0,0 +1,83 @@
+package persistence
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+// PostgresCategoryRepository implements the CategoryRepository interface
+type PostgresCategoryRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresCategoryRepository creates a new PostgreSQL category repository
+func NewPostgresCategoryRepository(db *gorm.DB) repositories.CategoryRepository {
+	return &PostgresCategoryRepository{db: db}
+}
+
+// Create creates a new category
+func (r *PostgresCategoryRepository) Create(ctx context.Context, category *entities.Category) error {
+	return conn(ctx, r.db).Create(category).Error
+}
+
+// GetByID retrieves a category by ID
+func (r *PostgresCategoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Category, error) {
+	var category entities.Category
+	err := conn(ctx, r.db).Where("id = ?", id).First(&category).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entities.ErrCategoryNotFound
+		}
+		return nil, err
+	}
+	return &category, nil
+}
+
+// GetByName retrieves a category of an owner by name, ignoring case
+func (r *PostgresCategoryRepository) GetByName(ctx context.Context, ownerID *uuid.UUID, name string) (*entities.Category, error) {
+	var category entities.Category
+	err := conn(ctx, r.db).Scopes(ownedBy(ownerID)).Where("LOWER(name) = ?", strings.ToLower(name)).First(&category).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entities.ErrCategoryNotFound
+		}
+		return nil, err
+	}
+	return &category, nil
+}
+
+// GetAll retrieves the categories of an owner in display order
+func (r *PostgresCategoryRepository) GetAll(ctx context.Context, ownerID *uuid.UUID) ([]*entities.Category, error) {
+	var categories []*entities.Category
+	err := conn(ctx, r.db).Scopes(ownedBy(ownerID)).Order("position ASC").Order("name ASC").Find(&categories).Error
+	return categories, err
+}
+
+// Update updates an existing category
+func (r *PostgresCategoryRepository) Update(ctx context.Context, category *entities.Category) error {
+	return conn(ctx, r.db).Save(category).Error
+}
+
+// Delete deletes a category and detaches it from any items that used it
+func (r *PostgresCategoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entities.Item{}).Where("category_id = ?", id).Update("category_id", nil).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ?", id).Delete(&entities.Category{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return entities.ErrCategoryNotFound
+		}
+		return nil
+	})
+}
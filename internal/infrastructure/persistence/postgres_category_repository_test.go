@@ -0,0 +1,141 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDBForCategories(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(&entities.ShoppingList{}, &entities.Category{}, &entities.Item{}, &entities.ListMember{}, &entities.ListInvitation{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestPostgresCategoryRepository_CreateAndGet(t *testing.T) {
+	db := setupTestDBForCategories(t)
+	repo := NewPostgresCategoryRepository(db)
+	ctx := context.Background()
+
+	category := entities.NewCategory("Produce", 1)
+	require.NoError(t, repo.Create(ctx, category))
+
+	got, err := repo.GetByID(ctx, category.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Produce", got.Name)
+	assert.Equal(t, 1, got.Position)
+
+	got, err = repo.GetByName(ctx, nil, "PRODUCE")
+	require.NoError(t, err)
+	assert.Equal(t, category.ID, got.ID)
+
+	_, err = repo.GetByID(ctx, uuid.New())
+	assert.Equal(t, entities.ErrCategoryNotFound, err)
+
+	_, err = repo.GetByName(ctx, nil, "Frozen")
+	assert.Equal(t, entities.ErrCategoryNotFound, err)
+}
+
+func TestPostgresCategoryRepository_GetAllOrdered(t *testing.T) {
+	db := setupTestDBForCategories(t)
+	repo := NewPostgresCategoryRepository(db)
+	ctx := context.Background()
+
+	for _, category := range []*entities.Category{
+		entities.NewCategory("Dairy", 2),
+		entities.NewCategory("Bakery", 2),
+		entities.NewCategory("Produce", 1),
+	} {
+		require.NoError(t, repo.Create(ctx, category))
+	}
+
+	got, err := repo.GetAll(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	assert.Equal(t, "Produce", got[0].Name)
+	assert.Equal(t, "Bakery", got[1].Name)
+	assert.Equal(t, "Dairy", got[2].Name)
+}
+
+func TestPostgresCategoryRepository_ScopedToOwner(t *testing.T) {
+	db := setupTestDBForCategories(t)
+	repo := NewPostgresCategoryRepository(db)
+	ctx := context.Background()
+
+	ana, bob := uuid.New(), uuid.New()
+	anaProduce := entities.NewCategory("Produce", 1)
+	anaProduce.OwnerID = &ana
+	bobProduce := entities.NewCategory("Produce", 1)
+	bobProduce.OwnerID = &bob
+	require.NoError(t, repo.Create(ctx, anaProduce))
+	require.NoError(t, repo.Create(ctx, bobProduce))
+
+	got, err := repo.GetByName(ctx, &bob, "produce")
+	require.NoError(t, err)
+	assert.Equal(t, bobProduce.ID, got.ID)
+
+	all, err := repo.GetAll(ctx, &ana)
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, anaProduce.ID, all[0].ID)
+
+	_, err = repo.GetByName(ctx, &ana, "Dairy")
+	assert.Equal(t, entities.ErrCategoryNotFound, err)
+}
+
+func TestPostgresCategoryRepository_Update(t *testing.T) {
+	db := setupTestDBForCategories(t)
+	repo := NewPostgresCategoryRepository(db)
+	ctx := context.Background()
+
+	category := entities.NewCategory("Produce", 1)
+	require.NoError(t, repo.Create(ctx, category))
+
+	category.Name = "Fruit & Vegetables"
+	category.Position = 4
+	require.NoError(t, repo.Update(ctx, category))
+
+	got, err := repo.GetByID(ctx, category.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Fruit & Vegetables", got.Name)
+	assert.Equal(t, 4, got.Position)
+}
+
+func TestPostgresCategoryRepository_DeleteDetachesItems(t *testing.T) {
+	db := setupTestDBForCategories(t)
+	repo := NewPostgresCategoryRepository(db)
+	itemRepo := NewPostgresItemRepository(db)
+	ctx := context.Background()
+
+	list := entities.NewShoppingList("Groceries", "")
+	require.NoError(t, db.Create(list).Error)
+
+	category := entities.NewCategory("Produce", 1)
+	require.NoError(t, repo.Create(ctx, category))
+
+	item := entities.NewItem("Apples", 6)
+	item.ShoppingListID = list.ID
+	item.CategoryID = &category.ID
+	require.NoError(t, itemRepo.Create(ctx, item))
+
+	require.NoError(t, repo.Delete(ctx, category.ID))
+
+	_, err := repo.GetByID(ctx, category.ID)
+	assert.Equal(t, entities.ErrCategoryNotFound, err)
+
+	got, err := itemRepo.GetByID(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.CategoryID)
+
+	assert.Equal(t, entities.ErrCategoryNotFound, repo.Delete(ctx, uuid.New()))
+}
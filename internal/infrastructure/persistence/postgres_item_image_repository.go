@@ -0,0 +1,65 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+// PostgresItemImageRepository implements the ItemImageRepository interface
+type PostgresItemImageRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresItemImageRepository creates a new PostgreSQL item image repository
+func NewPostgresItemImageRepository(db *gorm.DB) repositories.ItemImageRepository {
+	return &PostgresItemImageRepository{db: db}
+}
+
+// Save stores the image of an item, replacing the one it had
+func (r *PostgresItemImageRepository) Save(ctx context.Context, image *entities.ItemImage) error {
+	return conn(ctx, r.db).Save(image).Error
+}
+
+// GetByItemID retrieves the image of an item
+func (r *PostgresItemImageRepository) GetByItemID(ctx context.Context, itemID uuid.UUID) (*entities.ItemImage, error) {
+	var image entities.ItemImage
+	err := conn(ctx, r.db).Where("item_id = ?", itemID).First(&image).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entities.ErrImageNotFound
+		}
+		return nil, err
+	}
+	return &image, nil
+}
+
+// GetTrashedBefore retrieves the images of the items moved to the trash before the given time,
+// on their own or along with their shopping list
+func (r *PostgresItemImageRepository) GetTrashedBefore(ctx context.Context, deletedBefore time.Time) ([]*entities.ItemImage, error) {
+	db := conn(ctx, r.db)
+	expiredLists := db.Model(&entities.ShoppingList{}).Select("id").Where("deleted_at < ?", deletedBefore)
+	expiredItems := db.Model(&entities.Item{}).
+		Select("id").
+		Where("deleted_at < ? OR shopping_list_id IN (?)", deletedBefore, expiredLists)
+
+	var images []*entities.ItemImage
+	err := db.Where("item_id IN (?)", expiredItems).Find(&images).Error
+	return images, err
+}
+
+// Delete deletes the image of an item
+func (r *PostgresItemImageRepository) Delete(ctx context.Context, itemID uuid.UUID) error {
+	result := conn(ctx, r.db).Where("item_id = ?", itemID).Delete(&entities.ItemImage{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrImageNotFound
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+func TestPostgresItemImageRepository(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&entities.ItemImage{}))
+	repo := NewPostgresItemImageRepository(db)
+	ctx := context.Background()
+
+	itemID := uuid.New()
+	_, err := repo.GetByItemID(ctx, itemID)
+	assert.Equal(t, entities.ErrImageNotFound, err)
+
+	first := &entities.ItemImage{ItemID: itemID, ContentType: entities.ContentTypeJPEG, Size: 1200, Width: 64, Height: 48, BlobKey: "items/a"}
+	require.NoError(t, repo.Save(ctx, first))
+
+	second := &entities.ItemImage{ItemID: itemID, ContentType: entities.ContentTypePNG, Size: 800, Width: 32, Height: 32, BlobKey: "items/b"}
+	require.NoError(t, repo.Save(ctx, second))
+
+	got, err := repo.GetByItemID(ctx, itemID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.ContentTypePNG, got.ContentType)
+	assert.Equal(t, "items/b", got.BlobKey)
+
+	var stored int64
+	require.NoError(t, db.Model(&entities.ItemImage{}).Count(&stored).Error)
+	assert.Equal(t, int64(1), stored)
+
+	require.NoError(t, repo.Delete(ctx, itemID))
+	assert.Equal(t, entities.ErrImageNotFound, repo.Delete(ctx, itemID))
+}
+
+func TestPostgresItemImageRepository_GetTrashedBefore(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&entities.ItemImage{}))
+	repo := NewPostgresItemImageRepository(db)
+	ctx := context.Background()
+
+	longAgo := time.Now().Add(-48 * time.Hour)
+	recently := time.Now().Add(-time.Hour)
+	cutoff := time.Now().Add(-24 * time.Hour)
+
+	live := entities.NewShoppingList("Groceries", "")
+	expiredList := entities.NewShoppingList("Old", "")
+	expiredList.DeletedAt = &longAgo
+	require.NoError(t, db.Create(live).Error)
+	require.NoError(t, db.Create(expiredList).Error)
+
+	newItem := func(listID uuid.UUID, deletedAt *time.Time) *entities.Item {
+		item := entities.NewItem("Apples", 1)
+		item.ShoppingListID = listID
+		item.DeletedAt = deletedAt
+		require.NoError(t, db.Create(item).Error)
+		require.NoError(t, repo.Save(ctx, &entities.ItemImage{ItemID: item.ID, BlobKey: "items/" + item.ID.String()}))
+		return item
+	}
+	expiredItem := newItem(live.ID, &longAgo)
+	itemOfExpiredList := newItem(expiredList.ID, nil)
+	newItem(live.ID, &recently)
+	newItem(live.ID, nil)
+
+	images, err := repo.GetTrashedBefore(ctx, cutoff)
+	require.NoError(t, err)
+
+	var itemIDs []uuid.UUID
+	for _, image := range images {
+		itemIDs = append(itemIDs, image.ItemID)
+	}
+	assert.ElementsMatch(t, []uuid.UUID{expiredItem.ID, itemOfExpiredList.ID}, itemIDs)
+}
@@ -2,11 +2,13 @@ package persistence
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
 	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // PostgresItemRepository implements the ItemRepository interface
@@ -19,15 +21,17 @@ func NewPostgresItemRepository(db *gorm.DB) repositories.ItemRepository {
 	return &PostgresItemRepository{db: db}
 }
 
-// Create creates a new item
+// Create creates a new item at the end of its shopping list
 func (r *PostgresItemRepository) Create(ctx context.Context, item *entities.Item) error {
-	return r.db.WithContext(ctx).Create(item).Error
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return createItem(tx, item)
+	})
 }
 
-// GetByID retrieves an item by ID
+// GetByID retrieves an item by ID, unless it is in the trash
 func (r *PostgresItemRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Item, error) {
 	var item entities.Item
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&item).Error
+	err := conn(ctx, r.db).Scopes(notTrashed).Where("id = ?", id).First(&item).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, entities.ErrItemNotFound
@@ -37,29 +41,386 @@ func (r *PostgresItemRepository) GetByID(ctx context.Context, id uuid.UUID) (*en
 	return &item, nil
 }
 
-// GetByShoppingListID retrieves all items for a shopping list
+// GetByShoppingListID retrieves the items of a shopping list that are not in the trash, in list order
 func (r *PostgresItemRepository) GetByShoppingListID(
 	ctx context.Context,
 	shoppingListID uuid.UUID,
 ) ([]*entities.Item, error) {
 	var items []*entities.Item
-	err := r.db.WithContext(ctx).Where("shopping_list_id = ?", shoppingListID).Find(&items).Error
+	err := conn(ctx, r.db).
+		Scopes(notTrashed).
+		Where("shopping_list_id = ?", shoppingListID).
+		Order("position ASC").
+		Order("created_at ASC").
+		Find(&items).Error
 	return items, err
 }
 
-// Update updates an existing item
+// GetByShoppingListIDs retrieves the items of several shopping lists in one query,
+// grouped by shopping list and in order within each list
+func (r *PostgresItemRepository) GetByShoppingListIDs(
+	ctx context.Context,
+	shoppingListIDs []uuid.UUID,
+) ([]*entities.Item, error) {
+	var items []*entities.Item
+	if len(shoppingListIDs) == 0 {
+		return items, nil
+	}
+	err := conn(ctx, r.db).
+		Scopes(notTrashed).
+		Where("shopping_list_id IN ?", shoppingListIDs).
+		Order("shopping_list_id ASC").
+		Order("position ASC").
+		Order("created_at ASC").
+		Find(&items).Error
+	return items, err
+}
+
+// CountByShoppingListIDs counts the items and completed items of several shopping lists.
+// Lists without items are missing from the result.
+func (r *PostgresItemRepository) CountByShoppingListIDs(
+	ctx context.Context,
+	shoppingListIDs []uuid.UUID,
+) (map[uuid.UUID]entities.ItemCounts, error) {
+	counts := make(map[uuid.UUID]entities.ItemCounts, len(shoppingListIDs))
+	if len(shoppingListIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		ShoppingListID uuid.UUID
+		Total          int
+		Completed      int
+	}
+	err := conn(ctx, r.db).
+		Model(&entities.Item{}).
+		Scopes(notTrashed).
+		Select("shopping_list_id, COUNT(*) AS total, SUM(CASE WHEN completed THEN 1 ELSE 0 END) AS completed").
+		Where("shopping_list_id IN ?", shoppingListIDs).
+		Group("shopping_list_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.ShoppingListID] = entities.ItemCounts{Total: row.Total, Completed: row.Completed}
+	}
+	return counts, nil
+}
+
+// Update updates an existing item if it still has the version it was read at,
+// and bumps its version. A stale version fails with ErrVersionConflict.
 func (r *PostgresItemRepository) Update(ctx context.Context, item *entities.Item) error {
-	return r.db.WithContext(ctx).Save(item).Error
+	return updateItem(conn(ctx, r.db), item)
 }
 
-// Delete deletes an item
+// Delete moves an item to the trash and shifts the items after it up one position
 func (r *PostgresItemRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&entities.Item{})
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return trashItem(tx, id)
+	})
+}
+
+// ApplyBatch stores the changes of an item batch in a single transaction.
+// Updates are applied first, then deletions, and new items are appended last;
+// if any change fails, none is stored.
+func (r *PostgresItemRepository) ApplyBatch(ctx context.Context, batch repositories.ItemBatch) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for _, item := range batch.Update {
+			if err := updateItem(tx, item); err != nil {
+				return err
+			}
+		}
+		for _, id := range batch.Delete {
+			if err := trashItem(tx, id); err != nil {
+				return err
+			}
+		}
+		if len(batch.Delete) > 0 {
+			if err := refreshPositions(tx, batch.Update); err != nil {
+				return err
+			}
+		}
+		for _, item := range batch.Create {
+			if err := createItem(tx, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Reorder rewrites the positions of a shopping list's items to follow the given order,
+// bumping the version of the items that move
+func (r *PostgresItemRepository) Reorder(ctx context.Context, shoppingListID uuid.UUID, itemIDs []uuid.UUID) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for position, id := range itemIDs {
+			result := tx.Model(&entities.Item{}).
+				Scopes(notTrashed).
+				Where("id = ? AND shopping_list_id = ?", id, shoppingListID).
+				UpdateColumns(map[string]interface{}{
+					"position": position,
+					"version":  gorm.Expr("CASE WHEN position <> ? THEN version + 1 ELSE version END", position),
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return entities.ErrItemNotFound
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteCompleted moves the completed items of a shopping list to the trash and closes
+// the gaps they leave in the order. It returns the number of trashed items.
+func (r *PostgresItemRepository) DeleteCompleted(ctx context.Context, shoppingListID uuid.UUID) (int64, error) {
+	var trashedItems int64
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.Item{}).
+			Scopes(notTrashed).
+			Where("shopping_list_id = ? AND completed = ?", shoppingListID, true).
+			UpdateColumn("deleted_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		trashedItems = result.RowsAffected
+		if trashedItems == 0 {
+			return nil
+		}
+		return renumberItems(tx, shoppingListID)
+	})
+	return trashedItems, err
+}
+
+// SetCompleted marks every item of a shopping list as completed or not completed,
+// bumping the version of the items it changes. It returns the number of changed items.
+func (r *PostgresItemRepository) SetCompleted(ctx context.Context, shoppingListID uuid.UUID, completed bool) (int64, error) {
+	result := conn(ctx, r.db).
+		Model(&entities.Item{}).
+		Scopes(notTrashed).
+		Where("shopping_list_id = ? AND completed <> ?", shoppingListID, completed).
+		UpdateColumns(map[string]interface{}{
+			"completed":  completed,
+			"version":    gorm.Expr("version + 1"),
+			"updated_at": time.Now(),
+		})
+	return result.RowsAffected, result.Error
+}
+
+// GetTrashed retrieves the items deleted on their own from the lists a user owns or is a member of,
+// most recently deleted first. Items of shopping lists in the trash are left out, as they come back with their list.
+func (r *PostgresItemRepository) GetTrashed(ctx context.Context, userID *uuid.UUID) ([]*entities.Item, error) {
+	liveLists := conn(ctx, r.db).Model(&entities.ShoppingList{}).Scopes(notTrashed, accessibleTo(userID)).Select("id")
+
+	var items []*entities.Item
+	err := conn(ctx, r.db).
+		Scopes(trashed).
+		Where("shopping_list_id IN (?)", liveLists).
+		Order("deleted_at DESC").
+		Find(&items).Error
+	return items, err
+}
+
+// GetTrashedByID retrieves an item in the trash by ID
+func (r *PostgresItemRepository) GetTrashedByID(ctx context.Context, id uuid.UUID) (*entities.Item, error) {
+	var item entities.Item
+	err := conn(ctx, r.db).Scopes(trashed).Where("id = ?", id).First(&item).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entities.ErrItemNotFound
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+// Restore takes an item out of the trash and appends it to the end of its shopping list.
+// Items of a shopping list in the trash cannot be restored on their own.
+func (r *PostgresItemRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var item entities.Item
+		err := tx.Scopes(trashed).Where("id = ?", id).First(&item).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return entities.ErrItemNotFound
+			}
+			return err
+		}
+
+		var lists int64
+		err = tx.Model(&entities.ShoppingList{}).
+			Scopes(notTrashed).
+			Where("id = ?", item.ShoppingListID).
+			Count(&lists).Error
+		if err != nil {
+			return err
+		}
+		if lists == 0 {
+			return entities.ErrShoppingListNotFound
+		}
+
+		lastPosition, err := lastPosition(tx, item.ShoppingListID)
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&entities.Item{}).
+			Where("id = ?", id).
+			UpdateColumns(map[string]interface{}{"deleted_at": nil, "position": lastPosition + 1}).Error
+	})
+}
+
+// PurgeTrashed permanently deletes the items moved to the trash before the given time.
+// It returns the number of purged items.
+func (r *PostgresItemRepository) PurgeTrashed(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	result := conn(ctx, r.db).Where("deleted_at < ?", deletedBefore).Delete(&entities.Item{})
+	return result.RowsAffected, result.Error
+}
+
+// createItem inserts an item at the end of its shopping list
+func createItem(tx *gorm.DB, item *entities.Item) error {
+	lastPosition, err := lastPosition(tx, item.ShoppingListID)
+	if err != nil {
+		return err
+	}
+
+	item.Position = lastPosition + 1
+	if item.Version == 0 {
+		item.Version = 1
+	}
+	return tx.Create(item).Error
+}
+
+// updateItem stores an item if it still has the version it was read at, and bumps its version.
+// The position is left alone: it only changes through the operations that reorder the whole list.
+func updateItem(tx *gorm.DB, item *entities.Item) error {
+	version := item.Version
+	item.Version = version + 1
+
+	result := tx.Model(item).
+		Scopes(notTrashed).
+		Where("version = ?", version).
+		Select("*").
+		Omit("created_at", "position").
+		Updates(item)
+	if result.Error != nil {
+		item.Version = version
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		item.Version = version
+		return itemUpdateFailure(tx, item.ID)
+	}
+	return nil
+}
+
+// itemUpdateFailure explains why a conditional item update matched no rows
+func itemUpdateFailure(tx *gorm.DB, id uuid.UUID) error {
+	var count int64
+	err := tx.Model(&entities.Item{}).Scopes(notTrashed).Where("id = ?", id).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return entities.ErrItemNotFound
+	}
+	return entities.ErrVersionConflict
+}
+
+// trashItem moves an item to the trash and shifts the items after it up one position,
+// bumping their versions
+func trashItem(tx *gorm.DB, id uuid.UUID) error {
+	var item entities.Item
+	err := tx.Scopes(notTrashed).Where("id = ?", id).First(&item).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return entities.ErrItemNotFound
+		}
+		return err
+	}
+
+	result := tx.Model(&entities.Item{}).
+		Scopes(notTrashed).
+		Where("id = ?", id).
+		UpdateColumn("deleted_at", time.Now())
 	if result.Error != nil {
 		return result.Error
 	}
 	if result.RowsAffected == 0 {
 		return entities.ErrItemNotFound
 	}
+
+	return tx.Model(&entities.Item{}).
+		Scopes(notTrashed).
+		Where("shopping_list_id = ? AND position > ?", item.ShoppingListID, item.Position).
+		UpdateColumns(map[string]interface{}{
+			"position": gorm.Expr("position - 1"),
+			"version":  gorm.Expr("version + 1"),
+		}).Error
+}
+
+// refreshPositions reloads the positions and versions of items that other changes may have moved
+func refreshPositions(tx *gorm.DB, items []*entities.Item) error {
+	if len(items) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+
+	var current []entities.Item
+	if err := tx.Select("id", "position", "version").Where("id IN ?", ids).Find(&current).Error; err != nil {
+		return err
+	}
+	byID := make(map[uuid.UUID]entities.Item, len(current))
+	for _, item := range current {
+		byID[item.ID] = item
+	}
+	for _, item := range items {
+		if stored, ok := byID[item.ID]; ok {
+			item.Position = stored.Position
+			item.Version = stored.Version
+		}
+	}
 	return nil
 }
+
+// renumberItems closes the gaps in the positions of a shopping list's live items in a single statement,
+// bumping the versions of the items that move
+func renumberItems(tx *gorm.DB, shoppingListID uuid.UUID) error {
+	return tx.Exec(`
+		UPDATE items SET position = ranked.new_position, version = items.version + 1
+		FROM (
+			SELECT id, ROW_NUMBER() OVER (ORDER BY position, created_at) - 1 AS new_position
+			FROM items
+			WHERE shopping_list_id = ? AND deleted_at IS NULL
+		) AS ranked
+		WHERE items.id = ranked.id AND items.position <> ranked.new_position`,
+		shoppingListID).Error
+}
+
+// lastPosition returns the highest position in use by the live items of a shopping list, or -1.
+// It locks the shopping list until the transaction ends, so that concurrent appends to the same
+// list wait for each other instead of taking the same position.
+func lastPosition(tx *gorm.DB, shoppingListID uuid.UUID) (int, error) {
+	var locked []uuid.UUID
+	err := tx.Model(&entities.ShoppingList{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", shoppingListID).
+		Pluck("id", &locked).Error
+	if err != nil {
+		return 0, err
+	}
+
+	var position int
+	err = tx.Model(&entities.Item{}).
+		Scopes(notTrashed).
+		Where("shopping_list_id = ?", shoppingListID).
+		Select("COALESCE(MAX(position), -1)").
+		Scan(&position).Error
+	return position, err
+}
@@ -3,11 +3,13 @@ package persistence
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
@@ -16,7 +18,7 @@ func setupTestDBForItems(t *testing.T) (*gorm.DB, *entities.ShoppingList) {
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	require.NoError(t, err)
 
-	err = db.AutoMigrate(&entities.ShoppingList{}, &entities.Item{})
+	err = db.AutoMigrate(&entities.ShoppingList{}, &entities.Item{}, &entities.ListMember{}, &entities.ListInvitation{})
 	require.NoError(t, err)
 
 	// Create a test shopping list for items
@@ -200,6 +202,56 @@ func TestPostgresItemRepository_GetByShoppingListID(t *testing.T) {
 	assert.False(t, gotIDs[anotherItem.ID], "Item from another list should not be included")
 }
 
+func TestPostgresItemRepository_GetAndCountByShoppingListIDs(t *testing.T) {
+	db, testList := setupTestDBForItems(t)
+	repo := NewPostgresItemRepository(db)
+	ctx := context.Background()
+
+	otherList := entities.NewShoppingList("Other List", "")
+	emptyList := entities.NewShoppingList("Empty List", "")
+	require.NoError(t, db.Create(otherList).Error)
+	require.NoError(t, db.Create(emptyList).Error)
+
+	for _, item := range []*entities.Item{
+		{ID: uuid.New(), ShoppingListID: testList.ID, Name: "Milk", Quantity: 1},
+		{ID: uuid.New(), ShoppingListID: testList.ID, Name: "Bread", Quantity: 1, Completed: true},
+		{ID: uuid.New(), ShoppingListID: otherList.ID, Name: "Nails", Quantity: 1},
+	} {
+		require.NoError(t, repo.Create(ctx, item))
+	}
+	trashed := &entities.Item{ID: uuid.New(), ShoppingListID: testList.ID, Name: "Eggs", Quantity: 1}
+	require.NoError(t, repo.Create(ctx, trashed))
+	require.NoError(t, repo.Delete(ctx, trashed.ID))
+
+	ids := []uuid.UUID{testList.ID, otherList.ID, emptyList.ID}
+
+	items, err := repo.GetByShoppingListIDs(ctx, ids)
+	require.NoError(t, err)
+	names := make(map[uuid.UUID][]string)
+	for _, item := range items {
+		names[item.ShoppingListID] = append(names[item.ShoppingListID], item.Name)
+	}
+	assert.Equal(t, map[uuid.UUID][]string{
+		testList.ID:  {"Milk", "Bread"},
+		otherList.ID: {"Nails"},
+	}, names)
+
+	counts, err := repo.CountByShoppingListIDs(ctx, ids)
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]entities.ItemCounts{
+		testList.ID:  {Total: 2, Completed: 1},
+		otherList.ID: {Total: 1, Completed: 0},
+	}, counts)
+
+	items, err = repo.GetByShoppingListIDs(ctx, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, items)
+
+	counts, err = repo.CountByShoppingListIDs(ctx, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, counts)
+}
+
 func TestPostgresItemRepository_Update(t *testing.T) {
 	db, testList := setupTestDBForItems(t)
 	repo := NewPostgresItemRepository(db)
@@ -228,10 +280,75 @@ func TestPostgresItemRepository_Update(t *testing.T) {
 	updated, err := repo.GetByID(ctx, testItem.ID)
 	assert.NoError(t, err)
 	assert.Equal(t, "Updated Name", updated.Name)
-	assert.Equal(t, 5, updated.Quantity)
+	assert.Equal(t, 5.0, updated.Quantity)
 	assert.Equal(t, true, updated.Completed)
 }
 
+func TestPostgresItemRepository_UpdateVersioning(t *testing.T) {
+	db, testList := setupTestDBForItems(t)
+	repo := NewPostgresItemRepository(db)
+	ctx := context.Background()
+
+	testItem := entities.NewItem("Milk", 1)
+	testItem.ShoppingListID = testList.ID
+	require.NoError(t, repo.Create(ctx, testItem))
+
+	stale, err := repo.GetByID(ctx, testItem.ID)
+	require.NoError(t, err)
+
+	testItem.Quantity = 2
+	require.NoError(t, repo.Update(ctx, testItem))
+	assert.Equal(t, 2, testItem.Version)
+
+	stale.Completed = true
+	err = repo.Update(ctx, stale)
+	assert.Equal(t, entities.ErrVersionConflict, err)
+	assert.Equal(t, 1, stale.Version)
+
+	stored, err := repo.GetByID(ctx, testItem.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stored.Version)
+	assert.Equal(t, 2.0, stored.Quantity)
+	assert.False(t, stored.Completed)
+
+	missing := entities.NewItem("Ghost", 1)
+	assert.Equal(t, entities.ErrItemNotFound, repo.Update(ctx, missing))
+}
+
+func TestPostgresItemRepository_QuantityAndUnit(t *testing.T) {
+	db, testList := setupTestDBForItems(t)
+	repo := NewPostgresItemRepository(db)
+	ctx := context.Background()
+
+	flour := &entities.Item{
+		ID:             uuid.New(),
+		ShoppingListID: testList.ID,
+		Name:           "Flour",
+		Quantity:       1.5,
+		Unit:           entities.UnitKilogram,
+	}
+	require.NoError(t, repo.Create(ctx, flour))
+
+	got, err := repo.GetByID(ctx, flour.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1.5, got.Quantity)
+	assert.Equal(t, entities.UnitKilogram, got.Unit)
+
+	// Items stored without a unit fall back to pieces
+	legacy := &entities.Item{
+		ID:             uuid.New(),
+		ShoppingListID: testList.ID,
+		Name:           "Eggs",
+		Quantity:       12,
+	}
+	require.NoError(t, repo.Create(ctx, legacy))
+
+	got, err = repo.GetByID(ctx, legacy.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 12.0, got.Quantity)
+	assert.Equal(t, entities.UnitPieces, got.Unit)
+}
+
 func TestPostgresItemRepository_Delete(t *testing.T) {
 	db, testList := setupTestDBForItems(t)
 	repo := NewPostgresItemRepository(db)
@@ -309,15 +426,147 @@ func TestPostgresItemRepository_CascadeDelete(t *testing.T) {
 	err := itemRepo.Create(ctx, testItem)
 	require.NoError(t, err)
 
-	// Delete the shopping list (should cascade delete items)
+	// Delete the shopping list (moves it to the trash, items included)
 	err = listRepo.Delete(ctx, testList.ID)
 	assert.NoError(t, err)
 
-	// Note: SQLite in-memory doesn't enforce foreign key constraints by default
-	// In a real PostgreSQL environment, this would cascade delete
-	// For this test, we'll verify the list is deleted
 	_, err = listRepo.GetByID(ctx, testList.ID)
 	assert.Equal(t, entities.ErrShoppingListNotFound, err)
+
+	// Purging the list removes its items for good
+	purged, err := listRepo.PurgeTrashed(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	var remaining int64
+	require.NoError(t, db.Model(&entities.Item{}).Where("id = ?", testItem.ID).Count(&remaining).Error)
+	assert.Zero(t, remaining)
+}
+
+func TestPostgresItemRepository_TrashAndRestore(t *testing.T) {
+	db, testList := setupTestDBForItems(t)
+	repo := NewPostgresItemRepository(db)
+	ctx := context.Background()
+
+	var items []*entities.Item
+	for _, name := range []string{"Milk", "Bread", "Eggs"} {
+		item := entities.NewItem(name, 1)
+		item.ShoppingListID = testList.ID
+		require.NoError(t, repo.Create(ctx, item))
+		items = append(items, item)
+	}
+
+	require.NoError(t, repo.Delete(ctx, items[0].ID))
+
+	_, err := repo.GetByID(ctx, items[0].ID)
+	assert.Equal(t, entities.ErrItemNotFound, err)
+	assert.Equal(t, entities.ErrItemNotFound, repo.Delete(ctx, items[0].ID))
+
+	live, err := repo.GetByShoppingListID(ctx, testList.ID)
+	require.NoError(t, err)
+	require.Len(t, live, 2)
+	assert.Equal(t, 0, live[0].Position)
+
+	trash, err := repo.GetTrashed(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+	assert.Equal(t, items[0].ID, trash[0].ID)
+	assert.NotNil(t, trash[0].DeletedAt)
+
+	require.NoError(t, repo.Restore(ctx, items[0].ID))
+	assert.Equal(t, entities.ErrItemNotFound, repo.Restore(ctx, items[0].ID))
+	_, err = repo.GetTrashedByID(ctx, items[0].ID)
+	assert.Equal(t, entities.ErrItemNotFound, err)
+
+	restored, err := repo.GetByID(ctx, items[0].ID)
+	require.NoError(t, err)
+	assert.Nil(t, restored.DeletedAt)
+	assert.Equal(t, 2, restored.Position, "restored items are appended to the list")
+}
+
+func TestPostgresItemRepository_GetTrashed_SharedLists(t *testing.T) {
+	db, _ := setupTestDBForItems(t)
+	repo := NewPostgresItemRepository(db)
+	listRepo := NewPostgresShoppingListRepository(db)
+	memberRepo := NewPostgresListMemberRepository(db)
+	ctx := context.Background()
+
+	owner, editor, stranger := uuid.New(), uuid.New(), uuid.New()
+	list := entities.NewShoppingList("Groceries", "")
+	list.OwnerID = &owner
+	require.NoError(t, listRepo.Create(ctx, list))
+	require.NoError(t, memberRepo.Create(ctx, entities.NewListMember(list.ID, editor, entities.ListRoleEditor)))
+
+	item := entities.NewItem("Milk", 1)
+	item.ShoppingListID = list.ID
+	require.NoError(t, repo.Create(ctx, item))
+	require.NoError(t, repo.Delete(ctx, item.ID))
+
+	for _, userID := range []uuid.UUID{owner, editor} {
+		trash, err := repo.GetTrashed(ctx, &userID)
+		require.NoError(t, err)
+		require.Len(t, trash, 1)
+		assert.Equal(t, item.ID, trash[0].ID)
+	}
+
+	trash, err := repo.GetTrashed(ctx, &stranger)
+	require.NoError(t, err)
+	assert.Empty(t, trash)
+}
+
+func TestPostgresItemRepository_RestoreFromTrashedList(t *testing.T) {
+	db, testList := setupTestDBForItems(t)
+	repo := NewPostgresItemRepository(db)
+	listRepo := NewPostgresShoppingListRepository(db)
+	ctx := context.Background()
+
+	item := entities.NewItem("Milk", 1)
+	item.ShoppingListID = testList.ID
+	require.NoError(t, repo.Create(ctx, item))
+	require.NoError(t, repo.Delete(ctx, item.ID))
+	require.NoError(t, listRepo.Delete(ctx, testList.ID))
+
+	trash, err := repo.GetTrashed(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, trash, "items of trashed lists come back with their list")
+
+	trashedItem, err := repo.GetTrashedByID(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, testList.ID, trashedItem.ShoppingListID)
+	trashedList, err := listRepo.GetTrashedByID(ctx, testList.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, trashedList.DeletedAt)
+	_, err = listRepo.GetByID(ctx, testList.ID)
+	assert.Equal(t, entities.ErrShoppingListNotFound, err)
+
+	assert.Equal(t, entities.ErrShoppingListNotFound, repo.Restore(ctx, item.ID))
+}
+
+func TestPostgresItemRepository_PurgeTrashed(t *testing.T) {
+	db, testList := setupTestDBForItems(t)
+	repo := NewPostgresItemRepository(db)
+	ctx := context.Background()
+
+	expired := entities.NewItem("Milk", 1)
+	expired.ShoppingListID = testList.ID
+	recent := entities.NewItem("Bread", 1)
+	recent.ShoppingListID = testList.ID
+	require.NoError(t, repo.Create(ctx, expired))
+	require.NoError(t, repo.Create(ctx, recent))
+	require.NoError(t, repo.Delete(ctx, expired.ID))
+	require.NoError(t, repo.Delete(ctx, recent.ID))
+
+	longAgo := time.Now().Add(-48 * time.Hour)
+	require.NoError(t, db.Model(&entities.Item{}).Where("id = ?", expired.ID).UpdateColumn("deleted_at", longAgo).Error)
+
+	purged, err := repo.PurgeTrashed(ctx, time.Now().Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	trash, err := repo.GetTrashed(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+	assert.Equal(t, recent.ID, trash[0].ID)
 }
 
 func TestPostgresItemRepository_GetByID_DatabaseError(t *testing.T) {
@@ -353,3 +602,250 @@ func TestPostgresItemRepository_Delete_DatabaseError(t *testing.T) {
 	assert.Error(t, err)
 	assert.NotEqual(t, entities.ErrItemNotFound, err)
 }
+
+func TestPostgresItemRepository_Ordering(t *testing.T) {
+	db, testList := setupTestDBForItems(t)
+	repo := NewPostgresItemRepository(db)
+	ctx := context.Background()
+
+	names := []string{"Milk", "Bread", "Eggs", "Butter"}
+	items := make([]*entities.Item, len(names))
+	for i, name := range names {
+		items[i] = entities.NewItem(name, 1)
+		items[i].ShoppingListID = testList.ID
+		require.NoError(t, repo.Create(ctx, items[i]))
+		assert.Equal(t, i, items[i].Position, "new items go to the end")
+	}
+
+	// Reorder: Butter, Milk, Eggs, Bread
+	order := []uuid.UUID{items[3].ID, items[0].ID, items[2].ID, items[1].ID}
+	require.NoError(t, repo.Reorder(ctx, testList.ID, order))
+
+	got, err := repo.GetByShoppingListID(ctx, testList.ID)
+	require.NoError(t, err)
+	require.Len(t, got, 4)
+	for i, item := range got {
+		assert.Equal(t, order[i], item.ID)
+		assert.Equal(t, i, item.Position)
+	}
+
+	// Moved items get a new version, so that their ETags change; Eggs kept its place
+	versions := make(map[string]int)
+	for _, item := range got {
+		versions[item.Name] = item.Version
+	}
+	assert.Equal(t, map[string]int{"Butter": 2, "Milk": 2, "Eggs": 1, "Bread": 2}, versions)
+
+	// Deleting closes the gap
+	require.NoError(t, repo.Delete(ctx, items[0].ID))
+
+	got, err = repo.GetByShoppingListID(ctx, testList.ID)
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	assert.Equal(t, []string{"Butter", "Eggs", "Bread"}, []string{got[0].Name, got[1].Name, got[2].Name})
+	for i, item := range got {
+		assert.Equal(t, i, item.Position)
+	}
+
+	// Items added after a delete still go to the end
+	jam := entities.NewItem("Jam", 1)
+	jam.ShoppingListID = testList.ID
+	require.NoError(t, repo.Create(ctx, jam))
+	assert.Equal(t, 3, jam.Position)
+}
+
+func TestPostgresItemRepository_ApplyBatch(t *testing.T) {
+	db, testList := setupTestDBForItems(t)
+	repo := NewPostgresItemRepository(db)
+	ctx := context.Background()
+
+	milk := &entities.Item{ID: uuid.New(), ShoppingListID: testList.ID, Name: "Milk", Quantity: 1}
+	bread := &entities.Item{ID: uuid.New(), ShoppingListID: testList.ID, Name: "Bread", Quantity: 1}
+	eggs := &entities.Item{ID: uuid.New(), ShoppingListID: testList.ID, Name: "Eggs", Quantity: 6}
+	for _, item := range []*entities.Item{milk, bread, eggs} {
+		require.NoError(t, repo.Create(ctx, item))
+	}
+
+	t.Run("stores every change", func(t *testing.T) {
+		bread.MarkCompleted()
+		butter := &entities.Item{ID: uuid.New(), ShoppingListID: testList.ID, Name: "Butter", Quantity: 1}
+
+		err := repo.ApplyBatch(ctx, repositories.ItemBatch{
+			Create: []*entities.Item{butter},
+			Update: []*entities.Item{bread},
+			Delete: []uuid.UUID{milk.ID},
+		})
+		require.NoError(t, err)
+
+		items, err := repo.GetByShoppingListID(ctx, testList.ID)
+		require.NoError(t, err)
+		require.Len(t, items, 3)
+		for position, name := range []string{"Bread", "Eggs", "Butter"} {
+			assert.Equal(t, name, items[position].Name)
+			assert.Equal(t, position, items[position].Position)
+		}
+		assert.True(t, items[0].Completed)
+		// Completed, then moved up by the deletion of milk
+		assert.Equal(t, 3, items[0].Version)
+		assert.Equal(t, items[0].Version, bread.Version)
+		assert.Equal(t, 0, bread.Position)
+	})
+
+	t.Run("stores nothing when a change fails", func(t *testing.T) {
+		stale := *eggs
+		stale.Version = 7
+		stale.Name = "Free-range Eggs"
+
+		err := repo.ApplyBatch(ctx, repositories.ItemBatch{
+			Create: []*entities.Item{{ID: uuid.New(), ShoppingListID: testList.ID, Name: "Jam", Quantity: 1}},
+			Update: []*entities.Item{&stale},
+			Delete: []uuid.UUID{bread.ID},
+		})
+		assert.Equal(t, entities.ErrVersionConflict, err)
+
+		items, err := repo.GetByShoppingListID(ctx, testList.ID)
+		require.NoError(t, err)
+		require.Len(t, items, 3)
+		assert.Equal(t, "Eggs", items[1].Name)
+	})
+}
+
+func TestPostgresItemRepository_UpdateAfterConcurrentDelete(t *testing.T) {
+	db, testList := setupTestDBForItems(t)
+	repo := NewPostgresItemRepository(db)
+	ctx := context.Background()
+
+	milk := &entities.Item{ID: uuid.New(), ShoppingListID: testList.ID, Name: "Milk", Quantity: 1}
+	bread := &entities.Item{ID: uuid.New(), ShoppingListID: testList.ID, Name: "Bread", Quantity: 1}
+	eggs := &entities.Item{ID: uuid.New(), ShoppingListID: testList.ID, Name: "Eggs", Quantity: 6}
+	for _, item := range []*entities.Item{milk, bread, eggs} {
+		require.NoError(t, repo.Create(ctx, item))
+	}
+
+	// A client reads bread, then another one deletes milk before the first one writes bread back
+	read, err := repo.GetByID(ctx, bread.ID)
+	require.NoError(t, err)
+	require.NoError(t, repo.Delete(ctx, milk.ID))
+
+	read.Quantity = 2
+	assert.Equal(t, entities.ErrVersionConflict, repo.Update(ctx, read))
+
+	got, err := repo.GetByID(ctx, bread.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, got.Position)
+	assert.Equal(t, 2, got.Version)
+
+	// Even a write that passes the version check leaves the position alone
+	got.Position = 5
+	got.Quantity = 2
+	require.NoError(t, repo.Update(ctx, got))
+	items, err := repo.GetByShoppingListID(ctx, testList.ID)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "Bread", items[0].Name)
+	assert.Equal(t, 0, items[0].Position)
+	assert.Equal(t, 2.0, items[0].Quantity)
+	assert.Equal(t, 1, items[1].Position)
+}
+
+func TestPostgresItemRepository_DeleteCompletedBumpsMovedVersions(t *testing.T) {
+	db, testList := setupTestDBForItems(t)
+	repo := NewPostgresItemRepository(db)
+	ctx := context.Background()
+
+	milk := &entities.Item{ID: uuid.New(), ShoppingListID: testList.ID, Name: "Milk", Quantity: 1, Completed: true}
+	bread := &entities.Item{ID: uuid.New(), ShoppingListID: testList.ID, Name: "Bread", Quantity: 1}
+	for _, item := range []*entities.Item{milk, bread} {
+		require.NoError(t, repo.Create(ctx, item))
+	}
+
+	_, err := repo.DeleteCompleted(ctx, testList.ID)
+	require.NoError(t, err)
+
+	got, err := repo.GetByID(ctx, bread.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, got.Position)
+	assert.Equal(t, 2, got.Version)
+}
+
+func TestPostgresItemRepository_ReorderRejectsForeignItems(t *testing.T) {
+	db, testList := setupTestDBForItems(t)
+	repo := NewPostgresItemRepository(db)
+	ctx := context.Background()
+
+	item := entities.NewItem("Milk", 1)
+	item.ShoppingListID = testList.ID
+	require.NoError(t, repo.Create(ctx, item))
+
+	err := repo.Reorder(ctx, testList.ID, []uuid.UUID{uuid.New(), item.ID})
+	assert.Equal(t, entities.ErrItemNotFound, err)
+
+	// The transaction was rolled back
+	got, err := repo.GetByID(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, got.Position)
+}
+
+func TestPostgresItemRepository_ListActions(t *testing.T) {
+	db, testList := setupTestDBForItems(t)
+	repo := NewPostgresItemRepository(db)
+	ctx := context.Background()
+
+	names := []string{"Milk", "Bread", "Eggs", "Butter"}
+	items := make([]*entities.Item, len(names))
+	for i, name := range names {
+		items[i] = &entities.Item{ID: uuid.New(), ShoppingListID: testList.ID, Name: name, Quantity: 1}
+		require.NoError(t, repo.Create(ctx, items[i]))
+	}
+	other := &entities.Item{ID: uuid.New(), ShoppingListID: uuid.New(), Name: "Nails", Quantity: 1}
+	require.NoError(t, db.Create(other).Error)
+
+	for _, item := range []*entities.Item{items[0], items[2]} {
+		item.MarkCompleted()
+		require.NoError(t, repo.Update(ctx, item))
+	}
+
+	completed, err := repo.SetCompleted(ctx, testList.ID, true)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), completed)
+
+	got, err := repo.GetByID(ctx, items[1].ID)
+	require.NoError(t, err)
+	assert.True(t, got.Completed)
+	assert.Equal(t, 2, got.Version)
+
+	reset, err := repo.SetCompleted(ctx, testList.ID, false)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), reset)
+
+	got, err = repo.GetByID(ctx, other.ID)
+	require.NoError(t, err)
+	assert.False(t, got.Completed)
+
+	for _, item := range []*entities.Item{items[0], items[2]} {
+		stored, err := repo.GetByID(ctx, item.ID)
+		require.NoError(t, err)
+		stored.MarkCompleted()
+		require.NoError(t, repo.Update(ctx, stored))
+	}
+
+	cleared, err := repo.DeleteCompleted(ctx, testList.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), cleared)
+
+	remaining, err := repo.GetByShoppingListID(ctx, testList.ID)
+	require.NoError(t, err)
+	require.Len(t, remaining, 2)
+	assert.Equal(t, "Bread", remaining[0].Name)
+	assert.Equal(t, 0, remaining[0].Position)
+	assert.Equal(t, "Butter", remaining[1].Name)
+	assert.Equal(t, 1, remaining[1].Position)
+
+	trashed, err := repo.GetTrashed(ctx, nil)
+	require.NoError(t, err)
+	assert.Len(t, trashed, 2)
+
+	cleared, err = repo.DeleteCompleted(ctx, testList.ID)
+	require.NoError(t, err)
+	assert.Zero(t, cleared)
+}
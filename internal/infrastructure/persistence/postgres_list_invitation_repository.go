@@ -0,0 +1,98 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+// PostgresListInvitationRepository implements the ListInvitationRepository interface
+type PostgresListInvitationRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresListInvitationRepository creates a new PostgreSQL list invitation repository
+func NewPostgresListInvitationRepository(db *gorm.DB) repositories.ListInvitationRepository {
+	return &PostgresListInvitationRepository{db: db}
+}
+
+// Create creates a new invitation
+func (r *PostgresListInvitationRepository) Create(ctx context.Context, invitation *entities.ListInvitation) error {
+	return conn(ctx, r.db).Create(invitation).Error
+}
+
+// GetByID retrieves an invitation by ID
+func (r *PostgresListInvitationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.ListInvitation, error) {
+	return r.getWhere(ctx, "id = ?", id)
+}
+
+// GetByHash retrieves the invitation with a token hash
+func (r *PostgresListInvitationRepository) GetByHash(ctx context.Context, tokenHash string) (*entities.ListInvitation, error) {
+	return r.getWhere(ctx, "token_hash = ?", tokenHash)
+}
+
+// GetOutstanding retrieves the invitations of a shopping list that can still be accepted, newest first
+func (r *PostgresListInvitationRepository) GetOutstanding(
+	ctx context.Context,
+	shoppingListID uuid.UUID,
+	now time.Time,
+) ([]*entities.ListInvitation, error) {
+	var invitations []*entities.ListInvitation
+	err := conn(ctx, r.db).
+		Scopes(usableAt(now)).
+		Where("shopping_list_id = ?", shoppingListID).
+		Order("created_at DESC, id").
+		Find(&invitations).Error
+	return invitations, err
+}
+
+// Redeem counts a use of an invitation, unless it has expired or has no uses left.
+// The check and the count happen in one statement, so that concurrent redemptions
+// cannot exceed the allowed uses.
+func (r *PostgresListInvitationRepository) Redeem(ctx context.Context, id uuid.UUID, now time.Time) error {
+	result := conn(ctx, r.db).
+		Model(&entities.ListInvitation{}).
+		Scopes(usableAt(now)).
+		Where("id = ?", id).
+		UpdateColumn("uses", gorm.Expr("uses + 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrInvitationExpired
+	}
+	return nil
+}
+
+// Delete deletes an invitation
+func (r *PostgresListInvitationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := conn(ctx, r.db).Where("id = ?", id).Delete(&entities.ListInvitation{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrInvitationNotFound
+	}
+	return nil
+}
+
+// getWhere retrieves the invitation matching a condition
+func (r *PostgresListInvitationRepository) getWhere(
+	ctx context.Context,
+	condition string,
+	value interface{},
+) (*entities.ListInvitation, error) {
+	var invitation entities.ListInvitation
+	err := conn(ctx, r.db).Where(condition, value).First(&invitation).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entities.ErrInvitationNotFound
+		}
+		return nil, err
+	}
+	return &invitation, nil
+}
@@ -0,0 +1,59 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+func TestPostgresListInvitationRepository(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPostgresListInvitationRepository(db)
+	ctx := context.Background()
+
+	now := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+	listID := uuid.New()
+	once := 1
+	single, token, err := entities.NewListInvitation(listID, entities.ListRoleEditor, now.Add(time.Hour), &once)
+	require.NoError(t, err)
+	require.NoError(t, repo.Create(ctx, single))
+	multi, _, err := entities.NewListInvitation(listID, entities.ListRoleViewer, now.Add(time.Hour), nil)
+	require.NoError(t, err)
+	require.NoError(t, repo.Create(ctx, multi))
+	expired, _, err := entities.NewListInvitation(listID, entities.ListRoleViewer, now.Add(-time.Minute), nil)
+	require.NoError(t, err)
+	require.NoError(t, repo.Create(ctx, expired))
+
+	got, err := repo.GetByHash(ctx, entities.HashInvitationToken(token))
+	require.NoError(t, err)
+	assert.Equal(t, single.ID, got.ID)
+
+	outstanding, err := repo.GetOutstanding(ctx, listID, now)
+	require.NoError(t, err)
+	assert.Len(t, outstanding, 2)
+
+	require.NoError(t, repo.Redeem(ctx, single.ID, now))
+	assert.Equal(t, entities.ErrInvitationExpired, repo.Redeem(ctx, single.ID, now))
+	assert.Equal(t, entities.ErrInvitationExpired, repo.Redeem(ctx, expired.ID, now))
+	require.NoError(t, repo.Redeem(ctx, multi.ID, now))
+	require.NoError(t, repo.Redeem(ctx, multi.ID, now))
+
+	got, err = repo.GetByID(ctx, multi.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.Uses)
+
+	outstanding, err = repo.GetOutstanding(ctx, listID, now)
+	require.NoError(t, err)
+	require.Len(t, outstanding, 1)
+	assert.Equal(t, multi.ID, outstanding[0].ID)
+
+	require.NoError(t, repo.Delete(ctx, multi.ID))
+	_, err = repo.GetByID(ctx, multi.ID)
+	assert.Equal(t, entities.ErrInvitationNotFound, err)
+	assert.Equal(t, entities.ErrInvitationNotFound, repo.Delete(ctx, multi.ID))
+}
@@ -0,0 +1,84 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+// PostgresListMemberRepository implements the ListMemberRepository interface
+type PostgresListMemberRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresListMemberRepository creates a new PostgreSQL list member repository
+func NewPostgresListMemberRepository(db *gorm.DB) repositories.ListMemberRepository {
+	return &PostgresListMemberRepository{db: db}
+}
+
+// Create adds a member to a shopping list; a user who is already a member fails with ErrDuplicateMember
+func (r *PostgresListMemberRepository) Create(ctx context.Context, member *entities.ListMember) error {
+	err := conn(ctx, r.db).Create(member).Error
+	if err != nil && isDuplicateKey(r.db, err) {
+		return entities.ErrDuplicateMember
+	}
+	return err
+}
+
+// Get retrieves the membership of a user in a shopping list
+func (r *PostgresListMemberRepository) Get(ctx context.Context, shoppingListID, userID uuid.UUID) (*entities.ListMember, error) {
+	var member entities.ListMember
+	err := conn(ctx, r.db).Where("shopping_list_id = ? AND user_id = ?", shoppingListID, userID).First(&member).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entities.ErrMemberNotFound
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+// GetByShoppingListID retrieves the members of a shopping list in the order they joined
+func (r *PostgresListMemberRepository) GetByShoppingListID(
+	ctx context.Context,
+	shoppingListID uuid.UUID,
+) ([]*entities.ListMember, error) {
+	var members []*entities.ListMember
+	err := conn(ctx, r.db).
+		Where("shopping_list_id = ?", shoppingListID).
+		Order("created_at ASC, user_id").
+		Find(&members).Error
+	return members, err
+}
+
+// Update changes the role of an existing member
+func (r *PostgresListMemberRepository) Update(ctx context.Context, member *entities.ListMember) error {
+	result := conn(ctx, r.db).
+		Model(member).
+		Select("role", "updated_at").
+		Updates(member)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrMemberNotFound
+	}
+	return nil
+}
+
+// Delete removes a member from a shopping list
+func (r *PostgresListMemberRepository) Delete(ctx context.Context, shoppingListID, userID uuid.UUID) error {
+	result := conn(ctx, r.db).
+		Where("shopping_list_id = ? AND user_id = ?", shoppingListID, userID).
+		Delete(&entities.ListMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrMemberNotFound
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+func TestPostgresListMemberRepository(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPostgresListMemberRepository(db)
+	listRepo := NewPostgresShoppingListRepository(db)
+	ctx := context.Background()
+
+	owner := uuid.New()
+	list := entities.NewShoppingList("Groceries", "")
+	list.OwnerID = &owner
+	require.NoError(t, listRepo.Create(ctx, list))
+
+	editor := entities.NewListMember(list.ID, uuid.New(), entities.ListRoleEditor)
+	viewer := entities.NewListMember(list.ID, uuid.New(), entities.ListRoleViewer)
+	require.NoError(t, repo.Create(ctx, editor))
+	require.NoError(t, repo.Create(ctx, viewer))
+
+	// A concurrent share or invitation that slips past the service's check is still a duplicate
+	assert.Equal(t, entities.ErrDuplicateMember, repo.Create(ctx, entities.NewListMember(list.ID, editor.UserID, entities.ListRoleViewer)))
+
+	members, err := repo.GetByShoppingListID(ctx, list.ID)
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+
+	got, err := listRepo.GetByID(ctx, list.ID)
+	require.NoError(t, err)
+	role, ok := got.RoleOf(viewer.UserID)
+	assert.True(t, ok)
+	assert.Equal(t, entities.ListRoleViewer, role)
+
+	viewer.Role = entities.ListRoleOwner
+	require.NoError(t, repo.Update(ctx, viewer))
+	member, err := repo.Get(ctx, list.ID, viewer.UserID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.ListRoleOwner, member.Role)
+
+	missing := entities.NewListMember(list.ID, uuid.New(), entities.ListRoleViewer)
+	assert.Equal(t, entities.ErrMemberNotFound, repo.Update(ctx, missing))
+
+	require.NoError(t, repo.Delete(ctx, list.ID, editor.UserID))
+	_, err = repo.Get(ctx, list.ID, editor.UserID)
+	assert.Equal(t, entities.ErrMemberNotFound, err)
+	assert.Equal(t, entities.ErrMemberNotFound, repo.Delete(ctx, list.ID, editor.UserID))
+}
@@ -0,0 +1,80 @@
+package persistence
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+// PostgresPantryRepository implements the PantryRepository interface
+type PostgresPantryRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresPantryRepository creates a new PostgreSQL pantry repository
+func NewPostgresPantryRepository(db *gorm.DB) repositories.PantryRepository {
+	return &PostgresPantryRepository{db: db}
+}
+
+// Create creates a new pantry item
+func (r *PostgresPantryRepository) Create(ctx context.Context, item *entities.PantryItem) error {
+	return conn(ctx, r.db).Create(item).Error
+}
+
+// GetByID retrieves a pantry item by ID
+func (r *PostgresPantryRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.PantryItem, error) {
+	var item entities.PantryItem
+	err := conn(ctx, r.db).Where("id = ?", id).First(&item).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entities.ErrPantryItemNotFound
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+// GetAll retrieves the pantry items matching a query, soonest to expire first
+func (r *PostgresPantryRepository) GetAll(ctx context.Context, query repositories.PantryQuery) ([]*entities.PantryItem, error) {
+	db := conn(ctx, r.db).Scopes(ownedBy(query.OwnerID))
+	if query.Location != "" {
+		db = db.Where("LOWER(location) = ?", strings.ToLower(query.Location))
+	}
+	if query.ExpiresBefore != nil {
+		db = db.Where("expires_at < ?", *query.ExpiresBefore)
+	}
+
+	var items []*entities.PantryItem
+	err := db.Order("expires_at ASC NULLS LAST").Order("name ASC").Find(&items).Error
+	return items, err
+}
+
+// Update updates an existing pantry item
+func (r *PostgresPantryRepository) Update(ctx context.Context, item *entities.PantryItem) error {
+	result := conn(ctx, r.db).Model(item).
+		Select("name", "quantity", "unit", "location", "expires_at", "updated_at").
+		Updates(item)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrPantryItemNotFound
+	}
+	return nil
+}
+
+// Delete deletes a pantry item
+func (r *PostgresPantryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := conn(ctx, r.db).Where("id = ?", id).Delete(&entities.PantryItem{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrPantryItemNotFound
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDBForPantry(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(&entities.PantryItem{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestPostgresPantryRepository_CRUD(t *testing.T) {
+	db := setupTestDBForPantry(t)
+	repo := NewPostgresPantryRepository(db)
+	ctx := context.Background()
+
+	item := entities.NewPantryItem("Rice", 2, entities.UnitKilogram)
+	item.Location = "Cupboard"
+	require.NoError(t, repo.Create(ctx, item))
+
+	got, err := repo.GetByID(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Rice", got.Name)
+	assert.Equal(t, 2.0, got.Quantity)
+	assert.Equal(t, entities.UnitKilogram, got.Unit)
+
+	item.Quantity = 0
+	require.NoError(t, repo.Update(ctx, item))
+	got, err = repo.GetByID(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Zero(t, got.Quantity)
+
+	require.NoError(t, repo.Delete(ctx, item.ID))
+	_, err = repo.GetByID(ctx, item.ID)
+	assert.Equal(t, entities.ErrPantryItemNotFound, err)
+
+	assert.Equal(t, entities.ErrPantryItemNotFound, repo.Delete(ctx, uuid.New()))
+	assert.Equal(t, entities.ErrPantryItemNotFound, repo.Update(ctx, entities.NewPantryItem("Missing", 1, entities.UnitPieces)))
+}
+
+func TestPostgresPantryRepository_GetAll(t *testing.T) {
+	db := setupTestDBForPantry(t)
+	repo := NewPostgresPantryRepository(db)
+	ctx := context.Background()
+	day := func(d int) *time.Time {
+		date := time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC)
+		return &date
+	}
+
+	milk := entities.NewPantryItem("Milk", 1, entities.UnitLiter)
+	milk.Location = "Fridge"
+	milk.ExpiresAt = day(20)
+	yogurt := entities.NewPantryItem("Yogurt", 4, entities.UnitPieces)
+	yogurt.Location = "fridge"
+	yogurt.ExpiresAt = day(18)
+	rice := entities.NewPantryItem("Rice", 2, entities.UnitKilogram)
+	rice.Location = "Cupboard"
+	beans := entities.NewPantryItem("Beans", 3, entities.UnitPieces)
+	for _, item := range []*entities.PantryItem{milk, yogurt, rice, beans} {
+		require.NoError(t, repo.Create(ctx, item))
+	}
+
+	names := func(items []*entities.PantryItem) []string {
+		result := make([]string, len(items))
+		for i, item := range items {
+			result[i] = item.Name
+		}
+		return result
+	}
+
+	items, err := repo.GetAll(ctx, repositories.PantryQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Yogurt", "Milk", "Beans", "Rice"}, names(items))
+
+	items, err = repo.GetAll(ctx, repositories.PantryQuery{Location: "FRIDGE"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Yogurt", "Milk"}, names(items))
+
+	items, err = repo.GetAll(ctx, repositories.PantryQuery{ExpiresBefore: day(19)})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Yogurt"}, names(items))
+}
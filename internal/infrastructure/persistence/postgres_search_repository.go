@@ -0,0 +1,118 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+// PostgresSearchRepository implements the SearchRepository interface with PostgreSQL full-text search
+// over the search_vector columns of shopping lists and items
+type PostgresSearchRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresSearchRepository creates a new PostgreSQL full-text search repository
+func NewPostgresSearchRepository(db *gorm.DB) repositories.SearchRepository {
+	return &PostgresSearchRepository{db: db}
+}
+
+// NewSearchRepository creates the search repository suited to the database:
+// full-text search on PostgreSQL and plain pattern matching elsewhere
+func NewSearchRepository(db *gorm.DB) repositories.SearchRepository {
+	if db.Dialector.Name() == "postgres" {
+		return NewPostgresSearchRepository(db)
+	}
+	return NewLikeSearchRepository(db)
+}
+
+// rankedList is a shopping list matching a search, with its rank
+type rankedList struct {
+	entities.ShoppingList
+	Rank float64
+}
+
+// rankedItem is an item matching a search, with its rank
+type rankedItem struct {
+	entities.Item
+	Rank float64
+}
+
+// Search ranks the shopping lists and items matching a web-style query such as `batteries -aaa`
+func (r *PostgresSearchRepository) Search(ctx context.Context, query repositories.SearchQuery) ([]entities.SearchHit, error) {
+	db := conn(ctx, r.db)
+
+	var lists []rankedList
+	err := db.Raw(`
+		SELECT l.*, ts_rank(l.search_vector, q) AS rank
+		FROM shopping_lists l, websearch_to_tsquery('english', ?) q
+		WHERE l.deleted_at IS NULL AND l.search_vector @@ q
+		AND (CAST(? AS uuid) IS NULL OR l.owner_id = ?
+			OR l.id IN (SELECT shopping_list_id FROM list_members WHERE user_id = ?))
+		ORDER BY rank DESC
+		LIMIT ?`, query.Text, query.AccessibleTo, query.AccessibleTo, query.AccessibleTo, query.Limit).Scan(&lists).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var items []rankedItem
+	err = db.Raw(`
+		SELECT i.*, ts_rank(i.search_vector, q) AS rank
+		FROM items i
+		JOIN shopping_lists l ON l.id = i.shopping_list_id AND l.deleted_at IS NULL,
+		websearch_to_tsquery('english', ?) q
+		WHERE i.deleted_at IS NULL AND i.search_vector @@ q
+		AND (CAST(? AS uuid) IS NULL OR l.owner_id = ?
+			OR l.id IN (SELECT shopping_list_id FROM list_members WHERE user_id = ?))
+		ORDER BY rank DESC
+		LIMIT ?`, query.Text, query.AccessibleTo, query.AccessibleTo, query.AccessibleTo, query.Limit).Scan(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return collectSearchHits(db, lists, items, query.Limit)
+}
+
+// collectSearchHits merges ranked lists and items into the best hits, giving each item hit its list
+func collectSearchHits(db *gorm.DB, lists []rankedList, items []rankedItem, limit int) ([]entities.SearchHit, error) {
+	hits := make([]entities.SearchHit, 0, len(lists)+len(items))
+	for i := range lists {
+		hits = append(hits, entities.SearchHit{Kind: entities.SearchHitList, Rank: lists[i].Rank, List: &lists[i].ShoppingList})
+	}
+	for i := range items {
+		hits = append(hits, entities.SearchHit{Kind: entities.SearchHitItem, Rank: items[i].Rank, Item: &items[i].Item})
+	}
+
+	entities.SortSearchHits(hits)
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	var listIDs []uuid.UUID
+	for _, hit := range hits {
+		if hit.Item != nil {
+			listIDs = append(listIDs, hit.Item.ShoppingListID)
+		}
+	}
+	if len(listIDs) == 0 {
+		return hits, nil
+	}
+
+	var parents []*entities.ShoppingList
+	if err := db.Where("id IN ?", listIDs).Find(&parents).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]*entities.ShoppingList, len(parents))
+	for _, parent := range parents {
+		byID[parent.ID] = parent
+	}
+	for i := range hits {
+		if hits[i].Item != nil {
+			hits[i].List = byID[hits[i].Item.ShoppingListID]
+		}
+	}
+	return hits, nil
+}
@@ -0,0 +1,141 @@
+//go:build postgres
+
+package persistence
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	migrate "github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// setupPostgresTestDB migrates the database at TEST_DATABASE_URL and returns a transaction
+// that is rolled back when the test ends, so tests leave no rows behind
+func setupPostgresTestDB(t *testing.T) *gorm.DB {
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	m, err := migrate.New("file://../../../cmd/migrator/migrations", url)
+	require.NoError(t, err)
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		require.NoError(t, err)
+	}
+	sourceErr, dbErr := m.Close()
+	require.NoError(t, sourceErr)
+	require.NoError(t, dbErr)
+
+	db, err := gorm.Open(postgres.Open(url), &gorm.Config{})
+	require.NoError(t, err)
+	tx := db.Begin()
+	require.NoError(t, tx.Error)
+	t.Cleanup(func() { tx.Rollback() })
+	return tx
+}
+
+func TestNewSearchRepository_UsesFullTextSearchOnPostgres(t *testing.T) {
+	db := setupPostgresTestDB(t)
+
+	assert.IsType(t, &PostgresSearchRepository{}, NewSearchRepository(db))
+}
+
+func TestPostgresSearchRepository_Search(t *testing.T) {
+	db := setupPostgresTestDB(t)
+	repo := NewPostgresSearchRepository(db)
+	ctx := context.Background()
+
+	hardware := entities.NewShoppingList("Hardware store", "Batteries and bulbs")
+	groceries := entities.NewShoppingList("Groceries", "")
+	trashedList := entities.NewShoppingList("Old batteries run", "")
+	deletedAt := time.Now()
+	trashedList.DeletedAt = &deletedAt
+	for _, list := range []*entities.ShoppingList{hardware, groceries, trashedList} {
+		require.NoError(t, db.Create(list).Error)
+	}
+
+	aa := entities.NewItem("AA batteries", 8)
+	aa.ShoppingListID = hardware.ID
+	bread := entities.NewItem("Bread", 1)
+	bread.ShoppingListID = groceries.ID
+	trashedItem := entities.NewItem("AAA batteries", 4)
+	trashedItem.ShoppingListID = groceries.ID
+	trashedItem.DeletedAt = &deletedAt
+	orphan := entities.NewItem("9V batteries", 1)
+	orphan.ShoppingListID = trashedList.ID
+	for _, item := range []*entities.Item{aa, bread, trashedItem, orphan} {
+		require.NoError(t, db.Create(item).Error)
+	}
+
+	// Stemming matches "battery" to "batteries"; trashed lists and items are left out
+	hits, err := repo.Search(ctx, repositories.SearchQuery{Text: "battery", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, hits, 2)
+	ids := []uuid.UUID{}
+	for _, hit := range hits {
+		switch hit.Kind {
+		case entities.SearchHitList:
+			ids = append(ids, hit.List.ID)
+		case entities.SearchHitItem:
+			ids = append(ids, hit.Item.ID)
+			assert.Equal(t, hardware.ID, hit.List.ID)
+		}
+		assert.Greater(t, hit.Rank, 0.0)
+	}
+	assert.ElementsMatch(t, []uuid.UUID{hardware.ID, aa.ID}, ids)
+
+	// Web-style queries exclude words prefixed with a minus
+	hits, err = repo.Search(ctx, repositories.SearchQuery{Text: "batteries -bulbs", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, aa.ID, hits[0].Item.ID)
+
+	hits, err = repo.Search(ctx, repositories.SearchQuery{Text: "batteries", Limit: 1})
+	require.NoError(t, err)
+	assert.Len(t, hits, 1)
+}
+
+func TestPostgresSearchRepository_Search_AccessibleTo(t *testing.T) {
+	db := setupPostgresTestDB(t)
+	repo := NewPostgresSearchRepository(db)
+	ctx := context.Background()
+
+	owner := entities.NewUser("owner-"+uuid.NewString()+"@example.com", "hash")
+	member := entities.NewUser("member-"+uuid.NewString()+"@example.com", "hash")
+	stranger := entities.NewUser("stranger-"+uuid.NewString()+"@example.com", "hash")
+	for _, user := range []*entities.User{owner, member, stranger} {
+		require.NoError(t, db.Create(user).Error)
+	}
+
+	list := entities.NewShoppingList("Camping trip", "")
+	list.OwnerID = &owner.ID
+	require.NoError(t, db.Create(list).Error)
+	lantern := entities.NewItem("Lantern", 1)
+	lantern.ShoppingListID = list.ID
+	require.NoError(t, db.Create(lantern).Error)
+	require.NoError(t, db.Create(entities.NewListMember(list.ID, member.ID, entities.ListRoleViewer)).Error)
+
+	for _, userID := range []uuid.UUID{owner.ID, member.ID} {
+		hits, err := repo.Search(ctx, repositories.SearchQuery{Text: "lantern", AccessibleTo: &userID, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, hits, 1)
+		assert.Equal(t, lantern.ID, hits[0].Item.ID)
+		assert.Equal(t, list.ID, hits[0].List.ID)
+	}
+
+	hits, err := repo.Search(ctx, repositories.SearchQuery{Text: "lantern", AccessibleTo: &stranger.ID, Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+}
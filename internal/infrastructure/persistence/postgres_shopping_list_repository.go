@@ -3,11 +3,15 @@ package persistence
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
 	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // PostgresShoppingListRepository implements the ShoppingListRepository interface
@@ -22,13 +26,16 @@ func NewPostgresShoppingListRepository(db *gorm.DB) repositories.ShoppingListRep
 
 // Create creates a new shopping list
 func (r *PostgresShoppingListRepository) Create(ctx context.Context, list *entities.ShoppingList) error {
-	return r.db.WithContext(ctx).Create(list).Error
+	if list.Version == 0 {
+		list.Version = 1
+	}
+	return conn(ctx, r.db).Create(list).Error
 }
 
-// GetByID retrieves a shopping list by ID
+// GetByID retrieves a shopping list by ID along with its members, unless it is in the trash
 func (r *PostgresShoppingListRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.ShoppingList, error) {
 	var list entities.ShoppingList
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&list).Error
+	err := conn(ctx, r.db).Scopes(notTrashed).Preload("Members").Where("id = ?", id).First(&list).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, entities.ErrShoppingListNotFound
@@ -38,21 +45,104 @@ func (r *PostgresShoppingListRepository) GetByID(ctx context.Context, id uuid.UU
 	return &list, nil
 }
 
-// GetAll retrieves all shopping lists
-func (r *PostgresShoppingListRepository) GetAll(ctx context.Context) ([]*entities.ShoppingList, error) {
+// GetAll retrieves the shopping lists outside the trash that match a query, in its order.
+// A zero limit retrieves every matching list.
+func (r *PostgresShoppingListRepository) GetAll(
+	ctx context.Context,
+	query repositories.ShoppingListQuery,
+) ([]*entities.ShoppingList, error) {
+	sortBy := query.SortBy
+	if sortBy == "" {
+		sortBy = repositories.SortByCreatedAt
+	}
+	if !sortBy.IsValid() {
+		return nil, entities.ErrInvalidInput
+	}
+	column := string(sortBy)
+
+	db := conn(ctx, r.db).Scopes(notTrashed, accessibleTo(query.AccessibleTo))
+	if query.NameContains != "" {
+		db = db.Where("LOWER(name) LIKE ? ESCAPE '\\'", "%"+escapeLike(strings.ToLower(query.NameContains))+"%")
+	}
+	if query.UpdatedSince != nil {
+		db = db.Where("updated_at >= ?", *query.UpdatedSince)
+	}
+
+	direction, after := "ASC", ">"
+	if query.Descending {
+		direction, after = "DESC", "<"
+	}
+	if cursor := query.After; cursor != nil {
+		var key interface{} = cursor.Time
+		if sortBy == repositories.SortByName {
+			key = cursor.Name
+		}
+		db = db.Where(
+			fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))", column, after),
+			key, key, cursor.ID,
+		)
+	}
+
+	db = db.Order(column + " " + direction).Order("id " + direction)
+	if query.Limit > 0 {
+		db = db.Limit(query.Limit)
+	}
+
 	var lists []*entities.ShoppingList
-	err := r.db.WithContext(ctx).Find(&lists).Error
+	err := db.Find(&lists).Error
 	return lists, err
 }
 
-// Update updates an existing shopping list
+// Update updates the attributes of an existing shopping list if it still has the version
+// it was read at, and bumps its version. A stale version fails with ErrVersionConflict.
+// Its items are left untouched.
 func (r *PostgresShoppingListRepository) Update(ctx context.Context, list *entities.ShoppingList) error {
-	return r.db.WithContext(ctx).Save(list).Error
+	version := list.Version
+	list.Version = version + 1
+
+	result := conn(ctx, r.db).
+		Model(list).
+		Scopes(notTrashed).
+		Where("version = ?", version).
+		Select("*").
+		Omit("created_at", clause.Associations).
+		Updates(list)
+	if result.Error != nil {
+		list.Version = version
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		list.Version = version
+		return r.updateFailure(ctx, list.ID)
+	}
+	return nil
 }
 
-// Delete deletes a shopping list
+// updateFailure explains why a conditional update matched no rows
+func (r *PostgresShoppingListRepository) updateFailure(ctx context.Context, id uuid.UUID) error {
+	var count int64
+	err := conn(ctx, r.db).Model(&entities.ShoppingList{}).Scopes(notTrashed).Where("id = ?", id).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return entities.ErrShoppingListNotFound
+	}
+	return entities.ErrVersionConflict
+}
+
+// escapeLike escapes the LIKE wildcards of a literal search term
+func escapeLike(term string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(term)
+}
+
+// Delete moves a shopping list to the trash, taking its items along
 func (r *PostgresShoppingListRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&entities.ShoppingList{})
+	result := conn(ctx, r.db).
+		Model(&entities.ShoppingList{}).
+		Scopes(notTrashed).
+		Where("id = ?", id).
+		UpdateColumn("deleted_at", time.Now())
 	if result.Error != nil {
 		return result.Error
 	}
@@ -61,3 +151,68 @@ func (r *PostgresShoppingListRepository) Delete(ctx context.Context, id uuid.UUI
 	}
 	return nil
 }
+
+// GetTrashed retrieves the shopping lists of an owner in the trash, most recently deleted first
+func (r *PostgresShoppingListRepository) GetTrashed(ctx context.Context, ownerID *uuid.UUID) ([]*entities.ShoppingList, error) {
+	var lists []*entities.ShoppingList
+	err := conn(ctx, r.db).Scopes(trashed, ownedBy(ownerID)).Order("deleted_at DESC").Find(&lists).Error
+	return lists, err
+}
+
+// GetTrashedByID retrieves a shopping list in the trash by ID, along with its members
+func (r *PostgresShoppingListRepository) GetTrashedByID(ctx context.Context, id uuid.UUID) (*entities.ShoppingList, error) {
+	var list entities.ShoppingList
+	err := conn(ctx, r.db).Scopes(trashed).Preload("Members").Where("id = ?", id).First(&list).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entities.ErrShoppingListNotFound
+		}
+		return nil, err
+	}
+	return &list, nil
+}
+
+// Restore takes a shopping list out of the trash
+func (r *PostgresShoppingListRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	result := conn(ctx, r.db).
+		Model(&entities.ShoppingList{}).
+		Scopes(trashed).
+		Where("id = ?", id).
+		UpdateColumn("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrShoppingListNotFound
+	}
+	return nil
+}
+
+// PurgeTrashed permanently deletes the shopping lists that were moved to the trash before the given
+// time, along with their items, members and invitations. It returns the number of purged lists.
+func (r *PostgresShoppingListRepository) PurgeTrashed(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	var purged int64
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		expired := tx.Model(&entities.ShoppingList{}).
+			Select("id").
+			Where("deleted_at < ?", deletedBefore)
+
+		err := tx.Where("shopping_list_id IN (?)", expired).Delete(&entities.Item{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("shopping_list_id IN (?)", expired).Delete(&entities.ListMember{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("shopping_list_id IN (?)", expired).Delete(&entities.ListInvitation{}).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Where("deleted_at < ?", deletedBefore).Delete(&entities.ShoppingList{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	return purged, err
+}
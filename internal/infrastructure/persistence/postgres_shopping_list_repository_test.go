@@ -3,11 +3,13 @@ package persistence
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
@@ -16,7 +18,7 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	require.NoError(t, err)
 
-	err = db.AutoMigrate(&entities.ShoppingList{}, &entities.Item{})
+	err = db.AutoMigrate(&entities.ShoppingList{}, &entities.Item{}, &entities.ListMember{}, &entities.ListInvitation{})
 	require.NoError(t, err)
 
 	return db
@@ -140,7 +142,7 @@ func TestPostgresShoppingListRepository_GetAll(t *testing.T) {
 		require.NoError(t, err)
 	}
 
-	got, err := repo.GetAll(ctx)
+	got, err := repo.GetAll(ctx, repositories.ShoppingListQuery{})
 	assert.NoError(t, err)
 	assert.Len(t, got, 2)
 
@@ -155,6 +157,99 @@ func TestPostgresShoppingListRepository_GetAll(t *testing.T) {
 	}
 }
 
+func TestPostgresShoppingListRepository_GetAllQuery(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPostgresShoppingListRepository(db)
+	ctx := context.Background()
+
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	names := []string{"Weekly Groceries", "Hardware", "Party 100%", "groceries for mum"}
+	lists := make([]*entities.ShoppingList, len(names))
+	for i, name := range names {
+		lists[i] = entities.NewShoppingList(name, "")
+		lists[i].CreatedAt = base.Add(time.Duration(i) * time.Hour)
+		lists[i].UpdatedAt = base.Add(time.Duration(i) * time.Hour)
+		require.NoError(t, db.Create(lists[i]).Error)
+	}
+
+	namesOf := func(got []*entities.ShoppingList) []string {
+		result := make([]string, len(got))
+		for i, list := range got {
+			result[i] = list.Name
+		}
+		return result
+	}
+
+	t.Run("sorts by creation time by default", func(t *testing.T) {
+		got, err := repo.GetAll(ctx, repositories.ShoppingListQuery{})
+		require.NoError(t, err)
+		assert.Equal(t, names, namesOf(got))
+	})
+
+	t.Run("sorts by name descending", func(t *testing.T) {
+		got, err := repo.GetAll(ctx, repositories.ShoppingListQuery{SortBy: repositories.SortByName, Descending: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"groceries for mum", "Weekly Groceries", "Party 100%", "Hardware"}, namesOf(got))
+	})
+
+	t.Run("filters by name case-insensitively", func(t *testing.T) {
+		got, err := repo.GetAll(ctx, repositories.ShoppingListQuery{NameContains: "GROCERIES"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Weekly Groceries", "groceries for mum"}, namesOf(got))
+	})
+
+	t.Run("treats wildcards in the name filter literally", func(t *testing.T) {
+		got, err := repo.GetAll(ctx, repositories.ShoppingListQuery{NameContains: "0%"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Party 100%"}, namesOf(got))
+	})
+
+	t.Run("filters by update time", func(t *testing.T) {
+		since := base.Add(2 * time.Hour)
+		got, err := repo.GetAll(ctx, repositories.ShoppingListQuery{UpdatedSince: &since})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Party 100%", "groceries for mum"}, namesOf(got))
+	})
+
+	t.Run("resumes after a cursor", func(t *testing.T) {
+		got, err := repo.GetAll(ctx, repositories.ShoppingListQuery{
+			SortBy: repositories.SortByUpdatedAt,
+			After:  &repositories.ShoppingListCursor{Time: lists[1].UpdatedAt, ID: lists[1].ID},
+			Limit:  1,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Party 100%"}, namesOf(got))
+	})
+
+	t.Run("filters by owner and membership", func(t *testing.T) {
+		owner, friend := uuid.New(), uuid.New()
+		owned := entities.NewShoppingList("Mine", "")
+		owned.OwnerID = &owner
+		require.NoError(t, db.Create(owned).Error)
+		defer db.Delete(owned)
+		shared := entities.NewShoppingList("Shared", "")
+		shared.OwnerID = &friend
+		require.NoError(t, db.Create(shared).Error)
+		defer db.Delete(shared)
+		member := entities.NewListMember(shared.ID, owner, entities.ListRoleViewer)
+		require.NoError(t, db.Create(member).Error)
+		defer db.Delete(member)
+
+		got, err := repo.GetAll(ctx, repositories.ShoppingListQuery{AccessibleTo: &owner})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Mine", "Shared"}, namesOf(got))
+
+		got, err = repo.GetAll(ctx, repositories.ShoppingListQuery{AccessibleTo: &friend})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Shared"}, namesOf(got))
+	})
+
+	t.Run("rejects unknown sort fields", func(t *testing.T) {
+		_, err := repo.GetAll(ctx, repositories.ShoppingListQuery{SortBy: "description"})
+		assert.Equal(t, entities.ErrInvalidInput, err)
+	})
+}
+
 func TestPostgresShoppingListRepository_Update(t *testing.T) {
 	db := setupTestDB(t)
 	repo := NewPostgresShoppingListRepository(db)
@@ -183,6 +278,34 @@ func TestPostgresShoppingListRepository_Update(t *testing.T) {
 	assert.Equal(t, "Updated Description", updated.Description)
 }
 
+func TestPostgresShoppingListRepository_UpdateVersioning(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPostgresShoppingListRepository(db)
+	ctx := context.Background()
+
+	testList := entities.NewShoppingList("Groceries", "")
+	require.NoError(t, repo.Create(ctx, testList))
+
+	stale, err := repo.GetByID(ctx, testList.ID)
+	require.NoError(t, err)
+
+	testList.Name = "Weekly Groceries"
+	require.NoError(t, repo.Update(ctx, testList))
+	assert.Equal(t, 2, testList.Version)
+
+	stale.Name = "Party"
+	err = repo.Update(ctx, stale)
+	assert.Equal(t, entities.ErrVersionConflict, err)
+
+	stored, err := repo.GetByID(ctx, testList.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Weekly Groceries", stored.Name)
+	assert.Equal(t, 2, stored.Version)
+
+	missing := entities.NewShoppingList("Ghost", "")
+	assert.Equal(t, entities.ErrShoppingListNotFound, repo.Update(ctx, missing))
+}
+
 func TestPostgresShoppingListRepository_Delete(t *testing.T) {
 	db := setupTestDB(t)
 	repo := NewPostgresShoppingListRepository(db)
@@ -230,6 +353,63 @@ func TestPostgresShoppingListRepository_Delete(t *testing.T) {
 	}
 }
 
+func TestPostgresShoppingListRepository_TrashAndRestore(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPostgresShoppingListRepository(db)
+	ctx := context.Background()
+
+	kept := entities.NewShoppingList("Hardware", "")
+	trashedList := entities.NewShoppingList("Groceries", "")
+	require.NoError(t, repo.Create(ctx, kept))
+	require.NoError(t, repo.Create(ctx, trashedList))
+
+	require.NoError(t, repo.Delete(ctx, trashedList.ID))
+	assert.Equal(t, entities.ErrShoppingListNotFound, repo.Delete(ctx, trashedList.ID))
+
+	lists, err := repo.GetAll(ctx, repositories.ShoppingListQuery{})
+	require.NoError(t, err)
+	require.Len(t, lists, 1)
+	assert.Equal(t, kept.ID, lists[0].ID)
+
+	trashedList.Name = "Renamed"
+	assert.Equal(t, entities.ErrShoppingListNotFound, repo.Update(ctx, trashedList))
+
+	trash, err := repo.GetTrashed(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+	assert.Equal(t, trashedList.ID, trash[0].ID)
+
+	require.NoError(t, repo.Restore(ctx, trashedList.ID))
+	assert.Equal(t, entities.ErrShoppingListNotFound, repo.Restore(ctx, trashedList.ID))
+
+	restored, err := repo.GetByID(ctx, trashedList.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Groceries", restored.Name)
+	assert.Nil(t, restored.DeletedAt)
+}
+
+func TestPostgresShoppingListRepository_PurgeTrashed(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPostgresShoppingListRepository(db)
+	ctx := context.Background()
+
+	list := entities.NewShoppingList("Groceries", "")
+	require.NoError(t, repo.Create(ctx, list))
+	require.NoError(t, repo.Delete(ctx, list.ID))
+
+	purged, err := repo.PurgeTrashed(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, purged, "recently trashed lists are kept")
+
+	purged, err = repo.PurgeTrashed(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	trash, err := repo.GetTrashed(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, trash)
+}
+
 func TestPostgresShoppingListRepository_GetByID_DatabaseError(t *testing.T) {
 	// Test with a closed database connection to trigger database errors
 	db := setupTestDB(t)
@@ -263,3 +443,29 @@ func TestPostgresShoppingListRepository_Delete_DatabaseError(t *testing.T) {
 	assert.Error(t, err)
 	assert.NotEqual(t, entities.ErrShoppingListNotFound, err)
 }
+
+func TestPostgresShoppingListRepository_BudgetRoundTrip(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPostgresShoppingListRepository(db)
+	ctx := context.Background()
+
+	budget := int64(12550)
+	list := entities.NewShoppingList("Party", "")
+	list.Budget = &budget
+	list.Currency = "EUR"
+	require.NoError(t, repo.Create(ctx, list))
+
+	got, err := repo.GetByID(ctx, list.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.Budget)
+	assert.Equal(t, budget, *got.Budget)
+	assert.Equal(t, "EUR", got.Currency)
+
+	// Clearing the budget persists as NULL
+	got.Budget = nil
+	require.NoError(t, repo.Update(ctx, got))
+
+	got, err = repo.GetByID(ctx, list.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.Budget)
+}
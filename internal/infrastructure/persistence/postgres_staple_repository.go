@@ -0,0 +1,78 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+// PostgresStapleRepository implements the StapleRepository interface
+type PostgresStapleRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresStapleRepository creates a new PostgreSQL staple repository
+func NewPostgresStapleRepository(db *gorm.DB) repositories.StapleRepository {
+	return &PostgresStapleRepository{db: db}
+}
+
+// Create creates a new staple
+func (r *PostgresStapleRepository) Create(ctx context.Context, staple *entities.Staple) error {
+	return conn(ctx, r.db).Create(staple).Error
+}
+
+// GetByID retrieves a staple by ID
+func (r *PostgresStapleRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Staple, error) {
+	var staple entities.Staple
+	err := conn(ctx, r.db).Where("id = ?", id).First(&staple).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entities.ErrStapleNotFound
+		}
+		return nil, err
+	}
+	return &staple, nil
+}
+
+// GetAll retrieves the staples of an owner ordered by name
+func (r *PostgresStapleRepository) GetAll(ctx context.Context, ownerID *uuid.UUID) ([]*entities.Staple, error) {
+	var staples []*entities.Staple
+	err := conn(ctx, r.db).Scopes(ownedBy(ownerID)).Order("name ASC").Find(&staples).Error
+	return staples, err
+}
+
+// GetLow retrieves the staples of an owner whose quantity on hand is below their minimum, ordered by name
+func (r *PostgresStapleRepository) GetLow(ctx context.Context, ownerID *uuid.UUID) ([]*entities.Staple, error) {
+	var staples []*entities.Staple
+	err := conn(ctx, r.db).Scopes(ownedBy(ownerID)).Where("on_hand < min_quantity").Order("name ASC").Find(&staples).Error
+	return staples, err
+}
+
+// Update updates an existing staple
+func (r *PostgresStapleRepository) Update(ctx context.Context, staple *entities.Staple) error {
+	result := conn(ctx, r.db).Model(staple).
+		Select("name", "unit", "on_hand", "min_quantity", "shopping_list_id", "updated_at").
+		Updates(staple)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrStapleNotFound
+	}
+	return nil
+}
+
+// Delete deletes a staple
+func (r *PostgresStapleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := conn(ctx, r.db).Where("id = ?", id).Delete(&entities.Staple{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entities.ErrStapleNotFound
+	}
+	return nil
+}
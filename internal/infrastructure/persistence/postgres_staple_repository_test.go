@@ -0,0 +1,72 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDBForStaples(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(&entities.Staple{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestPostgresStapleRepository_CRUD(t *testing.T) {
+	db := setupTestDBForStaples(t)
+	repo := NewPostgresStapleRepository(db)
+	ctx := context.Background()
+
+	staple := entities.NewStaple("Coffee", entities.UnitPack, 2, 1, uuid.New())
+	require.NoError(t, repo.Create(ctx, staple))
+
+	staple.OnHand = 0
+	staple.MinQuantity = 2
+	require.NoError(t, repo.Update(ctx, staple))
+
+	got, err := repo.GetByID(ctx, staple.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, got.OnHand)
+	assert.Equal(t, 2.0, got.MinQuantity)
+
+	require.NoError(t, repo.Delete(ctx, staple.ID))
+	_, err = repo.GetByID(ctx, staple.ID)
+	assert.Equal(t, entities.ErrStapleNotFound, err)
+	assert.Equal(t, entities.ErrStapleNotFound, repo.Update(ctx, staple))
+	assert.Equal(t, entities.ErrStapleNotFound, repo.Delete(ctx, staple.ID))
+}
+
+func TestPostgresStapleRepository_GetLow(t *testing.T) {
+	db := setupTestDBForStaples(t)
+	repo := NewPostgresStapleRepository(db)
+	ctx := context.Background()
+	listID := uuid.New()
+
+	for _, staple := range []*entities.Staple{
+		entities.NewStaple("Toilet paper", entities.UnitPack, 0, 1, listID),
+		entities.NewStaple("Coffee", entities.UnitPack, 1, 2, listID),
+		entities.NewStaple("Rice", entities.UnitKilogram, 1, 1, listID),
+	} {
+		require.NoError(t, repo.Create(ctx, staple))
+	}
+
+	all, err := repo.GetAll(ctx, nil)
+	require.NoError(t, err)
+	assert.Len(t, all, 3)
+
+	low, err := repo.GetLow(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, low, 2)
+	assert.Equal(t, "Coffee", low[0].Name)
+	assert.Equal(t, "Toilet paper", low[1].Name)
+}
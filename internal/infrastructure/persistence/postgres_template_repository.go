@@ -0,0 +1,126 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+// PostgresTemplateRepository implements the TemplateRepository interface
+type PostgresTemplateRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresTemplateRepository creates a new PostgreSQL template repository
+func NewPostgresTemplateRepository(db *gorm.DB) repositories.TemplateRepository {
+	return &PostgresTemplateRepository{db: db}
+}
+
+// Create creates a new template together with its items
+func (r *PostgresTemplateRepository) Create(ctx context.Context, template *entities.Template) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Items").Create(template).Error; err != nil {
+			return err
+		}
+		return createTemplateItems(tx, template.Items)
+	})
+}
+
+// GetByID retrieves a template and its items by ID
+func (r *PostgresTemplateRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Template, error) {
+	var template entities.Template
+	err := conn(ctx, r.db).Preload("Items", orderTemplateItems).Where("id = ?", id).First(&template).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entities.ErrTemplateNotFound
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+// GetAll retrieves the templates of an owner and their items, ordered by name
+func (r *PostgresTemplateRepository) GetAll(ctx context.Context, ownerID *uuid.UUID) ([]*entities.Template, error) {
+	var templates []*entities.Template
+	err := conn(ctx, r.db).Scopes(ownedBy(ownerID)).Preload("Items", orderTemplateItems).Order("name ASC").Find(&templates).Error
+	return templates, err
+}
+
+// Update updates an existing template and replaces its items
+func (r *PostgresTemplateRepository) Update(ctx context.Context, template *entities.Template) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(template).
+			Select("name", "description", "budget", "currency", "recurrence", "next_run_at", "updated_at").
+			Updates(template)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return entities.ErrTemplateNotFound
+		}
+
+		if err := tx.Where("template_id = ?", template.ID).Delete(&entities.TemplateItem{}).Error; err != nil {
+			return err
+		}
+		return createTemplateItems(tx, template.Items)
+	})
+}
+
+// Delete deletes a template and its items
+func (r *PostgresTemplateRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("template_id = ?", id).Delete(&entities.TemplateItem{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ?", id).Delete(&entities.Template{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return entities.ErrTemplateNotFound
+		}
+		return nil
+	})
+}
+
+// GetDue retrieves the recurring templates whose next run is at or before now, oldest first.
+// Templates without an owner are skipped, as nobody could see the lists they would create.
+func (r *PostgresTemplateRepository) GetDue(ctx context.Context, now time.Time) ([]*entities.Template, error) {
+	var templates []*entities.Template
+	err := conn(ctx, r.db).Preload("Items", orderTemplateItems).
+		Where("next_run_at IS NOT NULL AND next_run_at <= ?", now).
+		Where("owner_id IS NOT NULL").
+		Order("next_run_at ASC").
+		Find(&templates).Error
+	return templates, err
+}
+
+// AdvanceSchedule moves the next run of a template from due to next, or clears it when next is nil.
+// It reports false when the run is no longer due, because another scheduler already advanced it.
+func (r *PostgresTemplateRepository) AdvanceSchedule(ctx context.Context, id uuid.UUID, due time.Time, next *time.Time) (bool, error) {
+	result := conn(ctx, r.db).Model(&entities.Template{}).
+		Where("id = ? AND next_run_at = ?", id, due).
+		Update("next_run_at", next)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// createTemplateItems stores the items of a template
+func createTemplateItems(tx *gorm.DB, items []entities.TemplateItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	return tx.Create(&items).Error
+}
+
+// orderTemplateItems preloads the items of a template in position order
+func orderTemplateItems(db *gorm.DB) *gorm.DB {
+	return db.Order("position ASC")
+}
@@ -0,0 +1,189 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDBForTemplates(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(&entities.Template{}, &entities.TemplateItem{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func newTestTemplate(name string, itemNames ...string) *entities.Template {
+	template := entities.NewTemplate(name, "")
+	items := make([]entities.TemplateItem, len(itemNames))
+	for i, itemName := range itemNames {
+		items[i] = entities.TemplateItem{Name: itemName, Quantity: 1, Unit: entities.UnitPieces}
+	}
+	template.SetItems(items)
+	return template
+}
+
+func TestPostgresTemplateRepository_CreateAndGet(t *testing.T) {
+	db := setupTestDBForTemplates(t)
+	repo := NewPostgresTemplateRepository(db)
+	ctx := context.Background()
+
+	template := newTestTemplate("Weekly basics", "Milk", "Bread", "Eggs")
+	require.NoError(t, repo.Create(ctx, template))
+
+	got, err := repo.GetByID(ctx, template.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Weekly basics", got.Name)
+	require.Len(t, got.Items, 3)
+	assert.Equal(t, []string{"Milk", "Bread", "Eggs"}, []string{got.Items[0].Name, got.Items[1].Name, got.Items[2].Name})
+
+	_, err = repo.GetByID(ctx, uuid.New())
+	assert.Equal(t, entities.ErrTemplateNotFound, err)
+}
+
+func TestPostgresTemplateRepository_GetAll(t *testing.T) {
+	db := setupTestDBForTemplates(t)
+	repo := NewPostgresTemplateRepository(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Create(ctx, newTestTemplate("Weekly basics", "Milk")))
+	require.NoError(t, repo.Create(ctx, newTestTemplate("BBQ party", "Sausages", "Charcoal")))
+
+	templates, err := repo.GetAll(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, templates, 2)
+	assert.Equal(t, "BBQ party", templates[0].Name)
+	assert.Len(t, templates[0].Items, 2)
+	assert.Equal(t, "Weekly basics", templates[1].Name)
+	assert.Len(t, templates[1].Items, 1)
+}
+
+func TestPostgresTemplateRepository_GetAll_ScopedToOwner(t *testing.T) {
+	db := setupTestDBForTemplates(t)
+	repo := NewPostgresTemplateRepository(db)
+	ctx := context.Background()
+
+	owner := uuid.New()
+	mine := newTestTemplate("Weekly basics", "Milk")
+	mine.OwnerID = &owner
+	theirs := newTestTemplate("BBQ party", "Sausages")
+	other := uuid.New()
+	theirs.OwnerID = &other
+	require.NoError(t, repo.Create(ctx, mine))
+	require.NoError(t, repo.Create(ctx, theirs))
+
+	templates, err := repo.GetAll(ctx, &owner)
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	assert.Equal(t, mine.ID, templates[0].ID)
+}
+
+func TestPostgresTemplateRepository_Update(t *testing.T) {
+	db := setupTestDBForTemplates(t)
+	repo := NewPostgresTemplateRepository(db)
+	ctx := context.Background()
+
+	template := newTestTemplate("BBQ", "Charcoal", "Buns")
+	require.NoError(t, repo.Create(ctx, template))
+
+	template.Name = "BBQ party"
+	template.SetItems([]entities.TemplateItem{{Name: "Sausages", Quantity: 12, Unit: entities.UnitPieces}})
+	require.NoError(t, repo.Update(ctx, template))
+
+	got, err := repo.GetByID(ctx, template.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "BBQ party", got.Name)
+	require.Len(t, got.Items, 1)
+	assert.Equal(t, "Sausages", got.Items[0].Name)
+
+	var stored int64
+	require.NoError(t, db.Model(&entities.TemplateItem{}).Count(&stored).Error)
+	assert.Equal(t, int64(1), stored)
+
+	missing := newTestTemplate("Missing")
+	assert.Equal(t, entities.ErrTemplateNotFound, repo.Update(ctx, missing))
+}
+
+func TestPostgresTemplateRepository_Delete(t *testing.T) {
+	db := setupTestDBForTemplates(t)
+	repo := NewPostgresTemplateRepository(db)
+	ctx := context.Background()
+
+	template := newTestTemplate("Weekly basics", "Milk", "Bread")
+	require.NoError(t, repo.Create(ctx, template))
+
+	require.NoError(t, repo.Delete(ctx, template.ID))
+
+	_, err := repo.GetByID(ctx, template.ID)
+	assert.Equal(t, entities.ErrTemplateNotFound, err)
+
+	var stored int64
+	require.NoError(t, db.Model(&entities.TemplateItem{}).Count(&stored).Error)
+	assert.Zero(t, stored)
+
+	assert.Equal(t, entities.ErrTemplateNotFound, repo.Delete(ctx, uuid.New()))
+}
+
+func TestPostgresTemplateRepository_Schedule(t *testing.T) {
+	db := setupTestDBForTemplates(t)
+	repo := NewPostgresTemplateRepository(db)
+	ctx := context.Background()
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+
+	due := now.Add(-time.Hour)
+	later := now.Add(time.Hour)
+	owner := uuid.New()
+	weekly := newTestTemplate("Weekly basics", "Milk")
+	weekly.Recurrence = "FREQ=WEEKLY"
+	weekly.NextRunAt = &due
+	weekly.OwnerID = &owner
+	upcoming := newTestTemplate("Monthly")
+	upcoming.Recurrence = "FREQ=MONTHLY"
+	upcoming.NextRunAt = &later
+	upcoming.OwnerID = &owner
+	// Templates from before accounts have no owner and are never materialized
+	ownerless := newTestTemplate("Ownerless")
+	ownerless.Recurrence = "FREQ=DAILY"
+	ownerless.NextRunAt = &due
+	for _, template := range []*entities.Template{weekly, upcoming, ownerless, newTestTemplate("One-off")} {
+		require.NoError(t, repo.Create(ctx, template))
+	}
+
+	templates, err := repo.GetDue(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	assert.Equal(t, weekly.ID, templates[0].ID)
+	assert.Len(t, templates[0].Items, 1)
+
+	next := due.AddDate(0, 0, 7)
+	claimed, err := repo.AdvanceSchedule(ctx, weekly.ID, due, &next)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	// A second scheduler working from the same due run loses the claim
+	claimed, err = repo.AdvanceSchedule(ctx, weekly.ID, due, &next)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	templates, err = repo.GetDue(ctx, now)
+	require.NoError(t, err)
+	assert.Empty(t, templates)
+
+	claimed, err = repo.AdvanceSchedule(ctx, weekly.ID, next, nil)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	got, err := repo.GetByID(ctx, weekly.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.NextRunAt)
+}
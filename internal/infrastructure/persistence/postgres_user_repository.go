@@ -0,0 +1,86 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+// PostgresUserRepository implements the UserRepository interface
+type PostgresUserRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresUserRepository creates a new PostgreSQL user repository
+func NewPostgresUserRepository(db *gorm.DB) repositories.UserRepository {
+	return &PostgresUserRepository{db: db}
+}
+
+// Create creates a new user; an email that is already registered fails with ErrDuplicateEmail
+func (r *PostgresUserRepository) Create(ctx context.Context, user *entities.User) error {
+	err := conn(ctx, r.db).Create(user).Error
+	if err != nil && isDuplicateKey(r.db, err) {
+		return entities.ErrDuplicateEmail
+	}
+	return err
+}
+
+// GetByID retrieves a user by ID
+func (r *PostgresUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	return r.getWhere(ctx, "id = ?", id)
+}
+
+// GetByEmail retrieves a user by normalized email
+func (r *PostgresUserRepository) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
+	return r.getWhere(ctx, "email = ?", email)
+}
+
+// getWhere retrieves the user matching a condition
+func (r *PostgresUserRepository) getWhere(ctx context.Context, condition string, value interface{}) (*entities.User, error) {
+	var user entities.User
+	err := conn(ctx, r.db).Where(condition, value).First(&user).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, entities.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// unownedModels are the records that got an owner when accounts were introduced
+var unownedModels = []interface{}{
+	&entities.ShoppingList{},
+	&entities.Template{},
+	&entities.PantryItem{},
+	&entities.Staple{},
+}
+
+// AdoptUnowned assigns every record without an owner, trashed lists included, to a user.
+// Categories named like one the user already has are left alone.
+func (r *PostgresUserRepository) AdoptUnowned(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var adopted int64
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for _, model := range unownedModels {
+			result := tx.Model(model).Where("owner_id IS NULL").UpdateColumn("owner_id", userID)
+			if result.Error != nil {
+				return result.Error
+			}
+			adopted += result.RowsAffected
+		}
+
+		result := tx.Model(&entities.Category{}).
+			Where("owner_id IS NULL").
+			Where("LOWER(name) NOT IN (SELECT LOWER(name) FROM categories WHERE owner_id = ?)", userID).
+			UpdateColumn("owner_id", userID)
+		if result.Error != nil {
+			return result.Error
+		}
+		adopted += result.RowsAffected
+		return nil
+	})
+	return adopted, err
+}
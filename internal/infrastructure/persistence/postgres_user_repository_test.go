@@ -0,0 +1,88 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDBForUsers(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(&entities.User{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestPostgresUserRepository(t *testing.T) {
+	db := setupTestDBForUsers(t)
+	repo := NewPostgresUserRepository(db)
+	ctx := context.Background()
+
+	user := entities.NewUser("ana@example.com", "hash")
+	require.NoError(t, repo.Create(ctx, user))
+
+	got, err := repo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "ana@example.com", got.Email)
+	assert.Equal(t, "hash", got.PasswordHash)
+
+	got, err = repo.GetByEmail(ctx, "ana@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, got.ID)
+
+	_, err = repo.GetByID(ctx, uuid.New())
+	assert.Equal(t, entities.ErrUserNotFound, err)
+	_, err = repo.GetByEmail(ctx, "bob@example.com")
+	assert.Equal(t, entities.ErrUserNotFound, err)
+
+	// Emails are unique, even when a concurrent registration slips past the service's check
+	assert.Equal(t, entities.ErrDuplicateEmail, repo.Create(ctx, entities.NewUser("ana@example.com", "other")))
+}
+
+func TestPostgresUserRepository_AdoptUnowned(t *testing.T) {
+	db := setupTestDBForUsers(t)
+	require.NoError(t, db.AutoMigrate(&entities.ShoppingList{}, &entities.Template{}, &entities.PantryItem{}, &entities.Staple{}, &entities.Category{}))
+	repo := NewPostgresUserRepository(db)
+	ctx := context.Background()
+
+	ana := entities.NewUser("ana@example.com", "hash")
+	bob := entities.NewUser("bob@example.com", "hash")
+	require.NoError(t, repo.Create(ctx, ana))
+	require.NoError(t, repo.Create(ctx, bob))
+
+	legacy := entities.NewShoppingList("Legacy", "")
+	trashed := entities.NewShoppingList("Trashed", "")
+	deletedAt := time.Now()
+	trashed.DeletedAt = &deletedAt
+	owned := entities.NewShoppingList("Bob's", "")
+	owned.OwnerID = &bob.ID
+	require.NoError(t, db.Create(legacy).Error)
+	require.NoError(t, db.Create(trashed).Error)
+	require.NoError(t, db.Create(owned).Error)
+
+	adopted, err := repo.AdoptUnowned(ctx, ana.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), adopted)
+
+	var lists []entities.ShoppingList
+	require.NoError(t, db.Order("name").Find(&lists).Error)
+	require.Len(t, lists, 3)
+	assert.Equal(t, bob.ID, *lists[0].OwnerID)
+	assert.Equal(t, ana.ID, *lists[1].OwnerID)
+	assert.Equal(t, ana.ID, *lists[2].OwnerID)
+
+	// Running it again finds nothing left to adopt
+	adopted, err = repo.AdoptUnowned(ctx, bob.ID)
+	require.NoError(t, err)
+	assert.Zero(t, adopted)
+}
@@ -0,0 +1,49 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// notTrashed restricts a query to rows that have not been moved to the trash
+func notTrashed(db *gorm.DB) *gorm.DB {
+	return db.Where("deleted_at IS NULL")
+}
+
+// trashed restricts a query to rows that have been moved to the trash
+func trashed(db *gorm.DB) *gorm.DB {
+	return db.Where("deleted_at IS NOT NULL")
+}
+
+// ownedBy restricts a query to the rows of an owner; a nil owner keeps every row
+func ownedBy(ownerID *uuid.UUID) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if ownerID == nil {
+			return db
+		}
+		return db.Where("owner_id = ?", *ownerID)
+	}
+}
+
+// accessibleTo restricts a query of shopping lists to those a user owns or is a member of;
+// a nil user keeps every list. Columns are qualified, so that the scope also works on joins.
+func accessibleTo(userID *uuid.UUID) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if userID == nil {
+			return db
+		}
+		return db.Where(
+			"(shopping_lists.owner_id = ? OR shopping_lists.id IN (SELECT shopping_list_id FROM list_members WHERE user_id = ?))",
+			*userID, *userID,
+		)
+	}
+}
+
+// usableAt restricts a query of invitations to those that are unexpired at a time and have uses left
+func usableAt(now time.Time) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("expires_at > ? AND (max_uses IS NULL OR uses < max_uses)", now)
+	}
+}
@@ -0,0 +1,37 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+	"gorm.io/gorm"
+)
+
+// txKey is the context key under which a running transaction is stored
+type txKey struct{}
+
+// GormTransactor implements the Transactor interface with GORM transactions
+type GormTransactor struct {
+	db *gorm.DB
+}
+
+// NewGormTransactor creates a new GORM transactor
+func NewGormTransactor(db *gorm.DB) repositories.Transactor {
+	return &GormTransactor{db: db}
+}
+
+// WithinTransaction runs fn in a transaction carried by its context.
+// Nested calls run in a savepoint of the enclosing transaction.
+func (t *GormTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return conn(ctx, t.db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// conn returns the transaction carried by the context, or db when there is none, bound to the context
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
@@ -0,0 +1,62 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/repositories"
+)
+
+func TestGormTransactor_WithinTransaction(t *testing.T) {
+	db := setupTestDB(t)
+	transactor := NewGormTransactor(db)
+	listRepo := NewPostgresShoppingListRepository(db)
+	itemRepo := NewPostgresItemRepository(db)
+	ctx := context.Background()
+
+	t.Run("commits the work of every repository", func(t *testing.T) {
+		list := entities.NewShoppingList("Committed", "")
+		item := entities.NewItem("Milk", 1)
+		item.ShoppingListID = list.ID
+
+		err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := listRepo.Create(ctx, list); err != nil {
+				return err
+			}
+			return itemRepo.ApplyBatch(ctx, repositories.ItemBatch{Create: []*entities.Item{item}})
+		})
+		require.NoError(t, err)
+
+		_, err = listRepo.GetByID(ctx, list.ID)
+		assert.NoError(t, err)
+		_, err = itemRepo.GetByID(ctx, item.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rolls back the work of every repository", func(t *testing.T) {
+		list := entities.NewShoppingList("Rolled Back", "")
+		item := entities.NewItem("Bread", 1)
+		item.ShoppingListID = list.ID
+		failure := errors.New("failure")
+
+		err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := listRepo.Create(ctx, list); err != nil {
+				return err
+			}
+			if err := itemRepo.Create(ctx, item); err != nil {
+				return err
+			}
+			return failure
+		})
+		assert.Equal(t, failure, err)
+
+		_, err = listRepo.GetByID(ctx, list.ID)
+		assert.Equal(t, entities.ErrShoppingListNotFound, err)
+		_, err = itemRepo.GetByID(ctx, item.ID)
+		assert.Equal(t, entities.ErrItemNotFound, err)
+	})
+}
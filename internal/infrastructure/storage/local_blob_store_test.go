@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+func TestLocalBlobStore(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "blobs")
+	store, err := NewLocalBlobStore(root)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, store.Put(ctx, "items/1/photo", []byte("first")))
+	require.NoError(t, store.Put(ctx, "items/1/photo", []byte("second")))
+
+	data, err := store.Get(ctx, "items/1/photo")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("second"), data)
+
+	stored, err := os.ReadFile(filepath.Join(root, "items", "1", "photo"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("second"), stored)
+
+	require.NoError(t, store.Delete(ctx, "items/1/photo"))
+	_, err = store.Get(ctx, "items/1/photo")
+	assert.Equal(t, entities.ErrBlobNotFound, err)
+	assert.NoError(t, store.Delete(ctx, "items/1/photo"))
+
+	// Temporary files never outlive a write
+	entries, err := os.ReadDir(filepath.Join(root, "items", "1"))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestLocalBlobStore_RejectsKeysOutsideRoot(t *testing.T) {
+	store, err := NewLocalBlobStore(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	for _, key := range []string{"../escape", "/etc/passwd", "items/../../escape", ""} {
+		assert.Equal(t, entities.ErrInvalidInput, store.Put(ctx, key, []byte("x")), key)
+		_, err := store.Get(ctx, key)
+		assert.Equal(t, entities.ErrInvalidInput, err, key)
+	}
+}
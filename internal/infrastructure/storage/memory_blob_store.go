@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"context"
+	"sync"
+
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+// MemoryBlobStore implements the BlobStore interface in memory, for tests
+type MemoryBlobStore struct {
+	mu    sync.RWMutex
+	blobs map[string][]byte
+}
+
+// NewMemoryBlobStore creates an empty in-memory blob store
+func NewMemoryBlobStore() *MemoryBlobStore {
+	return &MemoryBlobStore{blobs: make(map[string][]byte)}
+}
+
+// Put stores a copy of a blob, replacing any blob with the same key
+func (s *MemoryBlobStore) Put(_ context.Context, key string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blobs[key] = append([]byte(nil), data...)
+	return nil
+}
+
+// Get returns a copy of a blob
+func (s *MemoryBlobStore) Get(_ context.Context, key string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	data, ok := s.blobs[key]
+	if !ok {
+		return nil, entities.ErrBlobNotFound
+	}
+	return append([]byte(nil), data...), nil
+}
+
+// Delete removes a blob
+func (s *MemoryBlobStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.blobs, key)
+	return nil
+}
+
+// Keys returns the keys of the stored blobs
+func (s *MemoryBlobStore) Keys() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	keys := make([]string, 0, len(s.blobs))
+	for key := range s.blobs {
+		keys = append(keys, key)
+	}
+	return keys
+}
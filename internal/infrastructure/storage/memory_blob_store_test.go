@@ -0,0 +1,29 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uriberma/go-shopping-list-api/internal/domain/entities"
+)
+
+func TestMemoryBlobStore(t *testing.T) {
+	store := NewMemoryBlobStore()
+	ctx := context.Background()
+
+	data := []byte("photo")
+	require.NoError(t, store.Put(ctx, "items/1/photo", data))
+	data[0] = 'P'
+
+	got, err := store.Get(ctx, "items/1/photo")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("photo"), got)
+	assert.Equal(t, []string{"items/1/photo"}, store.Keys())
+
+	require.NoError(t, store.Delete(ctx, "items/1/photo"))
+	_, err = store.Get(ctx, "items/1/photo")
+	assert.Equal(t, entities.ErrBlobNotFound, err)
+	assert.Empty(t, store.Keys())
+}